@@ -0,0 +1,41 @@
+// backfill, ses bilgileri (süre, bitrate, örnekleme hızı, kanal sayısı, boyut ve codec)
+// kaydedilmeden önce yüklenmiş podcastlerin dosyalarını depodan okuyarak bu bilgileri doldurur.
+// Yalnızca duration_ms değeri 0 olan podcastler işlendiğinden tekrar çalıştırılması güvenlidir.
+//
+//	go run ./cmd/backfill -batch 100
+//	go run ./cmd/backfill -dry-run
+package main
+
+import (
+	"flag"
+	"log"
+	"shortcast/internal/config"
+	"shortcast/internal/repository"
+	"shortcast/internal/service"
+	"shortcast/internal/storage"
+)
+
+func main() {
+	batchSize := flag.Int("batch", 100, "her seferde veritabanından okunacak podcast sayısı")
+	dryRun := flag.Bool("dry-run", false, "okunan bilgileri yalnızca yazdır, veritabanını güncelleme")
+	flag.Parse()
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatalf("Config yüklenemedi: %v", err)
+	}
+
+	db := config.ConnectDB(cfg)
+	store, err := storage.New(cfg.Storage)
+	if err != nil {
+		log.Fatalf("Depolama sürücüsü oluşturulamadı: %v", err)
+	}
+
+	backfill := service.NewAudioBackfillService(repository.NewPodcastRepository(db), service.NewStorageService(store, repository.NewBlobRepository(db)))
+	result, err := backfill.Run(*batchSize, *dryRun)
+	if err != nil {
+		log.Fatalf("Backfill yarıda kaldı (%d güncellendi, %d hatalı): %v", result.Updated, result.Failed, err)
+	}
+
+	log.Printf("Backfill tamamlandı: %d podcast güncellendi, %d podcast okunamadı", result.Updated, result.Failed)
+}
@@ -0,0 +1,50 @@
+// gc, depodaki audio/, covers/, avatars/ ve uploads/ öneklerini tarayarak hiçbir podcast'e,
+// kullanıcıya veya tamamlanmamış yüklemeye ait olmayan sahipsiz dosyaları siler. Son grace süresi içinde
+// değiştirilmiş dosyalara dokunulmadığından işlenmekte olan yüklemeler etkilenmez; grace,
+// bir işin kuyrukta bekleyebileceği süreden uzun olmalıdır. Tekrar çalıştırılması güvenlidir.
+//
+//	go run ./cmd/gc -dry-run
+//	go run ./cmd/gc -grace 72h
+package main
+
+import (
+	"flag"
+	"log"
+	"shortcast/internal/config"
+	"shortcast/internal/repository"
+	"shortcast/internal/service"
+	"shortcast/internal/storage"
+	"time"
+)
+
+func main() {
+	grace := flag.Duration("grace", 24*time.Hour, "bu süreden daha yeni dosyalar silinmez")
+	batchSize := flag.Int("batch", 1000, "her seferde veritabanından okunacak podcast sayısı")
+	dryRun := flag.Bool("dry-run", false, "sahipsiz dosyaları yalnızca yazdır, silme")
+	flag.Parse()
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatalf("Config yüklenemedi: %v", err)
+	}
+
+	db := config.ConnectDB(cfg)
+	store, err := storage.New(cfg.Storage)
+	if err != nil {
+		log.Fatalf("Depolama sürücüsü oluşturulamadı: %v", err)
+	}
+
+	blobRepo := repository.NewBlobRepository(db)
+	gc := service.NewStorageGCService(repository.NewPodcastRepository(db), repository.NewUserRepository(db), blobRepo, service.NewStorageService(store, blobRepo))
+	result, err := gc.Run(*grace, *batchSize, *dryRun)
+	if err != nil {
+		log.Fatalf("GC yarıda kaldı (%d dosya tarandı, %d silindi): %v", result.Scanned, result.Deleted, err)
+	}
+
+	if *dryRun {
+		log.Printf("GC tamamlandı (dry-run): %d dosya tarandı, %d sahipsiz dosya (%d bayt) bulundu", result.Scanned, result.Orphaned, result.Bytes)
+		return
+	}
+	log.Printf("GC tamamlandı: %d dosya tarandı, %d sahipsiz dosyadan (%d bayt) %d tanesi silindi, %d tanesi silinemedi",
+		result.Scanned, result.Orphaned, result.Bytes, result.Deleted, result.Failed)
+}
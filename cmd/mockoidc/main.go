@@ -0,0 +1,237 @@
+// mockoidc, OIDC girişini yerelde denemek için kullanılan basit bir OpenID Connect sağlayıcısıdır.
+// Her isteği onaylar; kullanıcı bilgileri giriş formundan veya authorize isteğindeki
+// sub, email, email_verified ve name parametrelerinden alınır. Üretimde kullanılmamalıdır.
+//
+//	go run ./cmd/mockoidc -addr :9000
+//
+//	OIDC_PROVIDERS=mock
+//	OIDC_MOCK_ISSUER=http://localhost:9000
+//	OIDC_MOCK_CLIENT_ID=shortcast
+package main
+
+import (
+	"crypto/subtle"
+	"encoding/base64"
+	"flag"
+	"fmt"
+	"html"
+	"log"
+	"net/url"
+	"shortcast/internal/oidc"
+	"shortcast/internal/utils"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type authorization struct {
+	clientID      string
+	redirectURI   string
+	codeChallenge string
+	nonce         string
+	claims        jwt.MapClaims
+	expiresAt     time.Time
+}
+
+type server struct {
+	issuer string
+	keys   *utils.KeySet
+
+	mu    sync.Mutex
+	codes map[string]authorization
+}
+
+func main() {
+	addr := flag.String("addr", ":9000", "dinlenecek adres")
+	issuer := flag.String("issuer", "http://localhost:9000", "token'lara yazılacak issuer adresi")
+	flag.Parse()
+
+	keys, err := utils.LoadKeySet(utils.KeySetOptions{Algorithm: "RS256", Issuer: *issuer})
+	if err != nil {
+		log.Fatalf("Anahtar üretilemedi: %v", err)
+	}
+
+	s := &server{
+		issuer: strings.TrimSuffix(*issuer, "/"),
+		keys:   keys,
+		codes:  make(map[string]authorization),
+	}
+
+	app := fiber.New()
+	app.Get("/.well-known/openid-configuration", s.discovery)
+	app.Get("/jwks", s.jwks)
+	app.Get("/authorize", s.authorize)
+	app.Post("/token", s.token)
+
+	log.Fatal(app.Listen(*addr))
+}
+
+func (s *server) discovery(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{
+		"issuer":                                s.issuer,
+		"authorization_endpoint":                s.issuer + "/authorize",
+		"token_endpoint":                        s.issuer + "/token",
+		"jwks_uri":                              s.issuer + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+func (s *server) jwks(c *fiber.Ctx) error {
+	return c.JSON(s.keys.JWKS())
+}
+
+// authorize, sub parametresi yoksa kullanıcı bilgisi soran bir form gösterir,
+// varsa kodu üretip istemciye geri yönlendirir
+func (s *server) authorize(c *fiber.Ctx) error {
+	if c.Query("response_type") != "code" || c.Query("client_id") == "" || c.Query("redirect_uri") == "" {
+		return c.Status(fiber.StatusBadRequest).SendString("response_type=code, client_id ve redirect_uri gerekli")
+	}
+	if c.Query("code_challenge") == "" || c.Query("code_challenge_method") != "S256" {
+		return c.Status(fiber.StatusBadRequest).SendString("PKCE (S256) gerekli")
+	}
+
+	if c.Query("sub") == "" {
+		return s.loginForm(c)
+	}
+
+	code, err := utils.GenerateRandomToken(24)
+	if err != nil {
+		return err
+	}
+
+	email := c.Query("email", c.Query("sub")+"@mock.local")
+	name := c.Query("name", "Mock User")
+	givenName, familyName, _ := strings.Cut(name, " ")
+
+	s.mu.Lock()
+	s.codes[code] = authorization{
+		clientID:      c.Query("client_id"),
+		redirectURI:   c.Query("redirect_uri"),
+		codeChallenge: c.Query("code_challenge"),
+		nonce:         c.Query("nonce"),
+		claims: jwt.MapClaims{
+			"sub":                c.Query("sub"),
+			"email":              email,
+			"email_verified":     c.Query("email_verified", "true") == "true",
+			"name":               name,
+			"given_name":         givenName,
+			"family_name":        familyName,
+			"preferred_username": c.Query("sub"),
+		},
+		expiresAt: time.Now().Add(time.Minute),
+	}
+	s.mu.Unlock()
+
+	redirect, err := url.Parse(c.Query("redirect_uri"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).SendString("geçersiz redirect_uri")
+	}
+	query := redirect.Query()
+	query.Set("code", code)
+	query.Set("state", c.Query("state"))
+	redirect.RawQuery = query.Encode()
+
+	return c.Redirect(redirect.String(), fiber.StatusFound)
+}
+
+func (s *server) loginForm(c *fiber.Ctx) error {
+	var hidden strings.Builder
+	c.Context().QueryArgs().VisitAll(func(key, value []byte) {
+		fmt.Fprintf(&hidden, `<input type="hidden" name="%s" value="%s">`, html.EscapeString(string(key)), html.EscapeString(string(value)))
+	})
+
+	c.Type("html")
+	return c.SendString(`<!doctype html><html><body><h3>Mock OIDC</h3><form method="get" action="/authorize">` + hidden.String() + `
+<p><label>sub <input name="sub" value="mock-user"></label></p>
+<p><label>email <input name="email" value="mock-user@mock.local"></label></p>
+<p><label>name <input name="name" value="Mock User"></label></p>
+<p><label>email_verified <select name="email_verified"><option>true</option><option>false</option></select></label></p>
+<p><button type="submit">Giriş yap</button></p></form></body></html>`)
+}
+
+func (s *server) token(c *fiber.Ctx) error {
+	if c.FormValue("grant_type") != "authorization_code" {
+		return tokenError(c, "unsupported_grant_type")
+	}
+
+	code := c.FormValue("code")
+	s.mu.Lock()
+	auth, ok := s.codes[code]
+	delete(s.codes, code)
+	s.mu.Unlock()
+
+	if !ok || time.Now().After(auth.expiresAt) {
+		return tokenError(c, "invalid_grant")
+	}
+
+	clientID := c.FormValue("client_id")
+	if basicUser, _, ok := basicAuth(c); ok {
+		clientID = basicUser
+	}
+	if clientID != auth.clientID || c.FormValue("redirect_uri") != auth.redirectURI {
+		return tokenError(c, "invalid_grant")
+	}
+
+	challenge := oidc.CodeChallenge(c.FormValue("code_verifier"))
+	if subtle.ConstantTimeCompare([]byte(challenge), []byte(auth.codeChallenge)) != 1 {
+		return tokenError(c, "invalid_grant")
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"aud": auth.clientID,
+		"iat": now.Unix(),
+		"exp": now.Add(5 * time.Minute).Unix(),
+	}
+	if auth.nonce != "" {
+		claims["nonce"] = auth.nonce
+	}
+	for key, value := range auth.claims {
+		claims[key] = value
+	}
+
+	idToken, err := s.keys.Sign(claims)
+	if err != nil {
+		return err
+	}
+	accessToken, err := utils.GenerateRandomToken(24)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{
+		"access_token": accessToken,
+		"token_type":   "Bearer",
+		"expires_in":   300,
+		"id_token":     idToken,
+	})
+}
+
+// basicAuth, client_secret_basic ile gönderilen istemci bilgilerini okur
+func basicAuth(c *fiber.Ctx) (string, string, bool) {
+	header := c.Get(fiber.HeaderAuthorization)
+	if !strings.HasPrefix(header, "Basic ") {
+		return "", "", false
+	}
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(header, "Basic "))
+	if err != nil {
+		return "", "", false
+	}
+	username, password, ok := strings.Cut(string(decoded), ":")
+	if !ok {
+		return "", "", false
+	}
+	clientID, _ := url.QueryUnescape(username)
+	clientSecret, _ := url.QueryUnescape(password)
+	return clientID, clientSecret, true
+}
+
+func tokenError(c *fiber.Ctx, code string) error {
+	return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": code})
+}
@@ -0,0 +1,135 @@
+// Package audio, yüklenen ses dosyalarının formatını içeriğine bakarak tespit eder
+// ve süre gibi bilgileri dosyayı çözmeden okur.
+package audio
+
+import (
+	"errors"
+	"io"
+	"time"
+)
+
+type Format string
+
+const (
+	FormatMP3  Format = "mp3"
+	FormatM4A  Format = "m4a"
+	FormatOpus Format = "opus"
+	FormatWAV  Format = "wav"
+)
+
+// ErrUnsupportedFormat, dosyanın içeriği desteklenen formatlardan hiçbirine uymadığında döner
+var ErrUnsupportedFormat = errors.New("desteklenmeyen ses formatı")
+
+// MimeType, formatın R2'de saklanırken ve istemciye bildirilirken kullanılan MIME türüdür
+func (f Format) MimeType() string {
+	switch f {
+	case FormatMP3:
+		return "audio/mpeg"
+	case FormatM4A:
+		return "audio/mp4"
+	case FormatOpus:
+		return "audio/ogg"
+	case FormatWAV:
+		return "audio/wav"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+// Info, bir ses dosyasından okunan bilgilerdir. Bitrate saniyedeki bit sayısıdır;
+// formatın kendisinde yer almıyorsa dosya boyutu ve süreden hesaplanır.
+type Info struct {
+	Format     Format
+	Codec      string
+	Duration   time.Duration
+	Bitrate    int
+	SampleRate int
+	Channels   int
+	Size       int64
+}
+
+func (i *Info) MimeType() string {
+	return i.Format.MimeType()
+}
+
+// AudioProbe, belirli bir formattaki ses dosyasının bilgilerini okur
+type AudioProbe interface {
+	Probe(r io.ReadSeeker) (*Info, error)
+}
+
+var probes = map[Format]AudioProbe{
+	FormatMP3:  mp3Probe{},
+	FormatM4A:  mp4Probe{},
+	FormatOpus: oggOpusProbe{},
+	FormatWAV:  wavProbe{},
+}
+
+// headerSize, format tespiti için dosyanın başından okunan bayt sayısıdır
+const headerSize = 512
+
+// Detect, dosyanın ilk baytlarına bakarak formatını belirler. Dosya adı dikkate alınmaz.
+func Detect(header []byte) (Format, error) {
+	switch {
+	case len(header) >= 12 && string(header[0:4]) == "RIFF" && string(header[8:12]) == "WAVE":
+		return FormatWAV, nil
+	case len(header) >= 8 && string(header[4:8]) == "ftyp":
+		return FormatM4A, nil
+	case isOggOpus(header):
+		return FormatOpus, nil
+	case len(header) >= 3 && string(header[0:3]) == "ID3":
+		return FormatMP3, nil
+	case len(header) >= 2 && isMP3FrameSync(header[0], header[1]):
+		return FormatMP3, nil
+	default:
+		return "", ErrUnsupportedFormat
+	}
+}
+
+// Probe, dosyanın formatını tespit eder ve ilgili AudioProbe ile bilgilerini okur
+func Probe(r io.ReadSeeker) (*Info, error) {
+	header := make([]byte, headerSize)
+	n, err := io.ReadFull(r, header)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		if err == io.EOF {
+			return nil, ErrUnsupportedFormat
+		}
+		return nil, err
+	}
+
+	format, err := Detect(header[:n])
+	if err != nil {
+		return nil, err
+	}
+
+	fileSize, err := size(r)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := probes[format].Probe(r)
+	if err != nil {
+		return nil, err
+	}
+	if info.Duration <= 0 {
+		return nil, errors.New("ses süresi okunamadı")
+	}
+
+	info.Size = fileSize
+	if info.Bitrate == 0 && info.Duration > 0 {
+		info.Bitrate = int(float64(fileSize*8) / info.Duration.Seconds())
+	}
+
+	return info, nil
+}
+
+// size, okuyucunun toplam boyutunu döndürür ve konumu başa alır
+func size(r io.ReadSeeker) (int64, error) {
+	end, err := r.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return 0, err
+	}
+	return end, nil
+}
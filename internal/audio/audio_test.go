@@ -0,0 +1,109 @@
+package audio
+
+import (
+	"bytes"
+	"math"
+	"os"
+	"testing"
+	"time"
+)
+
+// mp3FrameDuration, testdata/silence.mp3'teki 44.1 kHz MPEG-1 Layer III frame'lerinin süresidir
+const mp3FrameDuration = 1152 * time.Second / 44100
+
+func TestProbe(t *testing.T) {
+	tests := []struct {
+		name     string
+		fixture  string
+		truncate int // 0'dan büyükse dosyanın yalnızca ilk truncate baytı okunur
+		format   Format
+		codec    string
+		duration time.Duration
+		wantErr  bool
+	}{
+		{name: "mp3", fixture: "silence.mp3", format: FormatMP3, codec: "mp3", duration: 38 * mp3FrameDuration},
+		{name: "mp3 yarım frame'de kesilmiş", fixture: "silence.mp3", truncate: 2000, format: FormatMP3, codec: "mp3", duration: 18 * mp3FrameDuration},
+		{name: "mp3 yalnızca ID3 etiketi", fixture: "silence.mp3", truncate: 30, wantErr: true},
+		{name: "m4a", fixture: "silence.m4a", format: FormatM4A, codec: "aac", duration: time.Second},
+		{name: "m4a moov ortasında kesilmiş", fixture: "silence.m4a", truncate: 400, wantErr: true},
+		{name: "m4a mdat ortasında kesilmiş", fixture: "silence.m4a", truncate: 100, wantErr: true},
+		{name: "opus", fixture: "silence.opus", format: FormatOpus, codec: "opus", duration: 993500 * time.Microsecond},
+		{name: "opus son sayfa ortasında kesilmiş", fixture: "silence.opus", truncate: 340, format: FormatOpus, codec: "opus", duration: 993500 * time.Microsecond},
+		{name: "opus yalnızca OpusHead sayfası", fixture: "silence.opus", truncate: 47, wantErr: true},
+		{name: "wav", fixture: "silence.wav", format: FormatWAV, codec: "pcm", duration: 500 * time.Millisecond},
+		{name: "wav data ortasında kesilmiş", fixture: "silence.wav", truncate: 4044, format: FormatWAV, codec: "pcm", duration: 250 * time.Millisecond},
+		{name: "wav fmt ortasında kesilmiş", fixture: "silence.wav", truncate: 30, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := os.ReadFile("testdata/" + tt.fixture)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.truncate > 0 {
+				data = data[:tt.truncate]
+			}
+
+			info, err := Probe(bytes.NewReader(data))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("hata bekleniyordu, sonuç: %+v", info)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("beklenmeyen hata: %v", err)
+			}
+
+			if info.Format != tt.format || info.Codec != tt.codec {
+				t.Errorf("format = %s/%s, beklenen %s/%s", info.Format, info.Codec, tt.format, tt.codec)
+			}
+			if diff := info.Duration - tt.duration; diff < -time.Millisecond || diff > time.Millisecond {
+				t.Errorf("süre = %v, beklenen %v", info.Duration, tt.duration)
+			}
+			if info.Size != int64(len(data)) {
+				t.Errorf("boyut = %d, beklenen %d", info.Size, len(data))
+			}
+		})
+	}
+}
+
+func TestDurationFromUnits(t *testing.T) {
+	maxSeconds := uint64(math.MaxInt64 / int64(time.Second))
+
+	tests := []struct {
+		name    string
+		units   uint64
+		rate    uint64
+		want    time.Duration
+		wantErr bool
+	}{
+		{name: "tam saniye", units: 44100, rate: 44100, want: time.Second},
+		{name: "kesirli", units: 47688, rate: 48000, want: 993500 * time.Microsecond},
+		{name: "sıfır", units: 0, rate: 1000, want: 0},
+		{name: "en büyük zaman ölçeği", units: math.MaxUint32 + math.MaxUint32/2, rate: math.MaxUint32, want: 1499999999},
+		{name: "sınırda", units: maxSeconds, rate: 1, want: time.Duration(maxSeconds) * time.Second},
+		{name: "saniyeler taşıyor", units: maxSeconds + 1, rate: 1, wantErr: true},
+		{name: "kesir taşıyor", units: maxSeconds*1000 + 999, rate: 1000, wantErr: true},
+		{name: "64 bit süre", units: math.MaxUint64, rate: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := durationFromUnits(tt.units, tt.rate)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("hata bekleniyordu, sonuç: %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("beklenmeyen hata: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("süre = %v, beklenen %v", got, tt.want)
+			}
+		})
+	}
+}
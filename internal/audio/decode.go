@@ -0,0 +1,152 @@
+package audio
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+
+	gomp3 "github.com/hajimehoshi/go-mp3"
+)
+
+// ErrNoDecoder, format için saf Go bir çözücü bulunmadığında döner. M4A (AAC) ve Opus
+// dosyalarının bilgileri okunabilir ancak örnekleri çözülemez.
+var ErrNoDecoder = errors.New("bu ses formatı için çözücü yok")
+
+// Decoder, ses dosyasını -1 ile 1 arasındaki örneklere çözer. Çok kanallı dosyalarda
+// örnekler kanal sırasıyla art arda gelir.
+type Decoder interface {
+	SampleRate() int
+	Channels() int
+	// Frames, kanal başına toplam örnek sayısıdır
+	Frames() int64
+	// Read, buf'ı örneklerle doldurur ve okunan örnek sayısını döndürür
+	Read(buf []float32) (int, error)
+}
+
+// NewDecoder, verilen formattaki dosya için bir Decoder döndürür
+func NewDecoder(r io.ReadSeeker, format Format) (Decoder, error) {
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case FormatMP3:
+		return newMP3Decoder(r)
+	case FormatWAV:
+		return newWAVDecoder(r)
+	default:
+		return nil, ErrNoDecoder
+	}
+}
+
+// mp3Decoder, go-mp3'ün ürettiği 16 bit stereo örnekleri float32'ye çevirir
+type mp3Decoder struct {
+	decoder *gomp3.Decoder
+	buf     []byte
+}
+
+func newMP3Decoder(r io.ReadSeeker) (*mp3Decoder, error) {
+	decoder, err := gomp3.NewDecoder(r)
+	if err != nil {
+		return nil, err
+	}
+	return &mp3Decoder{decoder: decoder}, nil
+}
+
+func (d *mp3Decoder) SampleRate() int { return d.decoder.SampleRate() }
+func (d *mp3Decoder) Channels() int   { return 2 }
+func (d *mp3Decoder) Frames() int64   { return d.decoder.Length() / 4 }
+
+func (d *mp3Decoder) Read(buf []float32) (int, error) {
+	if cap(d.buf) < len(buf)*2 {
+		d.buf = make([]byte, len(buf)*2)
+	}
+	raw := d.buf[:len(buf)*2]
+
+	n, err := io.ReadFull(d.decoder, raw)
+	if err == io.ErrUnexpectedEOF {
+		err = io.EOF
+	}
+
+	samples := n / 2
+	for i := 0; i < samples; i++ {
+		buf[i] = float32(int16(binary.LittleEndian.Uint16(raw[i*2:]))) / 32768
+	}
+	if samples > 0 && err == io.EOF {
+		err = nil
+	}
+	return samples, err
+}
+
+// wavDecoder, 8/16/24/32 bit tamsayı ve 32/64 bit kayan noktalı PCM verisini çözer
+type wavDecoder struct {
+	header *wavHeader
+	data   io.Reader
+	buf    []byte
+}
+
+func newWAVDecoder(r io.ReadSeeker) (*wavDecoder, error) {
+	header, err := readWAVHeader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	bytesPerSample := header.bitsPerSample / 8
+	switch {
+	case header.formatTag == wavFormatPCM && bytesPerSample >= 1 && bytesPerSample <= 4:
+	case header.formatTag == wavFormatFloat && (bytesPerSample == 4 || bytesPerSample == 8):
+	default:
+		return nil, ErrNoDecoder
+	}
+	if header.channels == 0 || header.blockAlign != bytesPerSample*header.channels {
+		return nil, errors.New("geçersiz WAV format bilgisi")
+	}
+
+	return &wavDecoder{
+		header: header,
+		data:   io.LimitReader(r, header.dataSize),
+	}, nil
+}
+
+func (d *wavDecoder) SampleRate() int { return d.header.sampleRate }
+func (d *wavDecoder) Channels() int   { return d.header.channels }
+func (d *wavDecoder) Frames() int64   { return d.header.dataSize / int64(d.header.blockAlign) }
+
+func (d *wavDecoder) Read(buf []float32) (int, error) {
+	bytesPerSample := d.header.bitsPerSample / 8
+	if cap(d.buf) < len(buf)*bytesPerSample {
+		d.buf = make([]byte, len(buf)*bytesPerSample)
+	}
+	raw := d.buf[:len(buf)*bytesPerSample]
+
+	n, err := io.ReadFull(d.data, raw)
+	if err == io.ErrUnexpectedEOF {
+		err = io.EOF
+	}
+
+	samples := n / bytesPerSample
+	for i := 0; i < samples; i++ {
+		b := raw[i*bytesPerSample:]
+		switch {
+		case d.header.formatTag == wavFormatFloat && bytesPerSample == 4:
+			buf[i] = math.Float32frombits(binary.LittleEndian.Uint32(b))
+		case d.header.formatTag == wavFormatFloat:
+			buf[i] = float32(math.Float64frombits(binary.LittleEndian.Uint64(b)))
+		case bytesPerSample == 1:
+			// 8 bit WAV örnekleri işaretsizdir
+			buf[i] = float32(int(b[0])-128) / 128
+		case bytesPerSample == 2:
+			buf[i] = float32(int16(binary.LittleEndian.Uint16(b))) / (1 << 15)
+		case bytesPerSample == 3:
+			v := int32(uint32(b[0])<<8|uint32(b[1])<<16|uint32(b[2])<<24) >> 8
+			buf[i] = float32(v) / (1 << 23)
+		default:
+			buf[i] = float32(int32(binary.LittleEndian.Uint32(b))) / (1 << 31)
+		}
+	}
+	if samples > 0 && err == io.EOF {
+		err = nil
+	}
+	return samples, err
+}
@@ -0,0 +1,165 @@
+package audio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+)
+
+// trimSilenceFilter, baştaki ve sondaki -50 dB altındaki sessizliği kırpar. Sondaki
+// sessizlik ses ters çevrilip baştan kırpılarak temizlenir.
+const trimSilenceFilter = "silenceremove=start_periods=1:start_threshold=-50dB:start_silence=0.1," +
+	"areverse," +
+	"silenceremove=start_periods=1:start_threshold=-50dB:start_silence=0.1," +
+	"areverse"
+
+// loudnormTruePeak ve loudnormRange, loudnorm filtresinin tepe ve ses yüksekliği aralığı hedefleridir
+const (
+	loudnormTruePeak = -1.5
+	loudnormRange    = 11.0
+)
+
+// ffmpegProcessor, ses yüksekliğini ffmpeg'in loudnorm filtresiyle iki geçişte ölçer ve normalize eder
+type ffmpegProcessor struct {
+	path string
+}
+
+// loudnormStats, loudnorm filtresinin print_format=json ile yazdırdığı ölçümlerdir
+type loudnormStats struct {
+	InputI       string `json:"input_i"`
+	InputTP      string `json:"input_tp"`
+	InputLRA     string `json:"input_lra"`
+	InputThresh  string `json:"input_thresh"`
+	OutputI      string `json:"output_i"`
+	OutputTP     string `json:"output_tp"`
+	TargetOffset string `json:"target_offset"`
+}
+
+func (p *ffmpegProcessor) Measure(ctx context.Context, path string, format Format) (*Loudness, error) {
+	stats, err := p.loudnorm(ctx, path, loudnormFilter(DefaultLoudnessTarget, nil))
+	if err != nil {
+		return nil, err
+	}
+	return parseLoudness(stats.InputI, stats.InputTP)
+}
+
+func (p *ffmpegProcessor) Normalize(ctx context.Context, src, dst string, format Format, opts NormalizeOptions) (*Loudness, error) {
+	codecArgs, ok := ffmpegCodecArgs[format]
+	if !ok {
+		return nil, ErrUnsupportedFormat
+	}
+
+	// İlk geçiş: kırpılmış sesin ölçümü
+	measured, err := p.loudnorm(ctx, src, trimSilenceFilter+","+loudnormFilter(opts.Target, nil))
+	if err != nil {
+		return nil, err
+	}
+
+	// İkinci geçiş: ölçülen değerlerle doğrusal normalizasyon
+	args := []string{"-hide_banner", "-nostats", "-y", "-i", src,
+		"-af", trimSilenceFilter + "," + loudnormFilter(opts.Target, measured),
+		"-map_metadata", "-1",
+	}
+	sampleRate := opts.SampleRate
+	if format == FormatOpus {
+		// Opus yalnızca 48 kHz'i destekler
+		sampleRate = 48000
+	}
+	if sampleRate > 0 {
+		// loudnorm çıktıyı 192 kHz'e çıkardığından örnekleme hızı geri alınır
+		args = append(args, "-ar", strconv.Itoa(sampleRate))
+	}
+	args = append(args, codecArgs...)
+	args = append(args, dst)
+
+	stderr, err := p.run(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := lastLoudnormStats(stderr)
+	if err != nil {
+		return nil, err
+	}
+	return parseLoudness(stats.OutputI, stats.OutputTP)
+}
+
+// ffmpegCodecArgs, normalize edilmiş sürümün kaynakla aynı formatta kodlanması için gereken argümanlardır
+var ffmpegCodecArgs = map[Format][]string{
+	FormatMP3:  {"-c:a", "libmp3lame", "-q:a", "2", "-f", "mp3"},
+	FormatM4A:  {"-c:a", "aac", "-b:a", "128k", "-movflags", "+faststart", "-f", "mp4"},
+	FormatOpus: {"-c:a", "libopus", "-b:a", "96k", "-f", "ogg"},
+	FormatWAV:  {"-c:a", "pcm_s16le", "-f", "wav"},
+}
+
+// loudnorm, verilen filtreyi çıktı üretmeden çalıştırır ve loudnorm ölçümlerini döndürür
+func (p *ffmpegProcessor) loudnorm(ctx context.Context, path, filter string) (*loudnormStats, error) {
+	stderr, err := p.run(ctx, "-hide_banner", "-nostats", "-i", path, "-af", filter, "-f", "null", "-")
+	if err != nil {
+		return nil, err
+	}
+	return lastLoudnormStats(stderr)
+}
+
+func (p *ffmpegProcessor) run(ctx context.Context, args ...string) ([]byte, error) {
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, p.path, args...)
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		// Hata mesajı ffmpeg çıktısının sonundadır
+		out := stderr.Bytes()
+		if len(out) > 512 {
+			out = out[len(out)-512:]
+		}
+		return nil, fmt.Errorf("ffmpeg çalıştırılamadı: %v: %s", err, bytes.TrimSpace(out))
+	}
+	return stderr.Bytes(), nil
+}
+
+// loudnormFilter, hedef ses yüksekliği için loudnorm filtresini oluşturur. measured verilirse
+// ikinci geçiş için ölçülen değerler eklenir ve doğrusal normalizasyon istenir.
+func loudnormFilter(target float64, measured *loudnormStats) string {
+	filter := fmt.Sprintf("loudnorm=I=%g:TP=%g:LRA=%g", target, loudnormTruePeak, loudnormRange)
+	if measured != nil {
+		filter += fmt.Sprintf(":measured_I=%s:measured_TP=%s:measured_LRA=%s:measured_thresh=%s:offset=%s:linear=true",
+			measured.InputI, measured.InputTP, measured.InputLRA, measured.InputThresh, measured.TargetOffset)
+	}
+	return filter + ":print_format=json"
+}
+
+// lastLoudnormStats, ffmpeg çıktısının sonundaki JSON bloğunu okur
+func lastLoudnormStats(stderr []byte) (*loudnormStats, error) {
+	end := bytes.LastIndexByte(stderr, '}')
+	if end < 0 {
+		return nil, errors.New("ffmpeg loudnorm çıktısı okunamadı")
+	}
+	start := bytes.LastIndexByte(stderr[:end], '{')
+	if start < 0 {
+		return nil, errors.New("ffmpeg loudnorm çıktısı okunamadı")
+	}
+
+	var stats loudnormStats
+	if err := json.Unmarshal(stderr[start:end+1], &stats); err != nil {
+		return nil, fmt.Errorf("ffmpeg loudnorm çıktısı okunamadı: %v", err)
+	}
+	return &stats, nil
+}
+
+// parseLoudness, loudnorm'un metin olarak yazdırdığı değerleri çevirir. Sessiz dosyalarda
+// loudnorm "-inf" yazar.
+func parseLoudness(integrated, truePeak string) (*Loudness, error) {
+	i, err := strconv.ParseFloat(integrated, 64)
+	if err != nil {
+		return nil, fmt.Errorf("geçersiz ses yüksekliği: %q", integrated)
+	}
+	tp, err := strconv.ParseFloat(truePeak, 64)
+	if err != nil {
+		return nil, fmt.Errorf("geçersiz tepe değeri: %q", truePeak)
+	}
+	return &Loudness{Integrated: i, Peak: tp}, nil
+}
@@ -0,0 +1,91 @@
+package audio
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// HLSMasterPlaylist, sürümleri listeleyen ana playlist'in dosya adıdır
+	HLSMasterPlaylist = "master.m3u8"
+	// HLSVariantPlaylist, her sürümün kendi dizinindeki segment playlist'inin dosya adıdır
+	HLSVariantPlaylist = "index.m3u8"
+)
+
+// HLSRendition, HLS paketindeki bir AAC sürümüdür
+type HLSRendition struct {
+	Name    string // sürümün dizini, örn. 64k
+	Bitrate int    // bit/s
+}
+
+// DefaultHLSRenditions, mobil ağlar için düşük ve Wi-Fi için yüksek bit hızlı sürümlerdir
+var DefaultHLSRenditions = []HLSRendition{
+	{Name: "64k", Bitrate: 64000},
+	{Name: "128k", Bitrate: 128000},
+}
+
+// HLSPackager, sesi ffmpeg ile AAC'ye kodlayıp MPEG-TS segmentlerine böler
+type HLSPackager struct {
+	ffmpeg          *ffmpegProcessor
+	Renditions      []HLSRendition
+	SegmentDuration time.Duration
+}
+
+// NewHLSPackager, ffmpeg bulunamazsa hata döner; HLS paketleme saf Go ile yapılamaz
+func NewHLSPackager(ffmpegPath string) (*HLSPackager, error) {
+	path, err := exec.LookPath(ffmpegPath)
+	if err != nil {
+		return nil, fmt.Errorf("HLS paketleme için ffmpeg bulunamadı: %v", err)
+	}
+	return &HLSPackager{
+		ffmpeg:          &ffmpegProcessor{path: path},
+		Renditions:      DefaultHLSRenditions,
+		SegmentDuration: 6 * time.Second,
+	}, nil
+}
+
+// Package, src'yi dir altına paketler: her sürüm için <ad>/index.m3u8 ve segmentleri,
+// ayrıca göreli yollarla sürümlere başvuran master.m3u8 yazılır
+func (p *HLSPackager) Package(ctx context.Context, src, dir string) error {
+	for _, rendition := range p.Renditions {
+		renditionDir := filepath.Join(dir, rendition.Name)
+		if err := os.MkdirAll(renditionDir, 0o755); err != nil {
+			return err
+		}
+
+		// Kapak fotoğrafı gibi gömülü görseller -vn ile atlanır
+		_, err := p.ffmpeg.run(ctx, "-hide_banner", "-nostats", "-y", "-i", src,
+			"-vn", "-map", "0:a:0", "-map_metadata", "-1",
+			"-c:a", "aac", "-b:a", strconv.Itoa(rendition.Bitrate), "-ac", "2", "-ar", "44100",
+			"-f", "hls",
+			"-hls_time", strconv.FormatFloat(p.SegmentDuration.Seconds(), 'f', -1, 64),
+			"-hls_playlist_type", "vod",
+			"-hls_segment_type", "mpegts",
+			"-hls_segment_filename", filepath.Join(renditionDir, "segment_%03d.ts"),
+			filepath.Join(renditionDir, HLSVariantPlaylist),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return os.WriteFile(filepath.Join(dir, HLSMasterPlaylist), []byte(MasterPlaylist(p.Renditions)), 0o644)
+}
+
+// MasterPlaylist, sürümleri bit hızına göre listeleyen ana playlist'i oluşturur.
+// BANDWIDTH, MPEG-TS ek yükü için bit hızından %10 yüksek bildirilir.
+func MasterPlaylist(renditions []HLSRendition) string {
+	var b strings.Builder
+	b.WriteString("#EXTM3U\n#EXT-X-VERSION:3\n")
+	for _, rendition := range renditions {
+		fmt.Fprintf(&b, "#EXT-X-STREAM-INF:BANDWIDTH=%d,CODECS=\"mp4a.40.2\"\n", rendition.Bitrate*11/10)
+		fmt.Fprintf(&b, "%s/%s\n", rendition.Name, HLSVariantPlaylist)
+	}
+	return b.String()
+}
@@ -0,0 +1,248 @@
+package audio
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"os"
+	"os/exec"
+)
+
+// DefaultLoudnessTarget, normalize edilmiş sürümlerin hedeflediği entegre ses yüksekliğidir (LUFS)
+const DefaultLoudnessTarget = -16.0
+
+// ErrNoNormalizer, normalize edilmiş sürüm üretemeyen (yalnızca ölçüm yapabilen) işlemcilerden döner
+var ErrNoNormalizer = errors.New("normalize edilmiş sürüm üretilemiyor")
+
+// Loudness, EBU R128'e göre ölçülen ses yüksekliğidir. Integrated LUFS, Peak dBFS
+// cinsindendir; ffmpeg ile ölçüldüğünde Peak gerçek tepe (dBTP) değeridir.
+type Loudness struct {
+	Integrated float64
+	Peak       float64
+}
+
+// NormalizeOptions, normalize edilmiş sürümün ayarlarıdır
+type NormalizeOptions struct {
+	Target     float64 // LUFS
+	SampleRate int     // Sıfırsa kaynağın örnekleme hızı korunmaz, ffmpeg varsayılanı kullanılır
+}
+
+// LoudnessProcessor, ses dosyalarının ses yüksekliğini ölçer ve hedef ses yüksekliğine
+// getirilmiş, baştaki ve sondaki sessizliği kırpılmış bir sürümünü üretir
+type LoudnessProcessor interface {
+	Measure(ctx context.Context, path string, format Format) (*Loudness, error)
+	// Normalize, src'nin normalize edilmiş sürümünü aynı formatta dst'ye yazar ve
+	// üretilen dosyanın ses yüksekliğini döndürür
+	Normalize(ctx context.Context, src, dst string, format Format, opts NormalizeOptions) (*Loudness, error)
+}
+
+// NewLoudnessProcessor, ffmpeg bulunursa onu, bulunamazsa yalnızca ölçüm yapabilen saf Go
+// işlemciyi döndürür. Saf Go işlemci çözücüsü olan formatları (MP3, WAV) ölçebilir.
+func NewLoudnessProcessor(ffmpegPath string) LoudnessProcessor {
+	if path, err := exec.LookPath(ffmpegPath); err == nil {
+		return &ffmpegProcessor{path: path}
+	}
+	return goLoudnessProcessor{}
+}
+
+type goLoudnessProcessor struct{}
+
+func (goLoudnessProcessor) Measure(ctx context.Context, path string, format Format) (*Loudness, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	decoder, err := NewDecoder(file, format)
+	if err != nil {
+		return nil, err
+	}
+	return MeasureLoudness(decoder)
+}
+
+func (goLoudnessProcessor) Normalize(ctx context.Context, src, dst string, format Format, opts NormalizeOptions) (*Loudness, error) {
+	return nil, ErrNoNormalizer
+}
+
+const (
+	// ITU-R BS.1770 ölçüm blokları 400 ms uzunluğundadır ve %75 örtüşür
+	loudnessSegment         = 0.1 // saniye
+	loudnessSegmentsInBlock = 4
+	absoluteGate            = -70.0 // LUFS
+	relativeGate            = -10.0 // LU
+)
+
+// MeasureLoudness, çözülen örneklerin entegre ses yüksekliğini ITU-R BS.1770-4'teki
+// K ağırlıklandırma ve iki aşamalı kapılama ile hesaplar. Peak örnek tepe değeridir.
+func MeasureLoudness(d Decoder) (*Loudness, error) {
+	channels := d.Channels()
+	sampleRate := d.SampleRate()
+	if channels <= 0 || sampleRate <= 0 {
+		return nil, errors.New("geçersiz ses akışı")
+	}
+
+	filters := make([]kWeighting, channels)
+	for i := range filters {
+		filters[i] = newKWeighting(float64(sampleRate))
+	}
+
+	segmentFrames := int(float64(sampleRate) * loudnessSegment)
+	// segments, her 100 ms'lik bölümün kanal ağırlıklı kareler toplamıdır
+	segments := make([]float64, 0, 64)
+	var sum, peak float64
+	frames := 0
+
+	buf := make([]float32, 4096*channels)
+	for {
+		n, err := d.Read(buf)
+		for i := 0; i < n; i++ {
+			sample := float64(buf[i])
+			if abs := math.Abs(sample); abs > peak {
+				peak = abs
+			}
+			filtered := filters[i%channels].process(sample)
+			sum += channelWeight(i%channels, channels) * filtered * filtered
+
+			if i%channels == channels-1 {
+				frames++
+				if frames == segmentFrames {
+					segments = append(segments, sum)
+					sum, frames = 0, 0
+				}
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	blockFrames := float64(segmentFrames * loudnessSegmentsInBlock)
+	blocks := make([]float64, 0, len(segments))
+	for i := 0; i+loudnessSegmentsInBlock <= len(segments); i++ {
+		var power float64
+		for _, segment := range segments[i : i+loudnessSegmentsInBlock] {
+			power += segment
+		}
+		blocks = append(blocks, power/blockFrames)
+	}
+
+	return &Loudness{
+		Integrated: gatedLoudness(blocks),
+		Peak:       20 * math.Log10(peak),
+	}, nil
+}
+
+// gatedLoudness, mutlak kapının (-70 LUFS) ve ona göre hesaplanan göreli kapının (-10 LU)
+// üzerindeki blokların ortalama ses yüksekliğidir. Hiç blok kalmazsa -Inf döner.
+func gatedLoudness(blocks []float64) float64 {
+	gated := func(threshold float64) float64 {
+		var sum float64
+		count := 0
+		for _, power := range blocks {
+			if blockLoudness(power) > threshold {
+				sum += power
+				count++
+			}
+		}
+		if count == 0 {
+			return math.Inf(-1)
+		}
+		return blockLoudness(sum / float64(count))
+	}
+
+	relative := gated(absoluteGate) + relativeGate
+	return gated(max(relative, absoluteGate))
+}
+
+func blockLoudness(power float64) float64 {
+	return -0.691 + 10*math.Log10(power)
+}
+
+// channelWeight, 5.1 düzenindeki surround kanallarına 1.41, LFE kanalına 0 ağırlık verir
+func channelWeight(channel, channels int) float64 {
+	if channels < 5 {
+		return 1
+	}
+	switch channel {
+	case 3:
+		if channels == 6 {
+			return 0
+		}
+		return 1.41
+	case 4, 5:
+		return 1.41
+	default:
+		return 1
+	}
+}
+
+// kWeighting, BS.1770'teki ön filtre (high shelf) ve RLB (high pass) filtrelerinden oluşan
+// iki biquad'dır. Katsayılar örnekleme hızına göre libebur128'deki gibi hesaplanır.
+type kWeighting struct {
+	stages [2]biquad
+}
+
+func newKWeighting(sampleRate float64) kWeighting {
+	var k kWeighting
+
+	// Ön filtre
+	{
+		f0 := 1681.974450955533
+		gain := 3.999843853973347
+		q := 0.7071752369554196
+
+		K := math.Tan(math.Pi * f0 / sampleRate)
+		vh := math.Pow(10, gain/20)
+		vb := math.Pow(vh, 0.4996667741545416)
+		a0 := 1 + K/q + K*K
+
+		k.stages[0] = biquad{
+			b0: (vh + vb*K/q + K*K) / a0,
+			b1: 2 * (K*K - vh) / a0,
+			b2: (vh - vb*K/q + K*K) / a0,
+			a1: 2 * (K*K - 1) / a0,
+			a2: (1 - K/q + K*K) / a0,
+		}
+	}
+
+	// RLB filtresi
+	{
+		f0 := 38.13547087602444
+		q := 0.5003270373238773
+
+		K := math.Tan(math.Pi * f0 / sampleRate)
+		a0 := 1 + K/q + K*K
+
+		k.stages[1] = biquad{
+			b0: 1,
+			b1: -2,
+			b2: 1,
+			a1: 2 * (K*K - 1) / a0,
+			a2: (1 - K/q + K*K) / a0,
+		}
+	}
+
+	return k
+}
+
+func (k *kWeighting) process(x float64) float64 {
+	return k.stages[1].process(k.stages[0].process(x))
+}
+
+// biquad, doğrudan form II transpoze ikinci dereceden IIR filtredir
+type biquad struct {
+	b0, b1, b2, a1, a2 float64
+	z1, z2             float64
+}
+
+func (b *biquad) process(x float64) float64 {
+	y := b.b0*x + b.z1
+	b.z1 = b.b1*x - b.a1*y + b.z2
+	b.z2 = b.b2*x - b.a2*y
+	return y
+}
@@ -0,0 +1,81 @@
+package audio
+
+import (
+	"errors"
+	"io"
+	"time"
+
+	"github.com/tcolgate/mp3"
+)
+
+type mp3Probe struct{}
+
+// isMP3FrameSync, iki baytın bir MPEG ses frame başlığıyla başlayıp başlamadığını kontrol eder.
+// Layer alanı 00 olan başlıklar ADTS (AAC) akışlarına ait olduğundan reddedilir.
+func isMP3FrameSync(b0, b1 byte) bool {
+	return b0 == 0xFF && b1&0xE0 == 0xE0 && (b1>>1)&0x03 != 0
+}
+
+// Probe, tüm MPEG frame'lerinin sürelerini toplayarak dosyanın süresini hesaplar
+func (mp3Probe) Probe(r io.ReadSeeker) (*Info, error) {
+	if err := skipID3v2(r); err != nil {
+		return nil, err
+	}
+
+	var duration time.Duration
+	var frames, bitrateSum int
+	var first mp3.FrameHeader
+	decoder := mp3.NewDecoder(r)
+	var frame mp3.Frame
+	skipped := 0
+	for {
+		if err := decoder.Decode(&frame, &skipped); err != nil {
+			break
+		}
+		if frames == 0 {
+			first = frame.Header()
+		}
+		duration += frame.Duration()
+		bitrateSum += int(frame.Header().BitRate())
+		frames++
+	}
+
+	if frames == 0 {
+		return nil, errors.New("geçerli MP3 frame'i bulunamadı")
+	}
+
+	channels := 2
+	if first.ChannelMode() == mp3.SingleChannel {
+		channels = 1
+	}
+
+	return &Info{
+		Format:   FormatMP3,
+		Codec:    "mp3",
+		Duration: duration,
+		// Frame'lerin süreleri eşit olduğundan VBR dosyalarda da ortalama bitrate budur
+		Bitrate:    bitrateSum / frames,
+		SampleRate: int(first.SampleRate()),
+		Channels:   channels,
+	}, nil
+}
+
+// skipID3v2, dosya bir ID3v2 etiketiyle başlıyorsa etiketin sonuna konumlanır. Etiket
+// içindeki veriler frame başlığı gibi görünebildiğinden süre hesabına katılmamalıdır.
+func skipID3v2(r io.ReadSeeker) error {
+	header := make([]byte, 10)
+	if _, err := io.ReadFull(r, header); err != nil || string(header[0:3]) != "ID3" {
+		_, err := r.Seek(0, io.SeekStart)
+		return err
+	}
+
+	// Boyut, her baytın yalnızca alt 7 bitinin kullanıldığı (syncsafe) 28 bitlik bir sayıdır
+	tagSize := int64(header[6]&0x7F)<<21 | int64(header[7]&0x7F)<<14 | int64(header[8]&0x7F)<<7 | int64(header[9]&0x7F)
+	if header[5]&0x10 != 0 {
+		// Footer
+		tagSize += 10
+	}
+
+	_, err := r.Seek(10+tagSize, io.SeekStart)
+	return err
+}
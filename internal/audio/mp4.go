@@ -0,0 +1,310 @@
+package audio
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"time"
+)
+
+type mp4Probe struct{}
+
+// maxMoovSize, bellekte okunacak en büyük moov atomu boyutudur. 60 saniyelik bir
+// ses dosyasının örnek tabloları bunun çok altında kalır.
+const maxMoovSize = 16 << 20
+
+type mp4Box struct {
+	kind string
+	body []byte
+}
+
+// Probe, moov atomunu bulur ve ses parçasının mdhd atomundaki zaman ölçeği ve süreyi
+// okur. Ses parçası bulunamazsa mvhd atomundaki film süresi kullanılır.
+func (mp4Probe) Probe(r io.ReadSeeker) (*Info, error) {
+	moov, err := readMoov(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var movieDuration time.Duration
+	var track *mp4Track
+
+	for _, box := range parseBoxes(moov) {
+		switch box.kind {
+		case "mvhd":
+			movieDuration, err = parseMediaHeader(box.body)
+			if err != nil {
+				return nil, err
+			}
+		case "trak":
+			t, err := parseTrack(box.body)
+			if err != nil {
+				return nil, err
+			}
+			if t.isAudio && track == nil {
+				track = t
+			}
+		}
+	}
+
+	if track == nil {
+		return nil, errors.New("MP4 dosyasında ses parçası bulunamadı")
+	}
+
+	duration := track.duration
+	if duration == 0 {
+		duration = movieDuration
+	}
+
+	return &Info{
+		Format:     FormatM4A,
+		Codec:      track.codec,
+		Duration:   duration,
+		SampleRate: track.sampleRate,
+		Channels:   track.channels,
+	}, nil
+}
+
+// readMoov, üst seviye atomları dolaşarak moov atomunun içeriğini döndürür.
+// moov atomu mdat'tan sonra da gelebildiğinden aradaki atomlar atlanır.
+func readMoov(r io.ReadSeeker) ([]byte, error) {
+	offset := int64(0)
+	header := make([]byte, 16)
+	for {
+		if _, err := io.ReadFull(r, header[:8]); err != nil {
+			return nil, errors.New("MP4 dosyasında moov atomu bulunamadı")
+		}
+
+		boxSize := int64(binary.BigEndian.Uint32(header[0:4]))
+		kind := string(header[4:8])
+		headerLen := int64(8)
+
+		switch boxSize {
+		case 0:
+			// Atom dosyanın sonuna kadar devam eder
+			if kind != "moov" {
+				return nil, errors.New("MP4 dosyasında moov atomu bulunamadı")
+			}
+			boxSize = maxMoovSize + headerLen
+		case 1:
+			if _, err := io.ReadFull(r, header[8:16]); err != nil {
+				return nil, errors.New("geçersiz MP4 atomu")
+			}
+			boxSize = int64(binary.BigEndian.Uint64(header[8:16]))
+			headerLen = 16
+		}
+
+		if boxSize < headerLen {
+			return nil, errors.New("geçersiz MP4 atomu")
+		}
+
+		if kind == "moov" {
+			if boxSize-headerLen > maxMoovSize {
+				return nil, errors.New("MP4 moov atomu çok büyük")
+			}
+			body, err := io.ReadAll(io.LimitReader(r, boxSize-headerLen))
+			if err != nil {
+				return nil, err
+			}
+			return body, nil
+		}
+
+		offset += boxSize
+		if _, err := r.Seek(offset, io.SeekStart); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// parseBoxes, bir atomun içindeki alt atomları döndürür. Bozuk bir atomla karşılaşıldığında
+// o noktaya kadar okunanlar döner.
+func parseBoxes(data []byte) []mp4Box {
+	var boxes []mp4Box
+	for len(data) >= 8 {
+		boxSize := uint64(binary.BigEndian.Uint32(data[0:4]))
+		kind := string(data[4:8])
+		headerLen := uint64(8)
+
+		switch boxSize {
+		case 0:
+			boxSize = uint64(len(data))
+		case 1:
+			if len(data) < 16 {
+				return boxes
+			}
+			boxSize = binary.BigEndian.Uint64(data[8:16])
+			headerLen = 16
+		}
+
+		if boxSize < headerLen || boxSize > uint64(len(data)) {
+			return boxes
+		}
+
+		boxes = append(boxes, mp4Box{kind: kind, body: data[headerLen:boxSize]})
+		data = data[boxSize:]
+	}
+	return boxes
+}
+
+type mp4Track struct {
+	isAudio    bool
+	duration   time.Duration
+	codec      string
+	sampleRate int
+	channels   int
+}
+
+// parseTrack, trak atomunun mdia atomundaki süreyi, parçanın ses parçası olup olmadığını
+// ve stsd atomundaki ilk örnek tanımının codec bilgilerini okur
+func parseTrack(trak []byte) (*mp4Track, error) {
+	track := &mp4Track{}
+	for _, box := range parseBoxes(trak) {
+		if box.kind != "mdia" {
+			continue
+		}
+
+		for _, child := range parseBoxes(box.body) {
+			switch child.kind {
+			case "hdlr":
+				// version/flags (4), pre_defined (4), handler_type (4)
+				if len(child.body) >= 12 && string(child.body[8:12]) == "soun" {
+					track.isAudio = true
+				}
+			case "mdhd":
+				var err error
+				track.duration, err = parseMediaHeader(child.body)
+				if err != nil {
+					return nil, err
+				}
+			case "minf":
+				if stsd := findBox(child.body, "stbl", "stsd"); stsd != nil {
+					parseSampleDescription(stsd, track)
+				}
+			}
+		}
+		break
+	}
+	return track, nil
+}
+
+// findBox, verilen yol üzerindeki iç içe atomu bulur
+func findBox(data []byte, path ...string) []byte {
+	for _, kind := range path {
+		var found []byte
+		for _, box := range parseBoxes(data) {
+			if box.kind == kind {
+				found = box.body
+				break
+			}
+		}
+		if found == nil {
+			return nil
+		}
+		data = found
+	}
+	return data
+}
+
+// parseSampleDescription, stsd atomundaki ilk AudioSampleEntry'den codec, kanal sayısı
+// ve örnekleme hızını okur
+func parseSampleDescription(stsd []byte, track *mp4Track) {
+	// version/flags (4), entry_count (4)
+	if len(stsd) < 8 {
+		return
+	}
+	entries := parseBoxes(stsd[8:])
+	if len(entries) == 0 {
+		return
+	}
+
+	entry := entries[0]
+	track.codec = mp4Codec(entry.kind)
+
+	// reserved (6), data_reference_index (2), version (2), revision (2), vendor (4),
+	// channelcount (2), samplesize (2), compression_id (2), packet_size (2), samplerate (16.16)
+	if len(entry.body) < 28 {
+		return
+	}
+	track.channels = int(binary.BigEndian.Uint16(entry.body[16:18]))
+	track.sampleRate = int(binary.BigEndian.Uint32(entry.body[24:28]) >> 16)
+}
+
+// mp4Codec, örnek tanımı atomunun türünü codec adına çevirir
+func mp4Codec(kind string) string {
+	switch kind {
+	case "mp4a":
+		return "aac"
+	case "alac":
+		return "alac"
+	case "Opus":
+		return "opus"
+	case "fLaC":
+		return "flac"
+	case "ac-3":
+		return "ac3"
+	case "ec-3":
+		return "eac3"
+	default:
+		return "unknown"
+	}
+}
+
+// parseMediaHeader, mvhd ve mdhd atomlarında ortak olan zaman ölçeği ve süre alanlarını okur
+func parseMediaHeader(body []byte) (time.Duration, error) {
+	if len(body) < 4 {
+		return 0, errors.New("geçersiz MP4 başlık atomu")
+	}
+
+	var timescale uint32
+	var duration uint64
+	switch body[0] {
+	case 0:
+		// version/flags (4), creation_time (4), modification_time (4), timescale (4), duration (4)
+		if len(body) < 20 {
+			return 0, errors.New("geçersiz MP4 başlık atomu")
+		}
+		timescale = binary.BigEndian.Uint32(body[12:16])
+		duration = uint64(binary.BigEndian.Uint32(body[16:20]))
+		if duration == 0xFFFFFFFF {
+			duration = 0
+		}
+	case 1:
+		// version/flags (4), creation_time (8), modification_time (8), timescale (4), duration (8)
+		if len(body) < 32 {
+			return 0, errors.New("geçersiz MP4 başlık atomu")
+		}
+		timescale = binary.BigEndian.Uint32(body[20:24])
+		duration = binary.BigEndian.Uint64(body[24:32])
+		if duration == 0xFFFFFFFFFFFFFFFF {
+			duration = 0
+		}
+	default:
+		return 0, errors.New("desteklenmeyen MP4 başlık sürümü")
+	}
+
+	if timescale == 0 {
+		return 0, errors.New("geçersiz MP4 zaman ölçeği")
+	}
+
+	return durationFromUnits(duration, uint64(timescale))
+}
+
+// errDurationTooLong, süre time.Duration ile gösterilemeyecek kadar uzun olduğunda döner
+var errDurationTooLong = errors.New("ses süresi çok uzun")
+
+// durationFromUnits, saniyede rate birim olan bir sayacı süreye çevirir. Sonuç
+// time.Duration'a sığmıyorsa taşmak yerine hata döner.
+func durationFromUnits(units, rate uint64) (time.Duration, error) {
+	seconds := units / rate
+	if seconds > math.MaxInt64/uint64(time.Second) {
+		return 0, errDurationTooLong
+	}
+	// remainder < rate <= MaxUint32 olduğundan çarpım uint64'e sığar
+	whole := time.Duration(seconds) * time.Second
+	fraction := time.Duration((units % rate) * uint64(time.Second) / rate)
+	if whole > math.MaxInt64-fraction {
+		return 0, errDurationTooLong
+	}
+	return whole + fraction, nil
+}
@@ -0,0 +1,133 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+type oggOpusProbe struct{}
+
+const (
+	// oggPageHeaderSize, segment tablosu hariç Ogg sayfa başlığının boyutudur
+	oggPageHeaderSize = 27
+	// opusGranuleRate, Opus akışlarında granule pozisyonunun her zaman kullandığı örnekleme hızıdır
+	opusGranuleRate = 48000
+	// oggTailSize, son sayfayı bulmak için dosyanın sonundan okunan bayt sayısıdır.
+	// Bir Ogg sayfası en fazla 65307 bayt olabilir.
+	oggTailSize = 65536 + oggPageHeaderSize
+)
+
+// isOggOpus, verinin ilk paketi OpusHead olan bir Ogg sayfasıyla başlayıp başlamadığını kontrol eder
+func isOggOpus(header []byte) bool {
+	if len(header) < oggPageHeaderSize || string(header[0:4]) != "OggS" {
+		return false
+	}
+	payload := oggPayloadOffset(header)
+	return payload > 0 && len(header) >= payload+8 && string(header[payload:payload+8]) == "OpusHead"
+}
+
+// oggPayloadOffset, sayfa verisinin başladığı konumu döndürür; başlık eksikse 0 döner
+func oggPayloadOffset(page []byte) int {
+	if len(page) < oggPageHeaderSize {
+		return 0
+	}
+	segments := int(page[26])
+	if len(page) < oggPageHeaderSize+segments {
+		return 0
+	}
+	return oggPageHeaderSize + segments
+}
+
+// Probe, OpusHead paketindeki pre-skip değerini ve aynı akışın son sayfasındaki granule
+// pozisyonunu okur. Süre, 48 kHz üzerinden (granule - pre-skip) olarak hesaplanır.
+func (oggOpusProbe) Probe(r io.ReadSeeker) (*Info, error) {
+	fileSize, err := size(r)
+	if err != nil {
+		return nil, err
+	}
+
+	first := make([]byte, headerSize)
+	n, err := io.ReadFull(r, first)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return nil, errors.New("geçersiz Ogg başlığı")
+	}
+	first = first[:n]
+
+	if !isOggOpus(first) {
+		return nil, errors.New("Ogg dosyası Opus ses içermiyor")
+	}
+
+	serial := binary.LittleEndian.Uint32(first[14:18])
+	payload := oggPayloadOffset(first)
+	// OpusHead: magic (8), version (1), channel count (1), pre-skip (2)
+	if len(first) < payload+12 {
+		return nil, errors.New("geçersiz OpusHead paketi")
+	}
+	channels := int(first[payload+9])
+	preSkip := uint64(binary.LittleEndian.Uint16(first[payload+10 : payload+12]))
+
+	tailStart := fileSize - oggTailSize
+	if tailStart < 0 {
+		tailStart = 0
+	}
+	if _, err := r.Seek(tailStart, io.SeekStart); err != nil {
+		return nil, err
+	}
+	tail, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	granule, ok := lastGranule(tail, serial)
+	if !ok {
+		return nil, errors.New("Ogg dosyasında granule pozisyonu bulunamadı")
+	}
+
+	samples := uint64(0)
+	if granule > preSkip {
+		samples = granule - preSkip
+	}
+
+	duration, err := durationFromUnits(samples, opusGranuleRate)
+	if err != nil {
+		return nil, err
+	}
+
+	// OpusHead'deki örnekleme hızı yalnızca kaynağı belirtir; Opus her zaman 48 kHz olarak çözülür
+	return &Info{
+		Format:     FormatOpus,
+		Codec:      "opus",
+		Duration:   duration,
+		SampleRate: opusGranuleRate,
+		Channels:   channels,
+	}, nil
+}
+
+// lastGranule, verideki son geçerli sayfanın granule pozisyonunu döndürür.
+// Sondan başa doğru aranır; başka bir akışa ait sayfalar ve granule'ü -1 olan
+// (içinde hiçbir paketin bitmediği) sayfalar atlanır.
+func lastGranule(data []byte, serial uint32) (uint64, bool) {
+	end := len(data)
+	for {
+		i := bytes.LastIndex(data[:end], []byte("OggS"))
+		if i < 0 {
+			return 0, false
+		}
+		end = i
+
+		page := data[i:]
+		if len(page) < oggPageHeaderSize || page[4] != 0 {
+			continue
+		}
+		if binary.LittleEndian.Uint32(page[14:18]) != serial {
+			continue
+		}
+		granule := binary.LittleEndian.Uint64(page[6:14])
+		if granule == 0xFFFFFFFFFFFFFFFF {
+			continue
+		}
+		return granule, true
+	}
+}
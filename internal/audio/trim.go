@@ -0,0 +1,107 @@
+package audio
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/tcolgate/mp3"
+)
+
+// TrimMP3, [start, end) aralığına tamamen giren MPEG frame'lerini yeniden kodlamadan w'ye
+// kopyalar. Başlangıç bir frame'in ortasına denk gelirse kesim sonraki frame'den başlar,
+// böylece çıktının süresi hiçbir zaman end-start'ı aşmaz. ID3v2 etiketi korunur; Xing/Info
+// ve VBRI başlık frame'leri kaynağın tamamını tarif ettiğinden atlanır.
+//
+// Kesimden sonraki ilk frame'ler bit rezervuarı üzerinden önceki frame'lerin verisine
+// başvurabileceğinden, çözücüler ilk birkaç milisaniyeyi sessiz çalabilir.
+func TrimMP3(r io.ReadSeeker, w io.Writer, start, end time.Duration) (time.Duration, error) {
+	if start < 0 || end <= start {
+		return 0, errors.New("geçersiz kırpma aralığı")
+	}
+
+	if err := copyID3v2(r, w); err != nil {
+		return 0, err
+	}
+
+	var position, duration time.Duration
+	decoder := mp3.NewDecoder(r)
+	var frame mp3.Frame
+	skipped := 0
+	first := true
+	for position < end {
+		if err := decoder.Decode(&frame, &skipped); err != nil {
+			break
+		}
+		frameStart := position
+		position += frame.Duration()
+
+		if first {
+			first = false
+			if isVBRHeaderFrame(&frame) {
+				position = 0
+				continue
+			}
+		}
+
+		if frameStart < start || position > end {
+			continue
+		}
+		if _, err := io.Copy(w, frame.Reader()); err != nil {
+			return 0, err
+		}
+		duration += frame.Duration()
+	}
+
+	if duration == 0 {
+		return 0, errors.New("kırpma aralığında MP3 frame'i bulunamadı")
+	}
+	return duration, nil
+}
+
+// copyID3v2, dosya bir ID3v2 etiketiyle başlıyorsa etiketi olduğu gibi w'ye kopyalar ve
+// okuyucuyu ilk frame'e konumlar
+func copyID3v2(r io.ReadSeeker, w io.Writer) error {
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	if err := skipID3v2(r); err != nil {
+		return err
+	}
+
+	tagEnd, err := r.Seek(0, io.SeekCurrent)
+	if err != nil || tagEnd == 0 {
+		return err
+	}
+
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	_, err = io.CopyN(w, r, tagEnd)
+	return err
+}
+
+// isVBRHeaderFrame, frame'in ses yerine Xing/Info veya VBRI başlığı taşıyıp taşımadığını kontrol eder.
+// Xing/Info yan bilginin hemen ardından, VBRI ise başlıktan 32 bayt sonra gelir.
+func isVBRHeaderFrame(frame *mp3.Frame) bool {
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, frame.Reader()); err != nil {
+		return false
+	}
+	data := buf.Bytes()
+
+	if sideInfo, err := frame.SideInfoLength(); err == nil {
+		offset := 4 + sideInfo
+		if frame.Header().Protection() {
+			offset += 2
+		}
+		if len(data) >= offset+4 {
+			if tag := string(data[offset : offset+4]); tag == "Xing" || tag == "Info" {
+				return true
+			}
+		}
+	}
+
+	return len(data) >= 40 && string(data[36:40]) == "VBRI"
+}
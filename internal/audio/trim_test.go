@@ -0,0 +1,150 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+)
+
+const (
+	// testFrameSize, 32 kbps 44.1 kHz mono MPEG-1 Layer III frame'inin bayt cinsinden boyutudur
+	testFrameSize = 144 * 32000 / 44100
+	// testMarkerOffset, test frame'lerinin sırasının yazıldığı konumdur; yan bilgiden,
+	// Xing (21) ve VBRI (36) başlıklarının konumlarından sonra gelir
+	testMarkerOffset = 48
+)
+
+// testID3, tek TIT2 çerçevesi içeren bir ID3v2.3 etiketidir
+func testID3() []byte {
+	body := append([]byte{0x03}, "shortcast"...)
+	frame := append([]byte("TIT2"), 0, 0, 0, byte(len(body)), 0, 0)
+	frame = append(frame, body...)
+	tag := []byte{'I', 'D', '3', 3, 0, 0, 0, 0, 0, byte(len(frame))}
+	return append(tag, frame...)
+}
+
+// testFrame, sessiz bir MP3 frame'i oluşturur. tag boş değilse yan bilginin hemen ardına
+// Xing/Info başlığı yazılır.
+func testFrame(marker byte, tag string) []byte {
+	frame := make([]byte, testFrameSize)
+	copy(frame, []byte{0xFF, 0xFB, 0x10, 0xC0})
+	if tag != "" {
+		copy(frame[21:], tag)
+		binary.BigEndian.PutUint32(frame[25:29], 0)
+	}
+	frame[testMarkerOffset] = marker
+	return frame
+}
+
+// testMP3, isteğe bağlı ID3 etiketi ve VBR başlık frame'iyle birlikte sırası işaretlenmiş
+// count adet frame'den oluşan bir MP3 dosyası oluşturur
+func testMP3(withID3 bool, vbrTag string, count int) []byte {
+	var data []byte
+	if withID3 {
+		data = append(data, testID3()...)
+	}
+	if vbrTag != "" {
+		data = append(data, testFrame(0xFF, vbrTag)...)
+	}
+	for i := 0; i < count; i++ {
+		data = append(data, testFrame(byte(i), "")...)
+	}
+	return data
+}
+
+func TestTrimMP3(t *testing.T) {
+	tests := []struct {
+		name    string
+		withID3 bool
+		vbrTag  string
+		start   time.Duration
+		end     time.Duration
+		frames  []byte // çıktıda beklenen frame'lerin sırası
+		wantErr bool
+	}{
+		{
+			name:    "Xing başlığı atlanır ve sayılmaz",
+			withID3: true, vbrTag: "Xing",
+			start: 0, end: 5*mp3FrameDuration + mp3FrameDuration/2,
+			frames: []byte{0, 1, 2, 3, 4},
+		},
+		{
+			name:   "Info başlığı atlanır",
+			vbrTag: "Info",
+			start:  2 * mp3FrameDuration, end: 4*mp3FrameDuration + mp3FrameDuration/2,
+			frames: []byte{2, 3},
+		},
+		{
+			name:    "frame ortasında başlayan kesim sonraki frame'den başlar",
+			withID3: true,
+			start:   2*mp3FrameDuration + mp3FrameDuration/2, end: 6*mp3FrameDuration + mp3FrameDuration/2,
+			frames: []byte{3, 4, 5},
+		},
+		{
+			name:    "VBR başlığı ve frame ortasında başlangıç birlikte",
+			withID3: true, vbrTag: "Xing",
+			start: mp3FrameDuration / 3, end: 3*mp3FrameDuration + mp3FrameDuration/2,
+			frames: []byte{1, 2},
+		},
+		{
+			name:  "aralık dosyanın dışında",
+			start: 20 * mp3FrameDuration, end: 30 * mp3FrameDuration,
+			wantErr: true,
+		},
+		{
+			name:  "aralık tek frame'den kısa",
+			start: mp3FrameDuration / 4, end: mp3FrameDuration / 2,
+			wantErr: true,
+		},
+		{
+			name:  "ters aralık",
+			start: 2 * mp3FrameDuration, end: mp3FrameDuration,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := testMP3(tt.withID3, tt.vbrTag, 10)
+			var out bytes.Buffer
+			duration, err := TrimMP3(bytes.NewReader(src), &out, tt.start, tt.end)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("hata bekleniyordu, süre: %v", duration)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("beklenmeyen hata: %v", err)
+			}
+
+			data := out.Bytes()
+			if tt.withID3 {
+				tag := testID3()
+				if !bytes.HasPrefix(data, tag) {
+					t.Fatalf("ID3 etiketi korunmamış")
+				}
+				data = data[len(tag):]
+			}
+
+			if len(data) != len(tt.frames)*testFrameSize {
+				t.Fatalf("çıktı %d bayt, beklenen %d frame (%d bayt)", len(data), len(tt.frames), len(tt.frames)*testFrameSize)
+			}
+			for i, want := range tt.frames {
+				frame := data[i*testFrameSize : (i+1)*testFrameSize]
+				if got := frame[testMarkerOffset]; got != want {
+					t.Errorf("%d. frame = %d, beklenen %d", i, got, want)
+				}
+			}
+
+			wantDuration := time.Duration(len(tt.frames)) * mp3FrameDuration
+			if diff := duration - wantDuration; diff < -time.Millisecond || diff > time.Millisecond {
+				t.Errorf("süre = %v, beklenen %v", duration, wantDuration)
+			}
+			if duration > tt.end-tt.start {
+				t.Errorf("süre %v, kırpma aralığını (%v) aşıyor", duration, tt.end-tt.start)
+			}
+		})
+	}
+}
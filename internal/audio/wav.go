@@ -0,0 +1,133 @@
+package audio
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+type wavProbe struct{}
+
+const (
+	wavFormatPCM        = 0x0001
+	wavFormatFloat      = 0x0003
+	wavFormatALaw       = 0x0006
+	wavFormatMuLaw      = 0x0007
+	wavFormatExtensible = 0xFFFE
+)
+
+// wavHeader, "fmt " chunk'ının alanları ve "data" chunk'ının konumudur
+type wavHeader struct {
+	formatTag     uint16
+	channels      int
+	sampleRate    int
+	byteRate      uint32
+	blockAlign    int
+	bitsPerSample int
+	dataOffset    int64
+	dataSize      int64
+}
+
+// Probe, "fmt " chunk'ındaki bayt hızını ve "data" chunk'ının boyutunu okur
+func (wavProbe) Probe(r io.ReadSeeker) (*Info, error) {
+	header, err := readWAVHeader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	duration, err := durationFromUnits(uint64(header.dataSize), uint64(header.byteRate))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Info{
+		Format:     FormatWAV,
+		Codec:      wavCodec(header.formatTag),
+		Duration:   duration,
+		Bitrate:    int(header.byteRate) * 8,
+		SampleRate: header.sampleRate,
+		Channels:   header.channels,
+	}, nil
+}
+
+// readWAVHeader, RIFF chunk'larını "data" chunk'ına kadar dolaşır ve okuyucuyu
+// ses verisinin başında bırakır
+func readWAVHeader(r io.ReadSeeker) (*wavHeader, error) {
+	fileSize, err := size(r)
+	if err != nil {
+		return nil, err
+	}
+
+	riff := make([]byte, 12)
+	if _, err := io.ReadFull(r, riff); err != nil {
+		return nil, errors.New("geçersiz WAV başlığı")
+	}
+
+	var header *wavHeader
+	offset := int64(12)
+	chunk := make([]byte, 8)
+	for {
+		if _, err := io.ReadFull(r, chunk); err != nil {
+			return nil, errors.New("WAV dosyasında ses verisi bulunamadı")
+		}
+		chunkSize := int64(binary.LittleEndian.Uint32(chunk[4:8]))
+		offset += 8
+
+		switch string(chunk[0:4]) {
+		case "fmt ":
+			if chunkSize < 16 {
+				return nil, errors.New("geçersiz WAV format bilgisi")
+			}
+			format := make([]byte, min(chunkSize, 40))
+			if _, err := io.ReadFull(r, format); err != nil {
+				return nil, errors.New("geçersiz WAV format bilgisi")
+			}
+			header = &wavHeader{
+				formatTag:     binary.LittleEndian.Uint16(format[0:2]),
+				channels:      int(binary.LittleEndian.Uint16(format[2:4])),
+				sampleRate:    int(binary.LittleEndian.Uint32(format[4:8])),
+				byteRate:      binary.LittleEndian.Uint32(format[8:12]),
+				blockAlign:    int(binary.LittleEndian.Uint16(format[12:14])),
+				bitsPerSample: int(binary.LittleEndian.Uint16(format[14:16])),
+			}
+			// WAVE_FORMAT_EXTENSIBLE dosyalarda asıl format, alt format GUID'inin ilk iki baytıdır
+			if header.formatTag == wavFormatExtensible && len(format) >= 26 {
+				header.formatTag = binary.LittleEndian.Uint16(format[24:26])
+			}
+
+		case "data":
+			if header == nil || header.byteRate == 0 {
+				return nil, errors.New("geçersiz WAV format bilgisi")
+			}
+			// Akış olarak kaydedilen dosyalarda boyut alanı 0 veya 0xFFFFFFFF olabilir
+			if chunkSize == 0 || offset+chunkSize > fileSize {
+				chunkSize = fileSize - offset
+			}
+			header.dataOffset = offset
+			header.dataSize = chunkSize
+			return header, nil
+		}
+
+		// Chunk'lar çift bayt sınırına hizalanır
+		offset += chunkSize + chunkSize%2
+		if _, err := r.Seek(offset, io.SeekStart); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// wavCodec, fmt chunk'ındaki format etiketini codec adına çevirir
+func wavCodec(tag uint16) string {
+	switch tag {
+	case wavFormatPCM:
+		return "pcm"
+	case wavFormatFloat:
+		return "pcm_float"
+	case wavFormatALaw:
+		return "alaw"
+	case wavFormatMuLaw:
+		return "mulaw"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,92 @@
+package audio
+
+import (
+	"io"
+	"math"
+)
+
+// Waveform, audiowaveform JSON formatında (sürüm 2, 8 bit, tek kanal) dalga formu verisidir.
+// Data, her piksel için sırasıyla en küçük ve en büyük değeri içerir.
+type Waveform struct {
+	Version         int    `json:"version"`
+	Channels        int    `json:"channels"`
+	SampleRate      int    `json:"sample_rate"`
+	SamplesPerPixel int    `json:"samples_per_pixel"`
+	Bits            int    `json:"bits"`
+	Length          int    `json:"length"`
+	Data            []int8 `json:"data"`
+}
+
+// GenerateWaveform, çözülen sesi tek kanala indirip en fazla pixels adet min/max çiftine örnekler
+func GenerateWaveform(decoder Decoder, pixels int) (*Waveform, error) {
+	channels := decoder.Channels()
+	samplesPerPixel := int((decoder.Frames() + int64(pixels) - 1) / int64(pixels))
+	if samplesPerPixel < 1 {
+		samplesPerPixel = 1
+	}
+
+	waveform := &Waveform{
+		Version:         2,
+		Channels:        1,
+		SampleRate:      decoder.SampleRate(),
+		SamplesPerPixel: samplesPerPixel,
+		Bits:            8,
+		Data:            make([]int8, 0, pixels*2),
+	}
+
+	buf := make([]float32, 4096*channels)
+	var pending []float32
+	var minValue, maxValue float32
+	count := 0
+
+	flush := func() {
+		waveform.Data = append(waveform.Data, toInt8(minValue), toInt8(maxValue))
+		minValue, maxValue, count = 0, 0, 0
+	}
+
+	for {
+		n, err := decoder.Read(buf)
+		samples := append(pending, buf[:n]...)
+
+		// Yarım kalan frame bir sonraki okumaya bırakılır
+		frames := len(samples) / channels
+		for i := 0; i < frames; i++ {
+			var sum float32
+			for c := 0; c < channels; c++ {
+				sum += samples[i*channels+c]
+			}
+			value := sum / float32(channels)
+
+			if count == 0 || value < minValue {
+				minValue = value
+			}
+			if count == 0 || value > maxValue {
+				maxValue = value
+			}
+			count++
+
+			if count == samplesPerPixel {
+				flush()
+			}
+		}
+		pending = append(pending[:0], samples[frames*channels:]...)
+
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if count > 0 {
+		flush()
+	}
+
+	waveform.Length = len(waveform.Data) / 2
+	return waveform, nil
+}
+
+func toInt8(v float32) int8 {
+	return int8(math.Max(-128, math.Min(127, math.Round(float64(v)*127))))
+}
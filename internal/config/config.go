@@ -5,30 +5,102 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
 
+// DefaultSecretKey, SECRET_KEY tanımlanmadığında kullanılan ve herkesin bildiği anahtardır.
+// İmza anahtarları bu değere veya SECRET_KEY'e düşmez.
+const DefaultSecretKey = "supersecretkey"
+
 type Config struct {
-	Port          string
-	DBHost        string
-	DBPort        string
-	DBUser        string
-	DBPassword    string
-	DBName        string
-	SecretKey     string
-	JWTExpiration int
-	RedisAddr     string
-	RedisPassword string
-	RedisDB       int
-	R2            R2Config
+	Port                     string
+	DBHost                   string
+	DBPort                   string
+	DBUser                   string
+	DBPassword               string
+	DBName                   string
+	SecretKey                string
+	JWTExpiration            int
+	JWTAlgorithm             string
+	JWTKeysDir               string
+	JWTActiveKeyID           string
+	JWTIssuer                string
+	JWTAcceptLegacyHS256     bool
+	RefreshTokenExpiration   int
+	RedisAddr                string
+	RedisPassword            string
+	RedisDB                  int
+	Storage                  StorageConfig
+	AppURL                   string
+	RequireEmailVerification bool
+	Mail                     MailConfig
+	OIDCProviders            []OIDCProviderConfig
+	Jobs                     JobsConfig
+	FFmpegPath               string
+	Loudness                 LoudnessConfig
+	HLS                      HLSConfig
+	Tus                      TusConfig
 }
 
-type R2Config struct {
-	AccountID       string
+// StorageConfig, medya dosyalarının saklandığı depolama sürücüsünün ayarlarıdır
+type StorageConfig struct {
+	Driver          string // s3 (R2, AWS S3, MinIO), local veya memory
+	Endpoint        string // Boşsa AWS'nin bölgeye göre varsayılan adresi kullanılır
+	Region          string
 	AccessKeyID     string
 	AccessKeySecret string
-	BucketName      string
+	Bucket          string
+	UsePathStyle    bool
+	LocalDir        string // local sürücüsünün dizini
+	BaseURL         string // local ve memory sürücülerinin imzalı URL'lerinin kökü
+	SigningKey      string // local ve memory sürücülerinin imza anahtarı; bu sürücülerde zorunludur
+}
+
+type MailConfig struct {
+	Driver       string // smtp, file veya memory
+	From         string
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+	OutboxDir    string
+}
+
+// JobsConfig, yüklenen medyayı arka planda işleyen iş kuyruğunun ayarlarıdır
+type JobsConfig struct {
+	Workers     int    // Aynı anda çalışan iş sayısı
+	MaxAttempts int    // Bir işin dead letter listesine taşınmadan önceki en fazla deneme sayısı
+	SpoolDir    string // Yüklenen dosyaların işlenene kadar bekletildiği dizin
+}
+
+// LoudnessConfig, yüklenen seslerin ses yüksekliğinin ölçülüp normalize edilmesi ayarlarıdır
+type LoudnessConfig struct {
+	Enabled bool
+	Target  float64 // Normalize edilmiş sürümün hedef ses yüksekliği (LUFS)
+}
+
+// HLSConfig, seslerin HLS olarak paketlenmesi ve akış bağlantılarının imzalanması ayarlarıdır
+type HLSConfig struct {
+	Enabled    bool   // ffmpeg gerektirir
+	SigningKey string // HLS açıkken zorunludur; boşsa stream_url verilmez
+}
+
+// TusConfig, tus protokolüyle parça parça yüklenen dosyaların nerede biriktirileceğidir
+type TusConfig struct {
+	Storage string // s3 (multipart) veya disk; boşsa depolama sürücüsü s3 ise s3, değilse disk
+	Dir     string // disk sürücüsünün dizini
+}
+
+// OIDCProviderConfig, OpenID Connect ile giriş yapılabilecek bir sağlayıcının ayarlarıdır
+type OIDCProviderConfig struct {
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
 }
 
 func LoadConfig() (*Config, error) {
@@ -37,26 +109,102 @@ func LoadConfig() (*Config, error) {
 	}
 
 	return &Config{
-		Port:          getEnv("PORT", "8080"),
-		DBHost:        getEnv("DB_HOST", "localhost"),
-		DBPort:        getEnv("DB_PORT", "5432"),
-		DBUser:        getEnv("DB_USER", "ahmet"),
-		DBPassword:    getEnv("DB_PASSWORD", "shortcast"),
-		DBName:        getEnv("DB_NAME", "shortcast"),
-		SecretKey:     getEnv("SECRET_KEY", "supersecretkey"), // JWT secret key
-		JWTExpiration: getEnvAsInt("JWT_EXPIRATION", 3600),    // JWT expiration süresi (saniye olarak)
-		RedisAddr:     getEnv("REDIS_ADDR", "localhost:6379"),
-		RedisPassword: getEnv("REDIS_PASSWORD", ""),
-		RedisDB:       getEnvAsInt("REDIS_DB", 0),
-		R2: R2Config{
-			AccountID:       os.Getenv("R2_ACCOUNT_ID"),
-			AccessKeyID:     os.Getenv("R2_ACCESS_KEY_ID"),
-			AccessKeySecret: os.Getenv("R2_ACCESS_KEY_SECRET"),
-			BucketName:      os.Getenv("R2_BUCKET_NAME"),
+		Port:                     getEnv("PORT", "8080"),
+		DBHost:                   getEnv("DB_HOST", "localhost"),
+		DBPort:                   getEnv("DB_PORT", "5432"),
+		DBUser:                   getEnv("DB_USER", "ahmet"),
+		DBPassword:               getEnv("DB_PASSWORD", "shortcast"),
+		DBName:                   getEnv("DB_NAME", "shortcast"),
+		SecretKey:                getEnv("SECRET_KEY", DefaultSecretKey), // JWT secret key
+		JWTExpiration:            getEnvAsInt("JWT_EXPIRATION", 900),     // Access token süresi (saniye olarak)
+		JWTAlgorithm:             getEnv("JWT_ALGORITHM", "EdDSA"),
+		JWTKeysDir:               getEnv("JWT_KEYS_DIR", "./keys"),
+		JWTActiveKeyID:           os.Getenv("JWT_ACTIVE_KEY_ID"),
+		JWTIssuer:                getEnv("JWT_ISSUER", "shortcast"),
+		JWTAcceptLegacyHS256:     getEnvAsBool("JWT_ACCEPT_LEGACY_HS256", false),
+		RefreshTokenExpiration:   getEnvAsInt("REFRESH_TOKEN_EXPIRATION", 30*24*3600),
+		RedisAddr:                getEnv("REDIS_ADDR", "localhost:6379"),
+		RedisPassword:            getEnv("REDIS_PASSWORD", ""),
+		RedisDB:                  getEnvAsInt("REDIS_DB", 0),
+		Storage:                  loadStorageConfig(),
+		AppURL:                   getEnv("APP_URL", "http://localhost:8080"),
+		RequireEmailVerification: getEnvAsBool("REQUIRE_EMAIL_VERIFICATION", true),
+		Mail: MailConfig{
+			Driver:       getEnv("MAIL_DRIVER", "file"),
+			From:         getEnv("MAIL_FROM", "Shortcast <no-reply@shortcast.local>"),
+			SMTPHost:     getEnv("SMTP_HOST", "localhost"),
+			SMTPPort:     getEnvAsInt("SMTP_PORT", 587),
+			SMTPUsername: os.Getenv("SMTP_USERNAME"),
+			SMTPPassword: os.Getenv("SMTP_PASSWORD"),
+			OutboxDir:    getEnv("MAIL_OUTBOX_DIR", "./tmp/outbox"),
+		},
+		OIDCProviders: loadOIDCProviders(),
+		Jobs: JobsConfig{
+			Workers:     getEnvAsInt("JOB_WORKERS", 4),
+			MaxAttempts: getEnvAsInt("JOB_MAX_ATTEMPTS", 5),
+			SpoolDir:    getEnv("UPLOAD_SPOOL_DIR", "./tmp/uploads"),
+		},
+		FFmpegPath: getEnv("FFMPEG_PATH", "ffmpeg"),
+		Loudness: LoudnessConfig{
+			Enabled: getEnvAsBool("LOUDNESS_NORMALIZATION", false),
+			Target:  getEnvAsFloat("LOUDNESS_TARGET", -16),
+		},
+		HLS: HLSConfig{
+			Enabled:    getEnvAsBool("HLS_PACKAGING", false),
+			SigningKey: os.Getenv("STREAM_SIGNING_KEY"),
+		},
+		Tus: TusConfig{
+			Storage: os.Getenv("TUS_STORAGE"),
+			Dir:     getEnv("TUS_DIR", "./tmp/tus"),
 		},
 	}, nil
 }
 
+// loadStorageConfig, S3_* değişkenlerini okur. Eski kurulumlar için boş olanların yerine
+// R2_* değişkenleri kullanılır; R2_ENDPOINT de yoksa adres R2_ACCOUNT_ID'den oluşturulur.
+func loadStorageConfig() StorageConfig {
+	endpoint := getEnv("S3_ENDPOINT", os.Getenv("R2_ENDPOINT"))
+	if accountID := os.Getenv("R2_ACCOUNT_ID"); endpoint == "" && accountID != "" {
+		endpoint = fmt.Sprintf("https://%s.r2.cloudflarestorage.com", accountID)
+	}
+
+	return StorageConfig{
+		Driver:          getEnv("STORAGE_DRIVER", "s3"),
+		Endpoint:        endpoint,
+		Region:          getEnv("S3_REGION", "auto"),
+		AccessKeyID:     getEnv("S3_ACCESS_KEY_ID", os.Getenv("R2_ACCESS_KEY_ID")),
+		AccessKeySecret: getEnv("S3_SECRET_ACCESS_KEY", os.Getenv("R2_ACCESS_KEY_SECRET")),
+		Bucket:          getEnv("S3_BUCKET", os.Getenv("R2_BUCKET_NAME")),
+		UsePathStyle:    getEnvAsBool("S3_USE_PATH_STYLE", true),
+		LocalDir:        getEnv("STORAGE_LOCAL_DIR", "./storage"),
+		BaseURL:         getEnv("APP_URL", "http://localhost:8080"),
+		SigningKey:      os.Getenv("STORAGE_SIGNING_KEY"),
+	}
+}
+
+// loadOIDCProviders, OIDC_PROVIDERS listesindeki her sağlayıcı için
+// OIDC_<AD>_ISSUER, OIDC_<AD>_CLIENT_ID gibi değişkenleri okur
+func loadOIDCProviders() []OIDCProviderConfig {
+	providers := make([]OIDCProviderConfig, 0)
+	for _, name := range strings.Split(os.Getenv("OIDC_PROVIDERS"), ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		prefix := "OIDC_" + strings.ToUpper(name) + "_"
+		providers = append(providers, OIDCProviderConfig{
+			Name:         name,
+			Issuer:       os.Getenv(prefix + "ISSUER"),
+			ClientID:     os.Getenv(prefix + "CLIENT_ID"),
+			ClientSecret: os.Getenv(prefix + "CLIENT_SECRET"),
+			RedirectURL:  getEnv(prefix+"REDIRECT_URL", getEnv("APP_URL", "http://localhost:8080")+"/api/auth/oidc/"+name+"/callback"),
+			Scopes:       strings.Fields(getEnv(prefix+"SCOPES", "openid email profile")),
+		})
+	}
+	return providers
+}
+
 func getEnv(key, defaultValue string) string {
 	value, exists := os.LookupEnv(key)
 	if !exists {
@@ -73,3 +221,21 @@ func getEnvAsInt(key string, defaultValue int) int {
 	}
 	return intValue
 }
+
+func getEnvAsBool(key string, defaultValue bool) bool {
+	value := getEnv(key, strconv.FormatBool(defaultValue))
+	boolValue, err := strconv.ParseBool(value)
+	if err != nil {
+		return defaultValue
+	}
+	return boolValue
+}
+
+func getEnvAsFloat(key string, defaultValue float64) float64 {
+	value := getEnv(key, strconv.FormatFloat(defaultValue, 'f', -1, 64))
+	floatValue, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return defaultValue
+	}
+	return floatValue
+}
@@ -1,24 +1,37 @@
-package config
-
-import (
-	"fmt"
-	"log"
-	"shortcast/internal/model"
-
-	"gorm.io/driver/postgres"
-	"gorm.io/gorm"
-)
-
-func ConnectDB(cfg *Config) *gorm.DB {
-	dsn := fmt.Sprintf("host=%s port=%s user=%s dbname=%s password=%s sslmode=disable",
-		cfg.DBHost, cfg.DBPort, cfg.DBUser, cfg.DBName, cfg.DBPassword,
-	)
-
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
-	if err != nil {
-		log.Fatalf("Veritabanına bağlanırken bir hata oluştu: %v", err)
-	}
-	db.AutoMigrate(&model.User{}, &model.Podcast{})
-
-	return db
-}
+package config
+
+import (
+	"fmt"
+	"log"
+	"shortcast/internal/model"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func ConnectDB(cfg *Config) *gorm.DB {
+	dsn := fmt.Sprintf("host=%s port=%s user=%s dbname=%s password=%s sslmode=disable",
+		cfg.DBHost, cfg.DBPort, cfg.DBUser, cfg.DBName, cfg.DBPassword,
+	)
+
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	if err != nil {
+		log.Fatalf("Veritabanına bağlanırken bir hata oluştu: %v", err)
+	}
+	// E-posta doğrulaması eklenmeden önce kayıt olmuş kullanıcılar doğrulanmış sayılır;
+	// aksi halde REQUIRE_EMAIL_VERIFICATION açıkken mevcut hesaplar giriş yapamaz
+	verifyExistingUsers := db.Migrator().HasTable(&model.User{}) && !db.Migrator().HasColumn(&model.User{}, "EmailVerifiedAt")
+
+	db.AutoMigrate(&model.User{}, &model.Podcast{})
+
+	if verifyExistingUsers {
+		err := db.Model(&model.User{}).
+			Where("email_verified_at IS NULL").
+			Update("email_verified_at", gorm.Expr("created_at")).Error
+		if err != nil {
+			log.Fatalf("Mevcut kullanıcılar doğrulanmış olarak işaretlenemedi: %v", err)
+		}
+	}
+
+	return db
+}
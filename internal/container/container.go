@@ -2,21 +2,36 @@ package container
 
 import (
 	"log"
+	"os/exec"
+	"shortcast/internal/audio"
 	"shortcast/internal/config"
 	"shortcast/internal/handler"
+	"shortcast/internal/mailer"
 	"shortcast/internal/middleware"
 	"shortcast/internal/model"
+	"shortcast/internal/queue"
 	"shortcast/internal/repository"
 	"shortcast/internal/service"
+	"shortcast/internal/storage"
+	"shortcast/internal/utils"
 )
 
 type Container struct {
 	AuthHandler    *handler.AuthHandler
 	UserHandler    *handler.UserHandler
 	PodcastHandler *handler.PodcastHandler
+	APIKeyHandler  *handler.APIKeyHandler
+	AdminHandler   *handler.AdminHandler
+	OIDCHandler    *handler.OIDCHandler
+	SocialHandler  *handler.SocialHandler
+	TusHandler     *handler.TusHandler
+	FileHandler    *handler.FileHandler
 	AuthMiddleware *middleware.AuthMiddleware
-	R2Service      *service.R2Service
+	StorageService *service.StorageService
 	RedisService   *service.RedisService
+	PodcastService *service.PodcastService
+	TusService     *service.TusService
+	Worker         *queue.Worker
 }
 
 func NewContainer() *Container {
@@ -37,38 +52,129 @@ func NewContainer() *Container {
 		&model.Podcast{},
 		&model.Like{},    // Like modelini ekledik
 		&model.Comment{}, // Comment modelini ekledik
+		&model.Session{},
+		&model.RefreshToken{},
+		&model.UserToken{},
+		&model.RecoveryCode{},
+		&model.APIKey{},
+		&model.UserIdentity{},
+		&model.Follow{},
+		&model.Block{},
+		&model.Mute{},
+		&model.PendingUpload{},
+		&model.TusUpload{},
+		&model.Blob{},
 	)
 	if err != nil {
 		log.Fatalf("Migrasyon hatası: %v", err)
 	}
 
 	userRepo := repository.NewUserRepository(db)
-	userService := service.NewUserService(userRepo)
-	userHandler := handler.NewUserService(userService)
+
+	mail, err := mailer.New(cfg.Mail)
+	if err != nil {
+		log.Fatalf("Mailer oluşturulamadı: %v", err)
+	}
+
+	if cfg.JWTAlgorithm == "HS256" && cfg.SecretKey == config.DefaultSecretKey {
+		log.Println("UYARI: Varsayılan SECRET_KEY kullanılıyor. Üretimde JWT_ALGORITHM=RS256 veya EdDSA kullanın ya da SECRET_KEY'i değiştirin.")
+	}
+
+	keys, err := utils.LoadKeySet(utils.KeySetOptions{
+		Algorithm:    cfg.JWTAlgorithm,
+		KeysDir:      cfg.JWTKeysDir,
+		ActiveKeyID:  cfg.JWTActiveKeyID,
+		Secret:       cfg.SecretKey,
+		AcceptLegacy: cfg.JWTAcceptLegacyHS256,
+		Issuer:       cfg.JWTIssuer,
+	})
+	if err != nil {
+		log.Fatalf("JWT anahtarları yüklenemedi: %v", err)
+	}
 
 	authRepo := repository.NewAuthRepository(db, redis)
-	authService := service.NewAuthService(authRepo, userRepo, cfg)
+	authService := service.NewAuthService(authRepo, userRepo, mail, keys, cfg)
 	authHandler := handler.NewAuthHandler(authService)
 
+	identityRepo := repository.NewIdentityRepository(db, redis)
+	oidcService := service.NewOIDCService(cfg.OIDCProviders, identityRepo, userRepo, authService)
+	oidcHandler := handler.NewOIDCHandler(oidcService)
+
 	podcastRepo := repository.NewPodcastRepository(db)
-	r2Service := service.NewR2Service(
-		cfg.R2.AccountID,
-		cfg.R2.AccessKeyID,
-		cfg.R2.AccessKeySecret,
-		cfg.R2.BucketName,
-	)
+	store, err := storage.New(cfg.Storage)
+	if err != nil {
+		log.Fatalf("Depolama sürücüsü oluşturulamadı: %v", err)
+	}
+	storageService := service.NewStorageService(store, repository.NewBlobRepository(db))
+	fileHandler := handler.NewFileHandler(storageService)
 	redisService := service.NewRedisService(redis)
-	podcastService := service.NewPodcastService(podcastRepo, userRepo, r2Service, redisService, cfg)
+	socialRepo := repository.NewSocialRepository(db)
+
+	// Yüklenen dosyalar Redis tabanlı iş kuyruğu üzerinden arka planda işlenir
+	jobQueue := queue.New(redis, "jobs:media", cfg.Jobs.MaxAttempts)
+	mediaService := service.NewMediaService(podcastRepo, storageService, redisService, jobQueue, cfg.Jobs.SpoolDir)
+	worker := queue.NewWorker(jobQueue, cfg.Jobs.Workers)
+	mediaService.Register(worker)
+	if cfg.Loudness.Enabled {
+		if _, err := exec.LookPath(cfg.FFmpegPath); err != nil {
+			log.Printf("UYARI: ffmpeg bulunamadı (%s). Ses yüksekliği yalnızca MP3 ve WAV dosyalarında ölçülecek, normalize edilmiş sürüm üretilmeyecek.", cfg.FFmpegPath)
+		}
+		mediaService.EnableLoudness(audio.NewLoudnessProcessor(cfg.FFmpegPath), cfg.Loudness.Target)
+	}
+
+	if cfg.HLS.Enabled {
+		// stream_url imzası izleyiciyi belirlediğinden anahtar tahmin edilebilir bir varsayılana düşmemeli
+		if key := cfg.HLS.SigningKey; key == "" || key == config.DefaultSecretKey || key == cfg.SecretKey {
+			log.Fatalf("HLS_PACKAGING açıkken SECRET_KEY'den farklı bir STREAM_SIGNING_KEY tanımlanmalı")
+		}
+		packager, err := audio.NewHLSPackager(cfg.FFmpegPath)
+		if err != nil {
+			log.Printf("UYARI: %v. HLS paketleri üretilmeyecek.", err)
+		} else {
+			mediaService.EnableHLS(packager)
+		}
+	}
+
+	podcastService := service.NewPodcastService(podcastRepo, userRepo, socialRepo, mediaService, storageService, redisService, cfg)
 	podcastHandler := handler.NewPodcastHandler(podcastService)
 
-	authMiddleware := middleware.NewAuthMiddleware(cfg, authRepo)
+	tusStore, err := service.NewTusStore(cfg.Tus.Storage, cfg.Tus.Dir, storageService)
+	if err != nil {
+		log.Fatalf("Tus deposu oluşturulamadı: %v", err)
+	}
+	tusService := service.NewTusService(podcastRepo, userRepo, podcastService, tusStore)
+	tusHandler := handler.NewTusHandler(tusService)
+
+	socialService := service.NewSocialService(socialRepo, userRepo)
+	socialHandler := handler.NewSocialHandler(socialService)
+
+	userService := service.NewUserService(userRepo, podcastRepo, socialRepo, authRepo, authService, storageService, redisService, cfg)
+	userHandler := handler.NewUserService(userService)
+
+	apiKeyRepo := repository.NewAPIKeyRepository(db)
+	apiKeyService := service.NewAPIKeyService(apiKeyRepo)
+	apiKeyHandler := handler.NewAPIKeyHandler(apiKeyService)
+
+	adminService := service.NewAdminService(userRepo, userService)
+	adminHandler := handler.NewAdminHandler(adminService)
+
+	authMiddleware := middleware.NewAuthMiddleware(cfg, keys, authRepo, userRepo, apiKeyService)
 
 	return &Container{
 		AuthHandler:    authHandler,
 		UserHandler:    userHandler,
 		PodcastHandler: podcastHandler,
+		APIKeyHandler:  apiKeyHandler,
+		AdminHandler:   adminHandler,
+		OIDCHandler:    oidcHandler,
+		SocialHandler:  socialHandler,
+		TusHandler:     tusHandler,
+		FileHandler:    fileHandler,
 		AuthMiddleware: authMiddleware,
-		R2Service:      r2Service,
+		StorageService: storageService,
 		RedisService:   redisService,
+		PodcastService: podcastService,
+		TusService:     tusService,
+		Worker:         worker,
 	}
 }
@@ -0,0 +1,135 @@
+// Package cover, yüklenen kapak fotoğraflarını doğrular, meta verilerinden arındırır ve
+// kare küçük boyutlu sürümlerini üretir.
+package cover
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"path"
+	"strings"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+// Sizes, üretilen kare sürümlerin kenar uzunluklarıdır (piksel)
+var Sizes = []int{150, 300, 600}
+
+const (
+	// MinDimension ve MaxDimension, kapak fotoğrafının kenarları için sınırlardır
+	MinDimension = 300
+	MaxDimension = 4096
+	// MaxFileSize, kabul edilen en büyük kapak fotoğrafı boyutudur
+	MaxFileSize = 10 * 1024 * 1024
+
+	jpegQuality = 90
+)
+
+// ErrUnsupportedFormat, dosya JPEG, PNG veya WebP olarak çözülemediğinde döner
+var ErrUnsupportedFormat = errors.New("desteklenmeyen görsel formatı")
+
+// Image, çözülmüş ve EXIF yönü uygulanmış kapak fotoğrafıdır. Dosyadaki EXIF, GPS ve
+// diğer meta veriler yeniden kodlanırken taşınmaz.
+type Image struct {
+	image.Image
+	Format string // jpeg, png veya webp
+}
+
+// Decode, dosyayı standart image paketleriyle çözer. Boyutlar dosyanın tamamı çözülmeden
+// önce başlıktan okunup kontrol edilir.
+func Decode(r io.ReadSeeker) (*Image, error) {
+	config, format, err := image.DecodeConfig(r)
+	if err != nil {
+		return nil, ErrUnsupportedFormat
+	}
+	if config.Width < MinDimension || config.Height < MinDimension {
+		return nil, fmt.Errorf("görsel en az %dx%d piksel olmalı", MinDimension, MinDimension)
+	}
+	if config.Width > MaxDimension || config.Height > MaxDimension {
+		return nil, fmt.Errorf("görsel en fazla %dx%d piksel olabilir", MaxDimension, MaxDimension)
+	}
+
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	img, _, err := image.Decode(r)
+	if err != nil {
+		return nil, fmt.Errorf("görsel çözülemedi: %v", err)
+	}
+
+	if format == "jpeg" {
+		if _, err := r.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+		img = applyOrientation(img, jpegOrientation(r))
+	}
+
+	return &Image{Image: img, Format: format}, nil
+}
+
+// Ext ve ContentType, meta verisi arındırılmış görselin kaydedileceği formatı belirtir.
+// Saydamlığı korumak için PNG'ler PNG, diğerleri JPEG olarak kodlanır.
+func (img *Image) Ext() string {
+	if img.Format == "png" {
+		return ".png"
+	}
+	return ".jpg"
+}
+
+func (img *Image) ContentType() string {
+	if img.Format == "png" {
+		return "image/png"
+	}
+	return "image/jpeg"
+}
+
+// Encode, görseli meta veri olmadan yeniden kodlar
+func (img *Image) Encode(w io.Writer) error {
+	if img.Format == "png" {
+		return png.Encode(w, img.Image)
+	}
+	return encodeJPEG(w, img.Image)
+}
+
+// EncodeRendition, görselin ortasından kare kırpılmış size x size JPEG sürümünü yazar
+func (img *Image) EncodeRendition(w io.Writer, size int) error {
+	bounds := img.Bounds()
+	side := min(bounds.Dx(), bounds.Dy())
+	crop := image.Rect(0, 0, side, side).Add(image.Pt(
+		bounds.Min.X+(bounds.Dx()-side)/2,
+		bounds.Min.Y+(bounds.Dy()-side)/2,
+	))
+
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img.Image, crop, draw.Src, nil)
+	return encodeJPEG(w, dst)
+}
+
+// encodeJPEG, saydam alanları beyaz zemin üzerine çizerek JPEG olarak kodlar
+func encodeJPEG(w io.Writer, img image.Image) error {
+	bounds := img.Bounds()
+	flat := image.NewRGBA(bounds)
+	draw.Draw(flat, bounds, image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(flat, bounds, img, bounds.Min, draw.Over)
+	return jpeg.Encode(w, flat, &jpeg.Options{Quality: jpegQuality})
+}
+
+// RenditionKey, kapak anahtarından verilen boyuttaki sürümün anahtarını türetir.
+// Örn. covers/1700000000_kapak.jpg -> covers/1700000000_kapak_300.jpg
+func RenditionKey(coverKey string, size int) string {
+	return fmt.Sprintf("%s_%d.jpg", strings.TrimSuffix(coverKey, path.Ext(coverKey)), size)
+}
+
+// RenditionKeys, kapak anahtarına ait tüm sürümlerin anahtarlarıdır
+func RenditionKeys(coverKey string) []string {
+	keys := make([]string, 0, len(Sizes))
+	for _, size := range Sizes {
+		keys = append(keys, RenditionKey(coverKey, size))
+	}
+	return keys
+}
@@ -0,0 +1,121 @@
+package cover
+
+import (
+	"bufio"
+	"encoding/binary"
+	"image"
+	"io"
+)
+
+// jpegOrientation, JPEG dosyasının APP1 (Exif) segmentindeki yön etiketini okur. Etiket
+// yoksa veya okunamazsa 1 (normal) döner. Telefonlar fotoğrafları döndürmek yerine bu
+// etiketi yazdığından, meta veriler silinmeden önce yön görsele uygulanmalıdır.
+func jpegOrientation(r io.Reader) int {
+	br := bufio.NewReader(r)
+
+	soi := make([]byte, 2)
+	if _, err := io.ReadFull(br, soi); err != nil || soi[0] != 0xFF || soi[1] != 0xD8 {
+		return 1
+	}
+
+	marker := make([]byte, 4)
+	for {
+		if _, err := io.ReadFull(br, marker); err != nil || marker[0] != 0xFF {
+			return 1
+		}
+		// SOS'tan sonra sıkıştırılmış görüntü verisi başlar
+		if marker[1] == 0xDA {
+			return 1
+		}
+
+		length := int(binary.BigEndian.Uint16(marker[2:4])) - 2
+		if length < 0 {
+			return 1
+		}
+		segment := make([]byte, length)
+		if _, err := io.ReadFull(br, segment); err != nil {
+			return 1
+		}
+
+		if marker[1] == 0xE1 && len(segment) > 6 && string(segment[0:6]) == "Exif\x00\x00" {
+			return exifOrientation(segment[6:])
+		}
+	}
+}
+
+// exifOrientation, TIFF yapısındaki ilk IFD'de 0x0112 etiketini arar
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[0:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	offset := int(order.Uint32(tiff[4:8]))
+	if offset+2 > len(tiff) {
+		return 1
+	}
+	entries := int(order.Uint16(tiff[offset : offset+2]))
+	for i := 0; i < entries; i++ {
+		entry := offset + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:entry+2]) == 0x0112 {
+			value := int(order.Uint16(tiff[entry+8 : entry+10]))
+			if value < 1 || value > 8 {
+				return 1
+			}
+			return value
+		}
+	}
+	return 1
+}
+
+// applyOrientation, Exif yön değerine göre görseli döndürür veya aynalar
+func applyOrientation(img image.Image, orientation int) image.Image {
+	if orientation <= 1 || orientation > 8 {
+		return img
+	}
+
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	// 5-8 arası değerlerde genişlik ve yükseklik yer değiştirir
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2: // yatay aynalama
+				dx, dy = w-1-x, y
+			case 3: // 180 derece
+				dx, dy = w-1-x, h-1-y
+			case 4: // dikey aynalama
+				dx, dy = x, h-1-y
+			case 5: // transpoze
+				dx, dy = y, x
+			case 6: // saat yönünde 90 derece
+				dx, dy = h-1-y, x
+			case 7: // ters transpoze
+				dx, dy = h-1-y, w-1-x
+			case 8: // saat yönünün tersine 90 derece
+				dx, dy = y, w-1-x
+			}
+			dst.Set(dx, dy, img.At(bounds.Min.X+x, bounds.Min.Y+y))
+		}
+	}
+	return dst
+}
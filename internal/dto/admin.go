@@ -0,0 +1,31 @@
+package dto
+
+import "time"
+
+type AdminUserResponse struct {
+	ID            uint      `json:"id"`
+	FirstName     string    `json:"first_name"`
+	LastName      string    `json:"last_name"`
+	Username      string    `json:"username"`
+	Email         string    `json:"email"`
+	Role          string    `json:"role"`
+	EmailVerified bool      `json:"email_verified"`
+	TOTPEnabled   bool      `json:"totp_enabled"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type AdminUserListRequest struct {
+	Page  int `query:"page"`
+	Limit int `query:"limit"`
+}
+
+type AdminUserListResponse struct {
+	Users []AdminUserResponse `json:"users"`
+	Page  int                 `json:"page"`
+	Limit int                 `json:"limit"`
+	Total int64               `json:"total"`
+}
+
+type UpdateUserRoleRequest struct {
+	Role string `json:"role" validate:"required,oneof=user moderator admin"`
+}
@@ -1,62 +1,133 @@
-package dto
-
-import (
-	"time"
-)
-
-type UploadPodcastRequest struct {
-	UserID   uint
-	Title    string `form:"title" validate:"required"`
-	Category string `form:"category" validate:"required"`
-}
-
-type PodcastResponse struct {
-	ID       uint    `json:"id"`
-	Title    string  `json:"title"`
-	Category string  `json:"category"`
-	AudioURL string  `json:"audio_url"`
-	CoverURL string  `json:"cover_url"`
-	User     UserDTO `json:"user"`
-}
-
-type PodcastCursor struct {
-	NextCursor  *uint             `json:"next_cursor,omitempty"`
-	PrevCursor  *uint             `json:"prev_cursor,omitempty"`
-	Podcasts    []PodcastResponse `json:"podcasts"`
-	HasNext     bool              `json:"has_next"`
-	HasPrevious bool              `json:"has_previous"`
-}
-
-type PodcastDiscoverRequest struct {
-	Cursor    *uint  `query:"cursor"`    // İsteğe bağlı cursor
-	Direction string `query:"direction"` // "next" veya "prev"
-	Limit     int    `query:"limit"`     // Sayfa başına podcast sayısı
-}
-
-type UpdatePodcastRequest struct {
-	Title    string `json:"title" validate:"required"`
-	Category string `json:"category" validate:"required"`
-}
-
-type CommentRequest struct {
-	Content string `json:"content" validate:"required"`
-}
-
-type CommentResponse struct {
-	ID        uint      `json:"id"`
-	Content   string    `json:"content"`
-	UserID    uint      `json:"user_id"`
-	Username  string    `json:"username"`
-	CreatedAt time.Time `json:"created_at"`
-}
-
-type LikeResponse struct {
-	PodcastID uint `json:"podcast_id"`
-	UserID    uint `json:"user_id"`
-	Liked     bool `json:"liked"`
-}
-
-// UpdatePodcastCoverRequest, kapak fotoğrafı güncellemek için
-type UpdatePodcastCoverRequest struct {
-	CoverURL string `json:"cover_url"`
-}
+package dto
+
+import (
+	"time"
+)
+
+type UploadPodcastRequest struct {
+	UserID   uint
+	Title    string `form:"title" validate:"required"`
+	Category string `form:"category" validate:"required"`
+	// StartMs ve EndMs, MP3 dosyasından alınacak en fazla 60 saniyelik aralıktır
+	StartMs *int64 `form:"start_ms"`
+	EndMs   *int64 `form:"end_ms"`
+}
+
+type PodcastResponse struct {
+	ID       uint   `json:"id"`
+	Title    string `json:"title"`
+	Category string `json:"category"`
+	Status   string `json:"status"` // processing, ready veya failed
+	// AudioURL ve NormalizedAudioURL, erişimi her istekte kontrol eden /api/podcasts/:id/audio
+	// adresleridir ve Authorization başlığı gerektirir
+	AudioURL   string `json:"audio_url"`
+	MimeType   string `json:"mime_type"`
+	DurationMs int64  `json:"duration_ms"`
+	Bitrate    int    `json:"bitrate"`     // bit/s
+	SampleRate int    `json:"sample_rate"` // Hz
+	Channels   int    `json:"channels"`
+	SizeBytes  int64  `json:"size_bytes"`
+	Codec      string `json:"codec"`
+	// LoudnessLUFS ve LoudnessPeak ölçülmemişse null'dır; istemciler kazancı hedef - LoudnessLUFS olarak uygulayabilir
+	LoudnessLUFS       *float64 `json:"loudness_lufs"`
+	LoudnessPeak       *float64 `json:"loudness_peak"`
+	NormalizedAudioURL string   `json:"normalized_audio_url"`
+	// StreamURL, HLS master playlist'inin yanıtı alan kullanıcıya bağlı imzalı adresidir;
+	// HLS paketleme kapalıyken boştur
+	StreamURL string `json:"stream_url"`
+	CoverURL  string `json:"cover_url"`
+	// CoverURLs, kapağın kare sürümlerinin kenar uzunluğuna ("150", "300", "600") göre URL'leridir
+	CoverURLs map[string]string `json:"cover_urls"`
+	User      UserDTO           `json:"user"`
+}
+
+// PodcastStatusResponse, podcast'in arka planda işlenme durumudur
+type PodcastStatusResponse struct {
+	PodcastID uint   `json:"podcast_id"`
+	Status    string `json:"status"`          // processing, ready veya failed
+	Stage     string `json:"stage,omitempty"` // queued, uploading_audio, uploading_cover, waveform, loudness
+	Progress  int    `json:"progress"`        // 0-100
+	Error     string `json:"error,omitempty"`
+}
+
+// CreateUploadRequest, dosyaların doğrudan R2'ye yüklenmesi için imzalı URL isteğidir.
+// Boyut ve içerik türü imzaya dahil edilir; istemci tam olarak bu değerlerle yüklemelidir.
+type CreateUploadRequest struct {
+	Title            string `json:"title"`
+	Category         string `json:"category"`
+	AudioFilename    string `json:"audio_filename"`
+	AudioSize        int64  `json:"audio_size"`
+	AudioContentType string `json:"audio_content_type"`
+	CoverFilename    string `json:"cover_filename"`
+	CoverSize        int64  `json:"cover_size"`
+	CoverContentType string `json:"cover_content_type"`
+}
+
+// PresignedUpload, istemcinin dosyayı yüklemek için göndereceği istektir
+type PresignedUpload struct {
+	URL     string            `json:"url"`
+	Method  string            `json:"method"`
+	Headers map[string]string `json:"headers"` // İstekte gönderilmesi gereken başlıklar
+}
+
+type UploadSessionResponse struct {
+	ID        uint            `json:"id"`
+	Audio     PresignedUpload `json:"audio"`
+	Cover     PresignedUpload `json:"cover"`
+	ExpiresAt time.Time       `json:"expires_at"`
+}
+
+// CompleteUploadRequest, yüklemeyi tamamlarken isteğe bağlı kırpma aralığıdır
+type CompleteUploadRequest struct {
+	StartMs *int64 `json:"start_ms"`
+	EndMs   *int64 `json:"end_ms"`
+}
+
+type PodcastCursor struct {
+	NextCursor  *uint             `json:"next_cursor,omitempty"`
+	PrevCursor  *uint             `json:"prev_cursor,omitempty"`
+	Podcasts    []PodcastResponse `json:"podcasts"`
+	HasNext     bool              `json:"has_next"`
+	HasPrevious bool              `json:"has_previous"`
+}
+
+type PodcastDiscoverRequest struct {
+	Cursor    *uint  `query:"cursor"`    // İsteğe bağlı cursor
+	Direction string `query:"direction"` // "next" veya "prev"
+	Limit     int    `query:"limit"`     // Sayfa başına podcast sayısı
+}
+
+// PodcastFeedRequest, takip akışı için en yeniden eskiye sayfalama parametreleridir.
+// Cursor, bir önceki sayfanın next_cursor değeridir.
+type PodcastFeedRequest struct {
+	Cursor *uint `query:"cursor"`
+	Limit  int   `query:"limit"`
+}
+
+type UpdatePodcastRequest struct {
+	Title    string `json:"title" validate:"required"`
+	Category string `json:"category" validate:"required"`
+}
+
+type CommentRequest struct {
+	Content string `json:"content" validate:"required"`
+}
+
+type CommentResponse struct {
+	ID        uint      `json:"id"`
+	Content   string    `json:"content"`
+	UserID    uint      `json:"user_id"`
+	Username  string    `json:"username"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type LikeResponse struct {
+	PodcastID uint `json:"podcast_id"`
+	UserID    uint `json:"user_id"`
+	Liked     bool `json:"liked"`
+}
+
+// UpdatePodcastCoverRequest, kapak fotoğrafı güncellemek için
+type UpdatePodcastCoverRequest struct {
+	CoverURL string `json:"cover_url"`
+}
@@ -1,29 +1,82 @@
-package dto
-
-type RegisterRequest struct {
-	FirstName string `json:"first_name" validate:"required"`
-	LastName  string `json:"last_name" validate:"required"`
-	Username  string `json:"username" validate:"required"`
-	Email     string `json:"email" validate:"required,email"`
-	Password  string `json:"password" validate:"required,min=6"`
-}
-
-type LoginRequest struct {
-	EmailOrUsername string `json:"emailOrUsername" validate:"required"`
-	Password        string `json:"password" validate:"required"`
-}
-
-type UserResponse struct {
-	ID        uint   `json:"id"`
-	Firstname string `json:"firstname"`
-	Lastname  string `json:"lastname"`
-	Username  string `json:"username"`
-	Email     string `json:"email"`
-}
-
-type UserDTO struct {
-	ID        uint   `json:"id"`
-	FirstName string `json:"first_name"`
-	LastName  string `json:"last_name"`
-	Username  string `json:"username"`
-}
+package dto
+
+import "time"
+
+type RegisterRequest struct {
+	FirstName string `json:"first_name" validate:"required"`
+	LastName  string `json:"last_name" validate:"required"`
+	Username  string `json:"username" validate:"required"`
+	Email     string `json:"email" validate:"required,email"`
+	Password  string `json:"password" validate:"required,min=6"`
+}
+
+type LoginRequest struct {
+	EmailOrUsername string `json:"emailOrUsername" validate:"required"`
+	Password        string `json:"password" validate:"required"`
+}
+
+// UserResponse, e-posta adresini yalnızca kullanıcı kendi bilgilerine baktığında içerir
+type UserResponse struct {
+	ID        uint   `json:"id"`
+	Firstname string `json:"firstname"`
+	Lastname  string `json:"lastname"`
+	Username  string `json:"username"`
+	Email     string `json:"email,omitempty"`
+	Bio       string `json:"bio"`
+	AvatarURL string `json:"avatar_url"`
+	Website   string `json:"website"`
+	Location  string `json:"location"`
+}
+
+type ProfileStats struct {
+	Podcasts      int64 `json:"podcasts"`
+	Followers     int64 `json:"followers"`
+	Following     int64 `json:"following"`
+	LikesReceived int64 `json:"likes_received"`
+}
+
+type ProfileResponse struct {
+	UserResponse
+	CreatedAt   time.Time    `json:"created_at"`
+	IsFollowing bool         `json:"is_following"`
+	Stats       ProfileStats `json:"stats"`
+}
+
+type FollowListRequest struct {
+	Page  int `query:"page"`
+	Limit int `query:"limit"`
+}
+
+type FollowListResponse struct {
+	Users []UserDTO `json:"users"`
+	Page  int       `json:"page"`
+	Limit int       `json:"limit"`
+	Total int64     `json:"total"`
+}
+
+// UpdateProfileRequest, yalnızca gönderilen alanları günceller
+type UpdateProfileRequest struct {
+	FirstName *string `json:"first_name"`
+	LastName  *string `json:"last_name"`
+	Username  *string `json:"username"`
+	Email     *string `json:"email" validate:"omitempty,email"`
+	Bio       *string `json:"bio" validate:"omitempty,max=500"`
+	Website   *string `json:"website" validate:"omitempty,url"`
+	Location  *string `json:"location" validate:"omitempty,max=100"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password" validate:"required,min=6"`
+}
+
+type DeleteAccountRequest struct {
+	Password string `json:"password"`
+}
+
+type UserDTO struct {
+	ID        uint   `json:"id"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Username  string `json:"username"`
+}
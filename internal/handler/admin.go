@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"shortcast/internal/dto"
+	"shortcast/internal/service"
+	"shortcast/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type AdminHandler struct {
+	adminService *service.AdminService
+}
+
+func NewAdminHandler(adminService *service.AdminService) *AdminHandler {
+	return &AdminHandler{adminService: adminService}
+}
+
+// ListUsers godoc
+// @Summary      List users
+// @Description  List all users with pagination (admin only)
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page   query     integer  false  "Page number"
+// @Param        limit  query     integer  false  "Users per page"
+// @Success      200  {object}  dto.AdminUserListResponse
+// @Failure      403  {object}  map[string]string  "error"
+// @Router       /admin/users [get]
+func (h *AdminHandler) ListUsers(c *fiber.Ctx) error {
+	var req dto.AdminUserListRequest
+	if err := c.QueryParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Geçersiz sorgu parametreleri",
+		})
+	}
+
+	users, err := h.adminService.ListUsers(&req)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Kullanıcılar getirilirken bir hata oluştu",
+		})
+	}
+
+	return c.JSON(users)
+}
+
+// GetUser godoc
+// @Summary      Get user details
+// @Description  Get a user's account details including role (admin only)
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "User ID"
+// @Success      200  {object}  dto.AdminUserResponse
+// @Failure      404  {object}  map[string]string  "error"
+// @Router       /admin/users/{id} [get]
+func (h *AdminHandler) GetUser(c *fiber.Ctx) error {
+	id, err := utils.ParamAsUint(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	user, err := h.adminService.GetUser(id)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(user)
+}
+
+// UpdateUserRole godoc
+// @Summary      Change user role
+// @Description  Change a user's role to user, moderator or admin (admin only)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id    path      int                        true  "User ID"
+// @Param        role  body      dto.UpdateUserRoleRequest  true  "New role"
+// @Success      200  {object}  dto.AdminUserResponse
+// @Failure      400  {object}  map[string]string  "error"
+// @Failure      404  {object}  map[string]string  "error"
+// @Router       /admin/users/{id}/role [put]
+func (h *AdminHandler) UpdateUserRole(c *fiber.Ctx) error {
+	id, err := utils.ParamAsUint(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	actorID := uint(claims["user_id"].(float64))
+
+	var req dto.UpdateUserRoleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Geçersiz istek verisi",
+		})
+	}
+
+	updated, err := h.adminService.UpdateUserRole(actorID, id, req.Role)
+	if err != nil {
+		if err.Error() == "kullanıcı bulunamadı" {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(updated)
+}
+
+// DeleteUser godoc
+// @Summary      Delete a user
+// @Description  Permanently delete a user account with its podcasts, likes, comments, API keys and files, and sign out all of its sessions (admin only)
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "User ID"
+// @Success      204  {object}  nil
+// @Failure      400  {object}  map[string]string  "error"
+// @Failure      404  {object}  map[string]string  "error"
+// @Router       /admin/users/{id} [delete]
+func (h *AdminHandler) DeleteUser(c *fiber.Ctx) error {
+	id, err := utils.ParamAsUint(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	actorID := uint(claims["user_id"].(float64))
+
+	if err := h.adminService.DeleteUser(actorID, id); err != nil {
+		if err.Error() == "kullanıcı bulunamadı" {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
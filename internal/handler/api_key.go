@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"shortcast/internal/dto"
+	"shortcast/internal/service"
+	"shortcast/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type APIKeyHandler struct {
+	apiKeyService *service.APIKeyService
+}
+
+func NewAPIKeyHandler(apiKeyService *service.APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{apiKeyService: apiKeyService}
+}
+
+// CreateAPIKey godoc
+// @Summary      Create an API key
+// @Description  Create a personal API key with the given scopes (podcasts:read, podcasts:write, comments:write, users:read). The key is only returned once.
+// @Tags         api-keys
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        key  body      dto.CreateAPIKeyRequest  true  "Key name and scopes"
+// @Success      201  {object}  dto.CreateAPIKeyResponse
+// @Failure      400  {object}  map[string]string  "error"
+// @Router       /api-keys [post]
+func (h *APIKeyHandler) CreateAPIKey(c *fiber.Ctx) error {
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userID := uint(claims["user_id"].(float64))
+
+	var req dto.CreateAPIKeyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Geçersiz istek verisi",
+		})
+	}
+
+	key, err := h.apiKeyService.CreateAPIKey(userID, &req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(key)
+}
+
+// GetAPIKeys godoc
+// @Summary      List API keys
+// @Description  List the authenticated user's active API keys
+// @Tags         api-keys
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {array}   dto.APIKeyResponse
+// @Failure      500  {object}  map[string]string  "error"
+// @Router       /api-keys [get]
+func (h *APIKeyHandler) GetAPIKeys(c *fiber.Ctx) error {
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userID := uint(claims["user_id"].(float64))
+
+	keys, err := h.apiKeyService.GetAPIKeys(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "API anahtarları getirilirken bir hata oluştu",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"api_keys": keys,
+	})
+}
+
+// RevokeAPIKey godoc
+// @Summary      Revoke an API key
+// @Description  Revoke one of the authenticated user's API keys
+// @Tags         api-keys
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "API key ID"
+// @Success      204  {object}  nil
+// @Failure      400  {object}  map[string]string  "error"
+// @Failure      404  {object}  map[string]string  "error"
+// @Router       /api-keys/{id} [delete]
+func (h *APIKeyHandler) RevokeAPIKey(c *fiber.Ctx) error {
+	id, err := utils.ParamAsUint(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Geçersiz API anahtarı ID",
+		})
+	}
+
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userID := uint(claims["user_id"].(float64))
+
+	if err := h.apiKeyService.RevokeAPIKey(id, userID); err != nil {
+		if err.Error() == "API anahtarı bulunamadı" {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "API anahtarı bulunamadı",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "API anahtarı iptal edilirken bir hata oluştu",
+		})
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -3,6 +3,7 @@ package handler
 import (
 	"shortcast/internal/dto"
 	"shortcast/internal/service"
+	"shortcast/internal/utils"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v5"
@@ -21,12 +22,12 @@ func NewAuthHandler(authService *service.AuthService) *AuthHandler {
 // Login godoc
 //
 //	@Summary		Login user
-//	@Description	Authenticate user with email/username and password
+//	@Description	Authenticate user with email/username and password. If two-factor authentication is enabled, a challenge token is returned instead of the token pair and the login must be completed with /auth/login/2fa.
 //	@Tags			auth
 //	@Accept			json
 //	@Produce		json
 //	@Param			login	body		dto.LoginRequest	true	"Login credentials"
-//	@Success		200		{object}	map[string]string	"token"
+//	@Success		200		{object}	dto.LoginResponse
 //	@Failure		400		{object}	map[string]string	"error"
 //	@Failure		401		{object}	map[string]string	"error"
 //	@Router			/auth/login [post]
@@ -39,16 +40,76 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
 		})
 	}
 
-	token, err := h.authService.Login(request.EmailOrUsername, request.Password)
+	response, err := h.authService.Login(request.EmailOrUsername, request.Password, c.Get(fiber.HeaderUserAgent), c.IP())
 	if err != nil {
 		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
 
-	return c.JSON(fiber.Map{
-		"token": token,
-	})
+	return c.JSON(response)
+}
+
+// LoginTwoFactor godoc
+//
+//	@Summary		Complete two-factor login
+//	@Description	Complete the login with the challenge token returned by /auth/login and a TOTP or recovery code
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			login	body		dto.TwoFactorLoginRequest	true	"Challenge token and code"
+//	@Success		200		{object}	dto.TokenResponse
+//	@Failure		400		{object}	map[string]string	"error"
+//	@Failure		401		{object}	map[string]string	"error"
+//	@Router			/auth/login/2fa [post]
+func (h *AuthHandler) LoginTwoFactor(c *fiber.Ctx) error {
+	var request dto.TwoFactorLoginRequest
+
+	if err := c.BodyParser(&request); err != nil || request.ChallengeToken == "" || request.Code == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Geçersiz istek verisi",
+		})
+	}
+
+	tokens, err := h.authService.LoginWithTwoFactor(request.ChallengeToken, request.Code, c.Get(fiber.HeaderUserAgent), c.IP())
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(tokens)
+}
+
+// Refresh godoc
+//
+//	@Summary		Refresh access token
+//	@Description	Exchange a refresh token for a new access token and a rotated refresh token
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			refresh	body		dto.RefreshTokenRequest	true	"Refresh token"
+//	@Success		200		{object}	dto.TokenResponse
+//	@Failure		400		{object}	map[string]string	"error"
+//	@Failure		401		{object}	map[string]string	"error"
+//	@Router			/auth/refresh [post]
+func (h *AuthHandler) Refresh(c *fiber.Ctx) error {
+	var request dto.RefreshTokenRequest
+
+	if err := c.BodyParser(&request); err != nil || request.RefreshToken == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Geçersiz istek verisi",
+		})
+	}
+
+	tokens, err := h.authService.Refresh(request.RefreshToken, c.Get(fiber.HeaderUserAgent), c.IP())
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(tokens)
 }
 
 // Register godoc
@@ -108,3 +169,308 @@ func (h *AuthHandler) Logout(c *fiber.Ctx) error {
 		"message": "Başarıyla çıkış yapıldı",
 	})
 }
+
+// GetSessions godoc
+// @Summary      List active sessions
+// @Description  List the authenticated user's active device sessions
+// @Tags         auth
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {array}   dto.SessionResponse
+// @Failure      500  {object}  map[string]string  "error"
+// @Router       /auth/sessions [get]
+func (h *AuthHandler) GetSessions(c *fiber.Ctx) error {
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userID := uint(claims["user_id"].(float64))
+
+	var currentSessionID uint
+	if sid, ok := claims["sid"].(float64); ok {
+		currentSessionID = uint(sid)
+	}
+
+	sessions, err := h.authService.GetSessions(userID, currentSessionID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Oturumlar getirilirken bir hata oluştu",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"sessions": sessions,
+	})
+}
+
+// RevokeSession godoc
+// @Summary      Revoke a session
+// @Description  Sign out a single device session of the authenticated user
+// @Tags         auth
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Session ID"
+// @Success      204  {object}  nil
+// @Failure      400  {object}  map[string]string  "error"
+// @Failure      404  {object}  map[string]string  "error"
+// @Router       /auth/sessions/{id} [delete]
+func (h *AuthHandler) RevokeSession(c *fiber.Ctx) error {
+	sessionID, err := utils.ParamAsUint(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Geçersiz oturum ID",
+		})
+	}
+
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userID := uint(claims["user_id"].(float64))
+
+	if err := h.authService.RevokeSession(userID, sessionID); err != nil {
+		if err.Error() == "oturum bulunamadı" {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Oturum bulunamadı",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Oturum sonlandırılırken bir hata oluştu",
+		})
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// VerifyEmail godoc
+// @Summary      Verify email address
+// @Description  Verify the user's email address with the token sent by email. The token can be sent as a query parameter (link in the email) or in the body.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        token   query     string                  false  "Verification token"
+// @Param        verify  body      dto.VerifyEmailRequest  false  "Verification token"
+// @Success      200  {object}  map[string]string  "message"
+// @Failure      400  {object}  map[string]string  "error"
+// @Router       /auth/verify [get]
+// @Router       /auth/verify [post]
+func (h *AuthHandler) VerifyEmail(c *fiber.Ctx) error {
+	token := c.Query("token")
+	if token == "" {
+		var request dto.VerifyEmailRequest
+		if err := c.BodyParser(&request); err == nil {
+			token = request.Token
+		}
+	}
+
+	if token == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Doğrulama token'ı gerekli",
+		})
+	}
+
+	if err := h.authService.VerifyEmail(token); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "E-posta adresi doğrulandı",
+	})
+}
+
+// ResendVerification godoc
+// @Summary      Resend verification email
+// @Description  Send a new email verification link if an unverified account exists for the given address. Previously sent links stop working.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        resend  body      dto.ResendVerificationRequest  true  "Email address"
+// @Success      200  {object}  map[string]string  "message"
+// @Failure      400  {object}  map[string]string  "error"
+// @Router       /auth/verify/resend [post]
+func (h *AuthHandler) ResendVerification(c *fiber.Ctx) error {
+	var request dto.ResendVerificationRequest
+
+	if err := c.BodyParser(&request); err != nil || request.Email == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Geçersiz istek verisi",
+		})
+	}
+
+	if err := h.authService.ResendVerificationEmail(request.Email); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Doğrulama e-postası gönderilemedi",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Adres kayıtlı ve doğrulanmamışsa doğrulama e-postası gönderildi",
+	})
+}
+
+// ForgotPassword godoc
+// @Summary      Request password reset
+// @Description  Send a password reset token to the given email address if an account exists
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        forgot  body      dto.ForgotPasswordRequest  true  "Email address"
+// @Success      200  {object}  map[string]string  "message"
+// @Failure      400  {object}  map[string]string  "error"
+// @Router       /auth/forgot-password [post]
+func (h *AuthHandler) ForgotPassword(c *fiber.Ctx) error {
+	var request dto.ForgotPasswordRequest
+
+	if err := c.BodyParser(&request); err != nil || request.Email == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Geçersiz istek verisi",
+		})
+	}
+
+	if err := h.authService.ForgotPassword(request.Email); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Şifre sıfırlama e-postası gönderilemedi",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Adres kayıtlıysa şifre sıfırlama e-postası gönderildi",
+	})
+}
+
+// ResetPassword godoc
+// @Summary      Reset password
+// @Description  Set a new password using a password reset token. All sessions of the user are signed out.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        reset  body      dto.ResetPasswordRequest  true  "Reset token and new password"
+// @Success      200  {object}  map[string]string  "message"
+// @Failure      400  {object}  map[string]string  "error"
+// @Router       /auth/reset-password [post]
+func (h *AuthHandler) ResetPassword(c *fiber.Ctx) error {
+	var request dto.ResetPasswordRequest
+
+	if err := c.BodyParser(&request); err != nil || request.Token == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Geçersiz istek verisi",
+		})
+	}
+
+	if len(request.Password) < 6 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Şifre en az 6 karakter olmalıdır",
+		})
+	}
+
+	if err := h.authService.ResetPassword(request.Token, request.Password); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Şifre başarıyla değiştirildi",
+	})
+}
+
+// SetupTOTP godoc
+// @Summary      Start two-factor setup
+// @Description  Generate a TOTP secret and otpauth URI. Two-factor authentication is enabled after confirming a code.
+// @Tags         auth
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  dto.TOTPSetupResponse
+// @Failure      400  {object}  map[string]string  "error"
+// @Router       /auth/2fa/setup [post]
+func (h *AuthHandler) SetupTOTP(c *fiber.Ctx) error {
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userID := uint(claims["user_id"].(float64))
+
+	response, err := h.authService.SetupTOTP(userID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(response)
+}
+
+// ConfirmTOTP godoc
+// @Summary      Confirm two-factor setup
+// @Description  Verify the first TOTP code, enable two-factor authentication and return single-use recovery codes
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        confirm  body      dto.TOTPConfirmRequest  true  "TOTP code"
+// @Success      200  {object}  dto.TOTPConfirmResponse
+// @Failure      400  {object}  map[string]string  "error"
+// @Router       /auth/2fa/confirm [post]
+func (h *AuthHandler) ConfirmTOTP(c *fiber.Ctx) error {
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userID := uint(claims["user_id"].(float64))
+
+	var request dto.TOTPConfirmRequest
+	if err := c.BodyParser(&request); err != nil || request.Code == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Geçersiz istek verisi",
+		})
+	}
+
+	response, err := h.authService.ConfirmTOTP(userID, request.Code)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(response)
+}
+
+// DisableTOTP godoc
+// @Summary      Disable two-factor authentication
+// @Description  Disable two-factor authentication with a TOTP or recovery code. Accounts with a password must also send the current password; accounts without one (created through an external provider) need a session opened by a login within the last 10 minutes instead.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        disable  body      dto.TOTPDisableRequest  true  "Password and code"
+// @Success      200  {object}  map[string]string  "message"
+// @Failure      400  {object}  map[string]string  "error"
+// @Router       /auth/2fa/disable [post]
+func (h *AuthHandler) DisableTOTP(c *fiber.Ctx) error {
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userID := uint(claims["user_id"].(float64))
+
+	var request dto.TOTPDisableRequest
+	if err := c.BodyParser(&request); err != nil || request.Code == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Geçersiz istek verisi",
+		})
+	}
+
+	var currentSessionID uint
+	if sid, ok := claims["sid"].(float64); ok {
+		currentSessionID = uint(sid)
+	}
+
+	if err := h.authService.DisableTOTP(userID, currentSessionID, request.Password, request.Code); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "İki adımlı doğrulama kapatıldı",
+	})
+}
+
+// JWKS, /.well-known/jwks.json adresinde access token'ları imzalayan anahtarların
+// public kısımlarını yayınlar. API grubunun dışında olduğu için Swagger'a dahil edilmez.
+func (h *AuthHandler) JWKS(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderCacheControl, "public, max-age=300")
+	return c.JSON(h.authService.JWKS())
+}
@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"shortcast/internal/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// FileHandler, local ve memory depolama sürücülerinin imzalı URL'lerini sunar. S3
+// sürücüsünde imzalı URL'ler doğrudan bucket'a gittiğinden bu route'lar 404 döner.
+type FileHandler struct {
+	storageService *service.StorageService
+}
+
+func NewFileHandler(storageService *service.StorageService) *FileHandler {
+	return &FileHandler{storageService: storageService}
+}
+
+// GetFile godoc
+// @Summary      Download a stored file
+// @Description  Serves files of the local and memory storage drivers through signed URLs returned by the API (cover_url, avatar_url and HLS segments). Supports single byte ranges and If-None-Match.
+// @Tags         files
+// @Param        key        path   string  true  "File key"
+// @Param        expires    query  int     true  "Expiry (unix seconds)"
+// @Param        signature  query  string  true  "Signature"
+// @Success      200
+// @Success      206
+// @Failure      403  {object}  map[string]string  "İmzalı bağlantı geçersiz veya süresi dolmuş"
+// @Failure      404  {object}  map[string]string  "Dosya bulunamadı"
+// @Failure      416  {object}  map[string]string  "Geçersiz aralık"
+// @Router       /files/{key} [get]
+func (h *FileHandler) GetFile(c *fiber.Ctx) error {
+	key, err := url.PathUnescape(c.Params("*"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Dosya bulunamadı"})
+	}
+
+	object, err := h.storageService.OpenSignedFile(key, c.Query("expires"), c.Query("signature"),
+		c.Get(fiber.HeaderRange), c.Get(fiber.HeaderIfNoneMatch))
+	if err != nil {
+		return fileError(c, err)
+	}
+
+	c.Set(fiber.HeaderAcceptRanges, "bytes")
+	c.Set(fiber.HeaderContentType, object.ContentType)
+	c.Set(fiber.HeaderETag, object.ETag)
+	if !object.LastModified.IsZero() {
+		c.Set(fiber.HeaderLastModified, object.LastModified.UTC().Format(http.TimeFormat))
+	}
+	c.Set(fiber.HeaderCacheControl, "private, max-age=3600")
+	if object.ContentRange != "" {
+		c.Set(fiber.HeaderContentRange, object.ContentRange)
+		c.Status(fiber.StatusPartialContent)
+	}
+	return c.SendStream(object.Body, int(object.ContentLength))
+}
+
+// PutFile godoc
+// @Summary      Upload a file to a signed URL
+// @Description  Target of the presigned PUT URLs returned by POST /podcasts/uploads when the local or memory storage driver is used. Content-Type and the body size must match the signed values.
+// @Tags         files
+// @Param        key        path   string  true  "File key"
+// @Param        expires    query  int     true  "Expiry (unix seconds)"
+// @Param        signature  query  string  true  "Signature"
+// @Success      200
+// @Failure      403  {object}  map[string]string  "İmzalı bağlantı geçersiz veya süresi dolmuş"
+// @Failure      404  {object}  map[string]string  "Dosya bulunamadı"
+// @Router       /files/{key} [put]
+func (h *FileHandler) PutFile(c *fiber.Ctx) error {
+	key, err := url.PathUnescape(c.Params("*"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Dosya bulunamadı"})
+	}
+
+	err = h.storageService.PutSignedFile(key, c.Query("expires"), c.Query("signature"),
+		c.Get(fiber.HeaderContentType), c.Body())
+	if err != nil {
+		return fileError(c, err)
+	}
+	return c.SendStatus(fiber.StatusOK)
+}
+
+func fileError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, service.ErrNotModified):
+		c.Set(fiber.HeaderETag, c.Get(fiber.HeaderIfNoneMatch))
+		return c.SendStatus(fiber.StatusNotModified)
+	case errors.Is(err, service.ErrInvalidRange):
+		return c.Status(fiber.StatusRequestedRangeNotSatisfiable).JSON(fiber.Map{"error": "Geçersiz aralık"})
+	case errors.Is(err, service.ErrInvalidSignature):
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "İmzalı bağlantı geçersiz veya süresi dolmuş"})
+	case errors.Is(err, service.ErrFileNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Dosya bulunamadı"})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Dosya işlenirken bir hata oluştu"})
+	}
+}
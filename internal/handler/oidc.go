@@ -0,0 +1,215 @@
+package handler
+
+import (
+	"shortcast/internal/dto"
+	"shortcast/internal/service"
+	"shortcast/internal/utils"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// oidcStateCookie, OIDC akışını başlatan tarayıcıya verilen state cookie'sidir
+const oidcStateCookie = "oidc_state"
+
+type OIDCHandler struct {
+	oidcService *service.OIDCService
+}
+
+func NewOIDCHandler(oidcService *service.OIDCService) *OIDCHandler {
+	return &OIDCHandler{oidcService: oidcService}
+}
+
+// GetProviders godoc
+// @Summary      List OpenID Connect providers
+// @Description  List the external providers that can be used to sign in
+// @Tags         auth
+// @Produce      json
+// @Success      200  {object}  dto.OIDCProvidersResponse
+// @Router       /auth/oidc/providers [get]
+func (h *OIDCHandler) GetProviders(c *fiber.Ctx) error {
+	return c.JSON(dto.OIDCProvidersResponse{
+		Providers: h.oidcService.Providers(),
+	})
+}
+
+// Login godoc
+// @Summary      Sign in with an OpenID Connect provider
+// @Description  Redirect to the provider's login page (authorization code flow with PKCE). The provider redirects back to /auth/oidc/{provider}/callback, which must be opened in the same browser (the state is bound with an HttpOnly cookie).
+// @Tags         auth
+// @Param        provider  path  string  true  "Provider name"
+// @Success      302
+// @Failure      404  {object}  map[string]string  "error"
+// @Failure      502  {object}  map[string]string  "error"
+// @Router       /auth/oidc/{provider}/login [get]
+func (h *OIDCHandler) Login(c *fiber.Ctx) error {
+	authURL, state, err := h.oidcService.AuthorizationURL(c.Context(), c.Params("provider"), 0, 0)
+	if err != nil {
+		return h.authorizationError(c, err)
+	}
+
+	h.setStateCookie(c, state)
+	return c.Redirect(authURL, fiber.StatusFound)
+}
+
+// Link godoc
+// @Summary      Link an OpenID Connect provider
+// @Description  Start linking an external provider to the authenticated user. The client should open the returned URL in the same browser that made this request (the state is bound with an HttpOnly cookie); the callback links the provider account instead of creating a new user, as long as the session that started linking is still active.
+// @Tags         auth
+// @Produce      json
+// @Security     BearerAuth
+// @Param        provider  path      string  true  "Provider name"
+// @Success      200       {object}  dto.OIDCAuthorizationResponse
+// @Failure      404       {object}  map[string]string  "error"
+// @Failure      502       {object}  map[string]string  "error"
+// @Router       /auth/oidc/{provider}/link [post]
+func (h *OIDCHandler) Link(c *fiber.Ctx) error {
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userID := uint(claims["user_id"].(float64))
+	var sessionID uint
+	if sid, ok := claims["sid"].(float64); ok {
+		sessionID = uint(sid)
+	}
+
+	authURL, state, err := h.oidcService.AuthorizationURL(c.Context(), c.Params("provider"), userID, sessionID)
+	if err != nil {
+		return h.authorizationError(c, err)
+	}
+
+	h.setStateCookie(c, state)
+	return c.JSON(dto.OIDCAuthorizationResponse{AuthorizationURL: authURL})
+}
+
+// Callback godoc
+// @Summary      OpenID Connect callback
+// @Description  Exchange the authorization code returned by the provider and sign in. Returns the same response as /auth/login.
+// @Tags         auth
+// @Produce      json
+// @Param        provider  path      string  true  "Provider name"
+// @Param        code      query     string  true  "Authorization code"
+// @Param        state     query     string  true  "State"
+// @Success      200       {object}  dto.LoginResponse
+// @Failure      400       {object}  map[string]string  "error"
+// @Failure      401       {object}  map[string]string  "error"
+// @Router       /auth/oidc/{provider}/callback [get]
+func (h *OIDCHandler) Callback(c *fiber.Ctx) error {
+	if providerError := c.Query("error"); providerError != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Sağlayıcı girişi reddetti: " + providerError,
+		})
+	}
+
+	code, state := c.Query("code"), c.Query("state")
+	if code == "" || state == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Geçersiz istek verisi",
+		})
+	}
+
+	browserState := c.Cookies(oidcStateCookie)
+	h.setStateCookie(c, "")
+
+	response, err := h.oidcService.Callback(c.Context(), c.Params("provider"), state, browserState, code, c.Get(fiber.HeaderUserAgent), c.IP())
+	if err != nil {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(response)
+}
+
+// GetIdentities godoc
+// @Summary      List linked providers
+// @Description  List the external provider accounts linked to the authenticated user
+// @Tags         auth
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {array}   dto.IdentityResponse
+// @Failure      500  {object}  map[string]string  "error"
+// @Router       /auth/identities [get]
+func (h *OIDCHandler) GetIdentities(c *fiber.Ctx) error {
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userID := uint(claims["user_id"].(float64))
+
+	identities, err := h.oidcService.GetIdentities(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Bağlı hesaplar getirilirken bir hata oluştu",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"identities": identities,
+	})
+}
+
+// Unlink godoc
+// @Summary      Unlink a provider
+// @Description  Remove a linked provider account. The last linked provider of a user without a password cannot be removed.
+// @Tags         auth
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Identity ID"
+// @Success      204  {object}  nil
+// @Failure      400  {object}  map[string]string  "error"
+// @Failure      404  {object}  map[string]string  "error"
+// @Router       /auth/identities/{id} [delete]
+func (h *OIDCHandler) Unlink(c *fiber.Ctx) error {
+	identityID, err := utils.ParamAsUint(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Geçersiz hesap ID",
+		})
+	}
+
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userID := uint(claims["user_id"].(float64))
+
+	if err := h.oidcService.Unlink(userID, identityID); err != nil {
+		if err.Error() == "bağlı hesap bulunamadı" {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Bağlı hesap bulunamadı",
+			})
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// setStateCookie, state'i akışı başlatan tarayıcıya bağlar; boş state cookie'yi siler.
+// Sağlayıcıdan dönüş üst düzey bir GET yönlendirmesi olduğundan SameSite=Lax cookie
+// callback'e gönderilir.
+func (h *OIDCHandler) setStateCookie(c *fiber.Ctx, state string) {
+	expires := time.Now().Add(service.OIDCStateTTL)
+	if state == "" {
+		expires = time.Unix(0, 0)
+	}
+	c.Cookie(&fiber.Cookie{
+		Name:     oidcStateCookie,
+		Value:    state,
+		Path:     "/api/auth/oidc",
+		Expires:  expires,
+		Secure:   c.Secure(),
+		HTTPOnly: true,
+		SameSite: fiber.CookieSameSiteLaxMode,
+	})
+}
+
+func (h *OIDCHandler) authorizationError(c *fiber.Ctx, err error) error {
+	if err.Error() == "sağlayıcı bulunamadı" {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Sağlayıcı bulunamadı",
+		})
+	}
+	return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{
+		"error": "Sağlayıcıya bağlanılamadı",
+	})
+}
@@ -1,16 +1,24 @@
 package handler
 
 import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
 	"net/url"
 	"shortcast/internal/dto"
+	"shortcast/internal/model"
 	"shortcast/internal/service"
 	"shortcast/internal/utils"
+	"strconv"
 
 	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v5"
-	"github.com/tcolgate/mp3"
 )
 
 type PodcastHandler struct {
@@ -23,15 +31,17 @@ func NewPodcastHandler(podcastService *service.PodcastService) *PodcastHandler {
 
 // UploadPodcast godoc
 // @Summary      Upload a podcast
-// @Description  Upload a podcast with audio file and metadata
+// @Description  Upload a podcast with audio file and metadata. The audio format is detected from the file contents; MP3, M4A (AAC), Ogg Opus and WAV files up to 60 seconds are accepted. MP3 files can be trimmed to a window of at most 60 seconds with start_ms/end_ms; longer MP3 files without a window are trimmed to their first 60 seconds. Files are processed in the background; the podcast is returned with status "processing" and becomes visible once it is "ready".
 // @Tags         podcast
 // @Accept       multipart/form-data
 // @Produce      json
 // @Param        title    formData  string  true  "Podcast title"
 // @Param        category formData  string  true  "Podcast category"
 // @Param        audio    formData  file    true  "Audio file"
-// @Param        cover    formData  file    true  "Cover image"
-// @Success      201  {object}  dto.PodcastResponse
+// @Param        cover    formData  file    true  "Cover image (JPEG, PNG or WebP, 300-4096 px, up to 10 MB)"
+// @Param        start_ms formData  int     false "Trim start in milliseconds (MP3 only)"
+// @Param        end_ms   formData  int     false "Trim end in milliseconds (MP3 only)"
+// @Success      202  {object}  dto.PodcastResponse
 // @Failure      400  {object}  map[string]string  "Hatalı istek"
 // @Failure      500  {object}  map[string]string  "Sunucu hatası"
 // @Router       /podcasts [post]
@@ -52,6 +62,16 @@ func (h *PodcastHandler) UploadPodcast(c *fiber.Ctx) error {
 		})
 	}
 
+	// Kırpma aralığı isteğe bağlıdır
+	var startErr, endErr error
+	podcastDTO.StartMs, startErr = formMilliseconds(c, "start_ms")
+	podcastDTO.EndMs, endErr = formMilliseconds(c, "end_ms")
+	if startErr != nil || endErr != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "start_ms ve end_ms sıfır veya pozitif tam sayı olmalıdır",
+		})
+	}
+
 	// Ses dosyasını kontrol et
 	audioFile, err := c.FormFile("audio")
 	if err != nil {
@@ -60,63 +80,163 @@ func (h *PodcastHandler) UploadPodcast(c *fiber.Ctx) error {
 		})
 	}
 
-	// Dosya uzantısını kontrol et
-	if !strings.HasSuffix(strings.ToLower(audioFile.Filename), ".mp3") {
+	// Kapak fotoğrafını kontrol et
+	coverFile, err := c.FormFile("cover")
+	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Sadece MP3 formatı kabul edilmektedir",
+			"error": "Kapak fotoğrafı gerekli",
 		})
 	}
 
-	// Dosyayı aç
-	file, err := audioFile.Open()
+	podcastDTO.UserID = userID
+
+	// Servis katmanına yönlendir
+	podcastResponse, err := h.podcastService.UploadPodcast(&podcastDTO, audioFile, coverFile)
 	if err != nil {
+		if isUploadValidationError(err) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Dosya açılamadı",
+			"error": err.Error(),
 		})
 	}
-	defer file.Close()
 
-	var duration float64
-	decoder := mp3.NewDecoder(file)
-	var frame mp3.Frame
-	skipped := 0
-	for {
-		if err := decoder.Decode(&frame, &skipped); err != nil {
-			break
-		}
-		duration += frame.Duration().Seconds()
-	}
+	return c.Status(fiber.StatusAccepted).JSON(podcastResponse)
+}
 
-	if duration > 60 {
+// CreateUpload godoc
+// @Summary      Create a direct upload
+// @Description  Returns presigned PUT URLs so the audio and cover files can be uploaded straight to storage instead of through the API. Each file must be uploaded with exactly the declared size and the returned headers (including Content-Type). The URLs and the pending upload expire after one hour; call the complete endpoint once both files are uploaded.
+// @Tags         podcast
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.CreateUploadRequest  true  "Upload metadata"
+// @Success      201  {object}  dto.UploadSessionResponse
+// @Failure      400  {object}  map[string]string  "Hatalı istek"
+// @Failure      500  {object}  map[string]string  "Sunucu hatası"
+// @Router       /podcasts/uploads [post]
+func (h *PodcastHandler) CreateUpload(c *fiber.Ctx) error {
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userID := uint(claims["user_id"].(float64))
+
+	var req dto.CreateUploadRequest
+	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Ses dosyası 60 saniyeden uzun olamaz",
+			"error": "Geçersiz istek formatı",
 		})
 	}
 
-	// Kapak fotoğrafını kontrol et
-	coverFile, err := c.FormFile("cover")
-	if err != nil {
+	if req.Title == "" || req.Category == "" {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Kapak fotoğrafı gerekli",
+			"error": "Başlık ve kategori alanları zorunludur",
+		})
+	}
+	if req.AudioFilename == "" || req.CoverFilename == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Ses dosyası ve kapak fotoğrafı adları zorunludur",
 		})
 	}
 
-	podcastDTO.UserID = userID
-
-	// Servis katmanına yönlendir
-	podcastResponse, err := h.podcastService.UploadPodcast(&podcastDTO, audioFile, coverFile)
+	session, err := h.podcastService.CreateUpload(userID, &req)
 	if err != nil {
+		if isUploadValidationError(err) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(podcastResponse)
+	return c.Status(fiber.StatusCreated).JSON(session)
+}
+
+// CompleteUpload godoc
+// @Summary      Complete a direct upload
+// @Description  Verifies that both files were uploaded with the declared size and content type, validates them like a regular upload and queues the podcast for processing. start_ms/end_ms optionally trim MP3 files. If validation fails the upload can be retried until it expires.
+// @Tags         podcast
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int                        true   "Upload ID"
+// @Param        request  body      dto.CompleteUploadRequest  false  "Trim window"
+// @Success      202  {object}  dto.PodcastResponse
+// @Failure      400  {object}  map[string]string  "Hatalı istek"
+// @Failure      404  {object}  map[string]string  "Yükleme bulunamadı"
+// @Failure      409  {object}  map[string]string  "Yükleme zaten tamamlanıyor"
+// @Failure      410  {object}  map[string]string  "Yüklemenin süresi dolmuş"
+// @Failure      500  {object}  map[string]string  "Sunucu hatası"
+// @Router       /podcasts/uploads/{id}/complete [post]
+func (h *PodcastHandler) CompleteUpload(c *fiber.Ctx) error {
+	id, err := utils.ParamAsUint(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz ID formatı"})
+	}
+
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userID := uint(claims["user_id"].(float64))
+
+	var req dto.CompleteUploadRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Geçersiz istek formatı",
+			})
+		}
+	}
+	if (req.StartMs != nil && *req.StartMs < 0) || (req.EndMs != nil && *req.EndMs < 0) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "start_ms ve end_ms sıfır veya pozitif tam sayı olmalıdır",
+		})
+	}
+
+	podcastResponse, err := h.podcastService.CompleteUpload(id, userID, &req)
+	if err != nil {
+		switch {
+		case err.Error() == "yükleme bulunamadı":
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Yükleme bulunamadı"})
+		case err.Error() == "yükleme zaten tamamlanıyor":
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+		case err.Error() == "yüklemenin süresi dolmuş":
+			return c.Status(fiber.StatusGone).JSON(fiber.Map{"error": err.Error()})
+		case isUploadValidationError(err):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		}
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(podcastResponse)
+}
+
+// formMilliseconds, isteğe bağlı milisaniye alanını okur; alan boşsa nil döner
+func formMilliseconds(c *fiber.Ctx, field string) (*int64, error) {
+	value := c.FormValue(field)
+	if value == "" {
+		return nil, nil
+	}
+	ms, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || ms < 0 {
+		return nil, fmt.Errorf("geçersiz %s", field)
+	}
+	return &ms, nil
+}
+
+// isUploadValidationError, yüklenen ses veya kapak dosyasının reddedildiğini belirten ve
+// istemciye olduğu gibi gösterilebilen servis hatalarını tanır
+func isUploadValidationError(err error) bool {
+	msg := err.Error()
+	return strings.HasPrefix(msg, "ses dosyası") || strings.HasPrefix(msg, "Ses dosyası") ||
+		strings.HasPrefix(msg, "kapak fotoğrafı")
 }
 
 // GetPodcastByID godoc
 // @Summary      Get podcast by ID
-// @Description  Retrieve podcast details by ID
+// @Description  Retrieve podcast details by ID. Podcasts that are still processing are only visible to users who can edit them; podcasts of users who blocked or were blocked by the viewer are not found.
 // @Tags         podcast
 // @Accept       json
 // @Produce      json
@@ -131,7 +251,11 @@ func (h *PodcastHandler) GetPodcastByID(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz ID formatı"})
 	}
 
-	podcastResponse, err := h.podcastService.GetPodcastByID(id)
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userID := uint(claims["user_id"].(float64))
+
+	podcastResponse, err := h.podcastService.GetPodcastByID(id, userID)
 	if err != nil {
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Podcast bulunamadı"})
 	}
@@ -139,6 +263,308 @@ func (h *PodcastHandler) GetPodcastByID(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusOK).JSON(podcastResponse)
 }
 
+// GetWaveform godoc
+// @Summary      Get podcast waveform
+// @Description  Retrieve the precomputed waveform peaks of a podcast in audiowaveform JSON format (version 2, 8-bit, mono, min/max pairs). Not available for M4A and Opus uploads.
+// @Tags         podcast
+// @Produce      json
+// @Param        id   path      int  true  "Podcast ID"
+// @Success      200  {object}  audio.Waveform
+// @Failure      400  {object}  map[string]string  "Geçersiz ID formatı"
+// @Failure      404  {object}  map[string]string  "Dalga formu bulunamadı"
+// @Router       /podcasts/{id}/waveform [get]
+func (h *PodcastHandler) GetWaveform(c *fiber.Ctx) error {
+	id, err := utils.ParamAsUint(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz ID formatı"})
+	}
+
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userID := uint(claims["user_id"].(float64))
+
+	waveform, err := h.podcastService.GetWaveform(id, userID)
+	if err != nil {
+		if err.Error() == "podcast bulunamadı" || err.Error() == "dalga formu bulunamadı" {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Dalga formu bulunamadı"})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Dalga formu getirilirken bir hata oluştu"})
+	}
+
+	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	c.Set(fiber.HeaderCacheControl, "private, max-age=86400")
+	return c.Send(waveform)
+}
+
+// GetAudio godoc
+// @Summary      Stream podcast audio
+// @Description  Streams the audio file through the API so access rules are checked on every play. audio_url and normalized_audio_url of podcast responses point here. Supports Range requests (206 Partial Content) for seeking and ETag/If-None-Match (304 Not Modified).
+// @Tags         podcast
+// @Produce      audio/mpeg
+// @Param        id             path    int     true   "Podcast ID"
+// @Param        variant        query   string  false  "Empty for the original file, normalized for the loudness normalized version"
+// @Param        Range          header  string  false  "Byte range, e.g. bytes=0-1023"
+// @Param        If-None-Match  header  string  false  "ETag of a cached copy"
+// @Success      200  {file}    file
+// @Success      206  {file}    file
+// @Success      304
+// @Failure      400  {object}  map[string]string  "Geçersiz ID formatı veya ses sürümü"
+// @Failure      404  {object}  map[string]string  "Podcast bulunamadı veya kullanıcı engelli"
+// @Failure      416  {object}  map[string]string  "Geçersiz aralık"
+// @Router       /podcasts/{id}/audio [get]
+func (h *PodcastHandler) GetAudio(c *fiber.Ctx) error {
+	id, err := utils.ParamAsUint(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz ID formatı"})
+	}
+
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userID := uint(claims["user_id"].(float64))
+
+	stream, err := h.podcastService.OpenAudio(id, userID, c.Query("variant"), c.Get(fiber.HeaderRange), c.Get(fiber.HeaderIfNoneMatch))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrNotModified):
+			c.Set(fiber.HeaderETag, c.Get(fiber.HeaderIfNoneMatch))
+			return c.SendStatus(fiber.StatusNotModified)
+		case errors.Is(err, service.ErrInvalidRange):
+			return c.Status(fiber.StatusRequestedRangeNotSatisfiable).JSON(fiber.Map{"error": "Geçersiz aralık"})
+		case err.Error() == "podcast bulunamadı":
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Podcast bulunamadı"})
+		case err.Error() == "geçersiz ses sürümü":
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz ses sürümü"})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Ses dosyası getirilirken bir hata oluştu"})
+		}
+	}
+
+	c.Set(fiber.HeaderAcceptRanges, "bytes")
+	c.Set(fiber.HeaderContentType, stream.ContentType)
+	c.Set(fiber.HeaderETag, stream.ETag)
+	if !stream.LastModified.IsZero() {
+		c.Set(fiber.HeaderLastModified, stream.LastModified.UTC().Format(http.TimeFormat))
+	}
+	// Erişim kuralları her istekte kontrol edildiğinden yanıt paylaşılan önbelleklerde tutulmaz
+	c.Set(fiber.HeaderCacheControl, "private, no-cache")
+	if stream.ContentRange != "" {
+		c.Set(fiber.HeaderContentRange, stream.ContentRange)
+		c.Status(fiber.StatusPartialContent)
+	}
+	return c.SendStream(stream.Body, int(stream.ContentLength))
+}
+
+// GetHLS godoc
+// @Summary      Get an HLS playlist or segment
+// @Description  Serves the HLS master playlist (master.m3u8), a rendition playlist (<rendition>/index.m3u8) or a segment (<rendition>/<segment>.ts) of a podcast. Requests are authorized by the viewer, expires and signature query parameters of stream_url instead of a token, because players request nested playlists and segments without headers. The signature is bound to the user the stream_url was issued to and access rules are checked for that user on every request. Every path in a playlist carries the same signature. Segments support single byte ranges and If-None-Match.
+// @Tags         podcast
+// @Produce      application/vnd.apple.mpegurl
+// @Produce      video/mp2t
+// @Param        id         path   int     true  "Podcast ID"
+// @Param        path       path   string  true  "master.m3u8, <rendition>/index.m3u8 or <rendition>/<segment>.ts"
+// @Param        viewer     query  int     true  "ID of the user the link was issued to"
+// @Param        expires    query  int     true  "Expiry (unix seconds)"
+// @Param        signature  query  string  true  "Signature"
+// @Success      200  {string}  string
+// @Success      206  {file}    file
+// @Success      304
+// @Failure      403  {object}  map[string]string  "Akış bağlantısı geçersiz veya süresi dolmuş"
+// @Failure      404  {object}  map[string]string  "Playlist bulunamadı"
+// @Failure      416  {object}  map[string]string  "Geçersiz aralık"
+// @Router       /podcasts/{id}/hls/{path} [get]
+func (h *PodcastHandler) GetHLS(c *fiber.Ctx) error {
+	id, err := utils.ParamAsUint(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz ID formatı"})
+	}
+
+	name := c.Params("*")
+	if !strings.HasSuffix(name, ".m3u8") {
+		return h.getHLSSegment(c, id, name)
+	}
+
+	playlist, err := h.podcastService.GetHLSPlaylist(id, name, c.Query("viewer"), c.Query("expires"), c.Query("signature"))
+	if err != nil {
+		switch err.Error() {
+		case "akış bağlantısı geçersiz veya süresi dolmuş":
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Akış bağlantısı geçersiz veya süresi dolmuş"})
+		case "podcast bulunamadı", "playlist bulunamadı":
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Playlist bulunamadı"})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Playlist getirilirken bir hata oluştu"})
+		}
+	}
+
+	// Segment istekleri erişim kurallarını yeniden kontrol ettiğinden playlist kısa süre önbelleğe alınabilir
+	c.Set(fiber.HeaderContentType, "application/vnd.apple.mpegurl")
+	c.Set(fiber.HeaderCacheControl, "private, max-age=300")
+	return c.Send(playlist)
+}
+
+func (h *PodcastHandler) getHLSSegment(c *fiber.Ctx, id uint, name string) error {
+	stream, err := h.podcastService.OpenHLSSegment(id, name, c.Query("viewer"), c.Query("expires"), c.Query("signature"),
+		c.Get(fiber.HeaderRange), c.Get(fiber.HeaderIfNoneMatch))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrNotModified):
+			c.Set(fiber.HeaderETag, c.Get(fiber.HeaderIfNoneMatch))
+			return c.SendStatus(fiber.StatusNotModified)
+		case errors.Is(err, service.ErrInvalidRange):
+			return c.Status(fiber.StatusRequestedRangeNotSatisfiable).JSON(fiber.Map{"error": "Geçersiz aralık"})
+		case err.Error() == "akış bağlantısı geçersiz veya süresi dolmuş":
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Akış bağlantısı geçersiz veya süresi dolmuş"})
+		case err.Error() == "podcast bulunamadı" || err.Error() == "playlist bulunamadı" || err.Error() == "segment bulunamadı":
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Segment bulunamadı"})
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Segment getirilirken bir hata oluştu"})
+		}
+	}
+
+	c.Set(fiber.HeaderAcceptRanges, "bytes")
+	c.Set(fiber.HeaderContentType, "video/mp2t")
+	c.Set(fiber.HeaderETag, stream.ETag)
+	// Erişim kuralları her istekte kontrol edildiğinden yanıt paylaşılan önbelleklerde tutulmaz
+	c.Set(fiber.HeaderCacheControl, "private, no-cache")
+	if stream.ContentRange != "" {
+		c.Set(fiber.HeaderContentRange, stream.ContentRange)
+		c.Status(fiber.StatusPartialContent)
+	}
+	return c.SendStream(stream.Body, int(stream.ContentLength))
+}
+
+// GetStatus godoc
+// @Summary      Get podcast processing status
+// @Description  Retrieve the background processing status of an uploaded podcast. Only the owner can see it.
+// @Tags         podcast
+// @Produce      json
+// @Param        id   path      int  true  "Podcast ID"
+// @Success      200  {object}  dto.PodcastStatusResponse
+// @Failure      400  {object}  map[string]string  "Geçersiz ID formatı"
+// @Failure      403  {object}  map[string]string  "Yetkisiz erişim"
+// @Failure      404  {object}  map[string]string  "Podcast bulunamadı"
+// @Router       /podcasts/{id}/status [get]
+func (h *PodcastHandler) GetStatus(c *fiber.Ctx) error {
+	id, err := utils.ParamAsUint(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz ID formatı"})
+	}
+
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userID := uint(claims["user_id"].(float64))
+
+	status, err := h.podcastService.GetStatus(id, userID)
+	if err != nil {
+		return statusError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(status)
+}
+
+// StreamStatus godoc
+// @Summary      Stream podcast processing status
+// @Description  Server-Sent Events stream of the processing status. Each update is sent as a "status" event with a PodcastStatusResponse payload; the stream ends once the podcast is "ready" or "failed".
+// @Tags         podcast
+// @Produce      text/event-stream
+// @Param        id   path      int  true  "Podcast ID"
+// @Success      200  {object}  dto.PodcastStatusResponse
+// @Failure      400  {object}  map[string]string  "Geçersiz ID formatı"
+// @Failure      403  {object}  map[string]string  "Yetkisiz erişim"
+// @Failure      404  {object}  map[string]string  "Podcast bulunamadı"
+// @Router       /podcasts/{id}/status/stream [get]
+func (h *PodcastHandler) StreamStatus(c *fiber.Ctx) error {
+	id, err := utils.ParamAsUint(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Geçersiz ID formatı"})
+	}
+
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userID := uint(claims["user_id"].(float64))
+
+	ctx, cancel := context.WithTimeout(context.Background(), statusStreamTimeout)
+	initial, updates, stop, err := h.podcastService.WatchStatus(ctx, id, userID)
+	if err != nil {
+		cancel()
+		return statusError(c, err)
+	}
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer cancel()
+		defer stop()
+
+		heartbeat := time.NewTicker(statusHeartbeatInterval)
+		defer heartbeat.Stop()
+
+		status := *initial
+		for {
+			if err := writeStatusEvent(w, &status); err != nil {
+				return
+			}
+			if status.Status != model.PodcastStatusProcessing {
+				return
+			}
+
+		wait:
+			for {
+				select {
+				case update, ok := <-updates:
+					if !ok {
+						return
+					}
+					status = update
+					break wait
+				case <-heartbeat.C:
+					// Bağlantı koptuysa yazma hatası alınır ve akış sonlanır
+					if _, err := w.WriteString(": keep-alive\n\n"); err != nil {
+						return
+					}
+					if err := w.Flush(); err != nil {
+						return
+					}
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	})
+
+	return nil
+}
+
+const (
+	// statusStreamTimeout, durum akışının en fazla açık kalacağı süredir
+	statusStreamTimeout = 10 * time.Minute
+	// statusHeartbeatInterval, proxy'lerin bağlantıyı kapatmaması için gönderilen yorum satırlarının aralığıdır
+	statusHeartbeatInterval = 15 * time.Second
+)
+
+func writeStatusEvent(w *bufio.Writer, status *dto.PodcastStatusResponse) error {
+	data, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "event: status\ndata: %s\n\n", data); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+func statusError(c *fiber.Ctx, err error) error {
+	switch err.Error() {
+	case "podcast bulunamadı":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Podcast bulunamadı"})
+	case "bu podcast'in durumunu görme yetkiniz yok":
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": err.Error()})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Podcast durumu getirilirken bir hata oluştu"})
+	}
+}
+
 // GetUserPodcasts godoc
 // @Summary      Get user's podcasts
 // @Description  Retrieve all podcasts of a specific user
@@ -158,7 +584,11 @@ func (h *PodcastHandler) GetUserPodcasts(c *fiber.Ctx) error {
 		})
 	}
 
-	podcasts, err := h.podcastService.GetUserPodcasts(userID)
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	viewerID := uint(claims["user_id"].(float64))
+
+	podcasts, err := h.podcastService.GetUserPodcasts(viewerID, userID)
 	if err != nil {
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
 			"error": "Podcastler getirilirken bir hata oluştu",
@@ -196,7 +626,11 @@ func (h *PodcastHandler) DiscoverPodcasts(c *fiber.Ctx) error {
 		})
 	}
 
-	result, err := h.podcastService.DiscoverPodcasts(&req)
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userID := uint(claims["user_id"].(float64))
+
+	result, err := h.podcastService.DiscoverPodcasts(userID, &req)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Podcastler getirilirken bir hata oluştu",
@@ -210,6 +644,40 @@ func (h *PodcastHandler) DiscoverPodcasts(c *fiber.Ctx) error {
 	return c.JSON(result)
 }
 
+// GetFeed godoc
+// @Summary      Following feed
+// @Description  Get podcasts from followed creators, newest first. Pass next_cursor from the previous page as cursor.
+// @Tags         podcast
+// @Produce      json
+// @Security     BearerAuth
+// @Param        cursor  query    integer  false  "Cursor for pagination"
+// @Param        limit   query    integer  false  "Number of podcasts per page"
+// @Success      200  {object}  dto.PodcastCursor
+// @Failure      400  {object}  map[string]string  "Geçersiz istek"
+// @Failure      500  {object}  map[string]string  "Sunucu hatası"
+// @Router       /podcasts/feed [get]
+func (h *PodcastHandler) GetFeed(c *fiber.Ctx) error {
+	var req dto.PodcastFeedRequest
+	if err := c.QueryParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Geçersiz sorgu parametreleri",
+		})
+	}
+
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userID := uint(claims["user_id"].(float64))
+
+	result, err := h.podcastService.GetFeed(userID, &req)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Podcastler getirilirken bir hata oluştu",
+		})
+	}
+
+	return c.JSON(result)
+}
+
 // UpdatePodcast godoc
 // @Summary      Update a podcast
 // @Description  Update podcast title and category
@@ -274,7 +742,7 @@ func (h *PodcastHandler) UpdatePodcast(c *fiber.Ctx) error {
 
 // DeletePodcast godoc
 // @Summary      Delete a podcast
-// @Description  Delete a podcast by ID (only owner can delete)
+// @Description  Delete a podcast by ID (owner, moderators and admins)
 // @Tags         podcast
 // @Accept       json
 // @Produce      json
@@ -341,6 +809,11 @@ func (h *PodcastHandler) LikePodcast(c *fiber.Ctx) error {
 
 	response, err := h.podcastService.LikePodcast(podcastID, userID)
 	if err != nil {
+		if err.Error() == "bu kullanıcı sizi engellediği için bu işlemi yapamazsınız" {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "İşlem başarısız oldu",
 		})
@@ -407,7 +880,11 @@ func (h *PodcastHandler) GetPodcastsByCategory(c *fiber.Ctx) error {
 		})
 	}
 
-	podcasts, err := h.podcastService.GetPodcastsByCategory(decodedCategory)
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userID := uint(claims["user_id"].(float64))
+
+	podcasts, err := h.podcastService.GetPodcastsByCategory(userID, decodedCategory)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Podcastler getirilirken bir hata oluştu",
@@ -457,6 +934,11 @@ func (h *PodcastHandler) AddComment(c *fiber.Ctx) error {
 
 	comment, err := h.podcastService.AddComment(podcastID, userID, req.Content)
 	if err != nil {
+		if err.Error() == "bu kullanıcı sizi engellediği için bu işlemi yapamazsınız" {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Yorum eklenirken bir hata oluştu",
 		})
@@ -467,13 +949,14 @@ func (h *PodcastHandler) AddComment(c *fiber.Ctx) error {
 
 // GetComments godoc
 // @Summary      Get podcast comments
-// @Description  Get all comments for a specific podcast
+// @Description  Get all comments for a specific podcast. Podcasts the user cannot see (blocked users, podcasts that are not ready yet) respond with 404.
 // @Tags         podcast
 // @Accept       json
 // @Produce      json
 // @Param        id   path      int  true  "Podcast ID"
 // @Success      200  {array}   dto.CommentResponse
 // @Failure      400  {object}  map[string]string  "Geçersiz podcast ID"
+// @Failure      404  {object}  map[string]string  "Podcast bulunamadı"
 // @Failure      500  {object}  map[string]string  "Sunucu hatası"
 // @Router       /podcasts/{id}/comments [get]
 func (h *PodcastHandler) GetComments(c *fiber.Ctx) error {
@@ -484,8 +967,17 @@ func (h *PodcastHandler) GetComments(c *fiber.Ctx) error {
 		})
 	}
 
-	comments, err := h.podcastService.GetComments(podcastID)
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userID := uint(claims["user_id"].(float64))
+
+	comments, err := h.podcastService.GetComments(podcastID, userID)
 	if err != nil {
+		if err.Error() == "podcast bulunamadı" {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Podcast bulunamadı",
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Yorumlar getirilirken bir hata oluştu",
 		})
@@ -501,9 +993,61 @@ func (h *PodcastHandler) GetComments(c *fiber.Ctx) error {
 	})
 }
 
+// DeleteComment godoc
+// @Summary      Delete a comment
+// @Description  Delete a comment (comment owner, moderators and admins)
+// @Tags         podcast
+// @Accept       json
+// @Produce      json
+// @Param        id          path      int  true  "Podcast ID"
+// @Param        comment_id  path      int  true  "Comment ID"
+// @Success      204  {object}  nil
+// @Failure      400  {object}  map[string]string  "Geçersiz ID"
+// @Failure      403  {object}  map[string]string  "Yetkisiz erişim"
+// @Failure      404  {object}  map[string]string  "Yorum bulunamadı"
+// @Router       /podcasts/{id}/comments/{comment_id} [delete]
+func (h *PodcastHandler) DeleteComment(c *fiber.Ctx) error {
+	podcastID, err := utils.ParamAsUint(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Geçersiz podcast ID",
+		})
+	}
+
+	commentID, err := utils.ParamAsUint(c, "comment_id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Geçersiz yorum ID",
+		})
+	}
+
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userID := uint(claims["user_id"].(float64))
+
+	err = h.podcastService.DeleteComment(podcastID, commentID, userID)
+	if err != nil {
+		if err.Error() == "yorum bulunamadı" {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Yorum bulunamadı",
+			})
+		}
+		if err.Error() == "bu yorumu silme yetkiniz yok" {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": "Bu yorumu silme yetkiniz yok",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Yorum silinirken bir hata oluştu",
+		})
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
 // UpdatePodcastCover godoc
 // @Summary      Update podcast cover
-// @Description  Update cover image of a podcast
+// @Description  Update cover image of a podcast. JPEG, PNG and WebP images between 300x300 and 4096x4096 pixels and up to 10 MB are accepted; metadata is stripped and square 150/300/600 px renditions are generated.
 // @Tags         podcast
 // @Accept       multipart/form-data
 // @Produce      json
@@ -535,6 +1079,11 @@ func (h *PodcastHandler) UpdatePodcastCover(c *fiber.Ctx) error {
 
 	podcastResponse, err := h.podcastService.UpdatePodcastCover(id, userID, coverFile)
 	if err != nil {
+		if strings.HasPrefix(err.Error(), "kapak fotoğrafı") {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
 		if err.Error() == "podcast bulunamadı" {
 			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
 				"error": "Podcast bulunamadı",
@@ -0,0 +1,303 @@
+package handler
+
+import (
+	"shortcast/internal/dto"
+	"shortcast/internal/service"
+	"shortcast/internal/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type SocialHandler struct {
+	socialService *service.SocialService
+}
+
+func NewSocialHandler(socialService *service.SocialService) *SocialHandler {
+	return &SocialHandler{socialService: socialService}
+}
+
+// Follow godoc
+// @Summary      Follow a user
+// @Description  Follow a creator so their podcasts appear in /podcasts/feed. Following an already followed user has no effect.
+// @Tags         social
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "User ID"
+// @Success      200  {object}  map[string]bool  "following"
+// @Failure      400  {object}  map[string]string  "error"
+// @Failure      404  {object}  map[string]string  "error"
+// @Router       /users/{id}/follow [post]
+func (h *SocialHandler) Follow(c *fiber.Ctx) error {
+	targetID, err := utils.ParamAsUint(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Geçersiz kullanıcı ID",
+		})
+	}
+
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userID := uint(claims["user_id"].(float64))
+
+	if err := h.socialService.Follow(userID, targetID); err != nil {
+		if err.Error() == "kullanıcı bulunamadı" {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Kullanıcı bulunamadı",
+			})
+		}
+		if err.Error() == "kendinizi takip edemezsiniz" || err.Error() == "engellenen bir kullanıcı takip edilemez" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Kullanıcı takip edilirken bir hata oluştu",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"following": true,
+	})
+}
+
+// Unfollow godoc
+// @Summary      Unfollow a user
+// @Description  Stop following a creator
+// @Tags         social
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "User ID"
+// @Success      200  {object}  map[string]bool  "following"
+// @Failure      400  {object}  map[string]string  "error"
+// @Router       /users/{id}/follow [delete]
+func (h *SocialHandler) Unfollow(c *fiber.Ctx) error {
+	targetID, err := utils.ParamAsUint(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Geçersiz kullanıcı ID",
+		})
+	}
+
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userID := uint(claims["user_id"].(float64))
+
+	if err := h.socialService.Unfollow(userID, targetID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Takip bırakılırken bir hata oluştu",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"following": false,
+	})
+}
+
+// GetFollowers godoc
+// @Summary      List followers
+// @Description  List the users following a creator, most recent first
+// @Tags         social
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id     path      int      true   "User ID"
+// @Param        page   query     integer  false  "Page number"
+// @Param        limit  query     integer  false  "Users per page"
+// @Success      200  {object}  dto.FollowListResponse
+// @Failure      400  {object}  map[string]string  "error"
+// @Failure      404  {object}  map[string]string  "error"
+// @Router       /users/{id}/followers [get]
+func (h *SocialHandler) GetFollowers(c *fiber.Ctx) error {
+	return h.listUsers(c, h.socialService.GetFollowers)
+}
+
+// GetFollowing godoc
+// @Summary      List followed users
+// @Description  List the creators a user follows, most recent first
+// @Tags         social
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id     path      int      true   "User ID"
+// @Param        page   query     integer  false  "Page number"
+// @Param        limit  query     integer  false  "Users per page"
+// @Success      200  {object}  dto.FollowListResponse
+// @Failure      400  {object}  map[string]string  "error"
+// @Failure      404  {object}  map[string]string  "error"
+// @Router       /users/{id}/following [get]
+func (h *SocialHandler) GetFollowing(c *fiber.Ctx) error {
+	return h.listUsers(c, h.socialService.GetFollowing)
+}
+
+// Block godoc
+// @Summary      Block a user
+// @Description  Block a user. Blocked users cannot see, like or comment on your podcasts, and follows between the two users are removed.
+// @Tags         social
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "User ID"
+// @Success      200  {object}  map[string]bool  "blocked"
+// @Failure      400  {object}  map[string]string  "error"
+// @Failure      404  {object}  map[string]string  "error"
+// @Router       /users/{id}/block [post]
+func (h *SocialHandler) Block(c *fiber.Ctx) error {
+	return h.setRelation(c, "blocked", true, h.socialService.Block)
+}
+
+// Unblock godoc
+// @Summary      Unblock a user
+// @Description  Remove a block
+// @Tags         social
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "User ID"
+// @Success      200  {object}  map[string]bool  "blocked"
+// @Failure      400  {object}  map[string]string  "error"
+// @Router       /users/{id}/block [delete]
+func (h *SocialHandler) Unblock(c *fiber.Ctx) error {
+	return h.setRelation(c, "blocked", false, h.socialService.Unblock)
+}
+
+// Mute godoc
+// @Summary      Mute a user
+// @Description  Hide a creator's podcasts from your discovery and feed results. The muted user is not notified or otherwise affected.
+// @Tags         social
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "User ID"
+// @Success      200  {object}  map[string]bool  "muted"
+// @Failure      400  {object}  map[string]string  "error"
+// @Failure      404  {object}  map[string]string  "error"
+// @Router       /users/{id}/mute [post]
+func (h *SocialHandler) Mute(c *fiber.Ctx) error {
+	return h.setRelation(c, "muted", true, h.socialService.Mute)
+}
+
+// Unmute godoc
+// @Summary      Unmute a user
+// @Description  Remove a mute
+// @Tags         social
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "User ID"
+// @Success      200  {object}  map[string]bool  "muted"
+// @Failure      400  {object}  map[string]string  "error"
+// @Router       /users/{id}/mute [delete]
+func (h *SocialHandler) Unmute(c *fiber.Ctx) error {
+	return h.setRelation(c, "muted", false, h.socialService.Unmute)
+}
+
+// GetBlockedUsers godoc
+// @Summary      List blocked users
+// @Description  List the users blocked by the authenticated user, most recent first
+// @Tags         social
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page   query     integer  false  "Page number"
+// @Param        limit  query     integer  false  "Users per page"
+// @Success      200  {object}  dto.FollowListResponse
+// @Failure      400  {object}  map[string]string  "error"
+// @Router       /users/me/blocks [get]
+func (h *SocialHandler) GetBlockedUsers(c *fiber.Ctx) error {
+	return h.listOwnUsers(c, h.socialService.GetBlockedUsers)
+}
+
+// GetMutedUsers godoc
+// @Summary      List muted users
+// @Description  List the users muted by the authenticated user, most recent first
+// @Tags         social
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page   query     integer  false  "Page number"
+// @Param        limit  query     integer  false  "Users per page"
+// @Success      200  {object}  dto.FollowListResponse
+// @Failure      400  {object}  map[string]string  "error"
+// @Router       /users/me/mutes [get]
+func (h *SocialHandler) GetMutedUsers(c *fiber.Ctx) error {
+	return h.listOwnUsers(c, h.socialService.GetMutedUsers)
+}
+
+// setRelation, kullanıcı ile :id arasındaki engelleme veya sessize alma ilişkisini değiştirir
+func (h *SocialHandler) setRelation(c *fiber.Ctx, field string, value bool, apply func(uint, uint) error) error {
+	targetID, err := utils.ParamAsUint(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Geçersiz kullanıcı ID",
+		})
+	}
+
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userID := uint(claims["user_id"].(float64))
+
+	if err := apply(userID, targetID); err != nil {
+		if err.Error() == "kullanıcı bulunamadı" {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Kullanıcı bulunamadı",
+			})
+		}
+		if err.Error() == "kendinizi engelleyemezsiniz" || err.Error() == "kendinizi sessize alamazsınız" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "İşlem başarısız oldu",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		field: value,
+	})
+}
+
+func (h *SocialHandler) listOwnUsers(c *fiber.Ctx, list func(uint, *dto.FollowListRequest) (*dto.FollowListResponse, error)) error {
+	var req dto.FollowListRequest
+	if err := c.QueryParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Geçersiz sorgu parametreleri",
+		})
+	}
+
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userID := uint(claims["user_id"].(float64))
+
+	users, err := list(userID, &req)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Kullanıcılar getirilirken bir hata oluştu",
+		})
+	}
+
+	return c.JSON(users)
+}
+
+func (h *SocialHandler) listUsers(c *fiber.Ctx, list func(uint, *dto.FollowListRequest) (*dto.FollowListResponse, error)) error {
+	userID, err := utils.ParamAsUint(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Geçersiz kullanıcı ID",
+		})
+	}
+
+	var req dto.FollowListRequest
+	if err := c.QueryParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Geçersiz sorgu parametreleri",
+		})
+	}
+
+	users, err := list(userID, &req)
+	if err != nil {
+		if err.Error() == "kullanıcı bulunamadı" {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Kullanıcı bulunamadı",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Kullanıcılar getirilirken bir hata oluştu",
+		})
+	}
+
+	return c.JSON(users)
+}
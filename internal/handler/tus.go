@@ -0,0 +1,235 @@
+package handler
+
+import (
+	"encoding/base64"
+	"net/http"
+	"shortcast/internal/model"
+	"shortcast/internal/service"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	tusVersion    = "1.0.0"
+	tusExtensions = "creation,expiration,checksum"
+	// statusChecksumMismatch, tus checksum eklentisinin parça özeti tutmadığında döndürdüğü durumdur
+	statusChecksumMismatch = 460
+)
+
+// TusHandler, tus 1.0 protokolünün sunucu tarafıdır: https://tus.io/protocols/resumable-upload
+type TusHandler struct {
+	tusService *service.TusService
+}
+
+func NewTusHandler(tusService *service.TusService) *TusHandler {
+	return &TusHandler{tusService: tusService}
+}
+
+// Resumable, tüm yanıtlara Tus-Resumable başlığını ekler ve OPTIONS dışındaki isteklerde
+// istemcinin protokol sürümünü kontrol eder
+func (h *TusHandler) Resumable(c *fiber.Ctx) error {
+	c.Set("Tus-Resumable", tusVersion)
+	if c.Method() != fiber.MethodOptions && c.Get("Tus-Resumable") != tusVersion {
+		c.Set("Tus-Version", tusVersion)
+		return c.Status(fiber.StatusPreconditionFailed).JSON(fiber.Map{
+			"error": "Desteklenmeyen tus sürümü",
+		})
+	}
+	return c.Next()
+}
+
+// Options godoc
+// @Summary      tus server capabilities
+// @Description  Returns the supported tus version, extensions, checksum algorithms and maximum upload size in the Tus-* headers.
+// @Tags         tus
+// @Success      204
+// @Router       /podcasts/tus [options]
+func (h *TusHandler) Options(c *fiber.Ctx) error {
+	c.Set("Tus-Version", tusVersion)
+	c.Set("Tus-Extension", tusExtensions)
+	c.Set("Tus-Max-Size", strconv.FormatInt(h.tusService.MaxSize(), 10))
+	c.Set("Tus-Checksum-Algorithm", strings.Join(service.TusChecksumAlgorithms, ","))
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// Create godoc
+// @Summary      Create a resumable upload
+// @Description  tus creation extension. Upload-Metadata must contain filename and kind (cover or audio). Upload the cover first; audio uploads also carry title, category, cover_upload_id and optionally start_ms/end_ms. When the last chunk of the audio upload is written the podcast is created like a regular upload and its ID is returned in the Podcast-Id header.
+// @Tags         tus
+// @Param        Tus-Resumable    header  string  true  "1.0.0"
+// @Param        Upload-Length    header  int     true  "File size in bytes"
+// @Param        Upload-Metadata  header  string  true  "Comma separated key and base64 value pairs"
+// @Success      201
+// @Failure      400  {object}  map[string]string  "Hatalı istek"
+// @Failure      413  {object}  map[string]string  "Dosya çok büyük"
+// @Failure      500  {object}  map[string]string  "Sunucu hatası"
+// @Router       /podcasts/tus [post]
+func (h *TusHandler) Create(c *fiber.Ctx) error {
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userID := uint(claims["user_id"].(float64))
+
+	if c.Get("Upload-Defer-Length") != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Upload-Defer-Length desteklenmiyor",
+		})
+	}
+	length, err := strconv.ParseInt(c.Get("Upload-Length"), 10, 64)
+	if err != nil || length <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Geçersiz Upload-Length başlığı",
+		})
+	}
+	if length > h.tusService.MaxSize() {
+		return c.Status(fiber.StatusRequestEntityTooLarge).JSON(fiber.Map{
+			"error": "Dosya boyutu sınırı aşıyor",
+		})
+	}
+
+	metadata, err := parseUploadMetadata(c.Get("Upload-Metadata"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Geçersiz Upload-Metadata başlığı",
+		})
+	}
+
+	upload, err := h.tusService.CreateUpload(userID, length, metadata)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "yükleme oluşturulamadı") {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	c.Location(strings.TrimSuffix(c.BaseURL()+c.Path(), "/") + "/" + upload.ID)
+	setUploadHeaders(c, upload)
+	return c.SendStatus(fiber.StatusCreated)
+}
+
+// Head godoc
+// @Summary      Get the offset of a resumable upload
+// @Description  Returns Upload-Offset and Upload-Length so an interrupted upload can be resumed from the last stored byte.
+// @Tags         tus
+// @Param        Tus-Resumable  header  string  true  "1.0.0"
+// @Param        id             path    string  true  "Upload ID"
+// @Success      200
+// @Failure      404  {object}  map[string]string  "Yükleme bulunamadı"
+// @Failure      410  {object}  map[string]string  "Yüklemenin süresi dolmuş"
+// @Router       /podcasts/tus/{id} [head]
+func (h *TusHandler) Head(c *fiber.Ctx) error {
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userID := uint(claims["user_id"].(float64))
+
+	upload, err := h.tusService.GetUpload(c.Params("id"), userID)
+	if err != nil {
+		return tusError(c, err)
+	}
+
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	c.Set("Upload-Length", strconv.FormatInt(upload.Length, 10))
+	setUploadHeaders(c, upload)
+	return c.SendStatus(fiber.StatusOK)
+}
+
+// Patch godoc
+// @Summary      Upload a chunk
+// @Description  Appends the request body at Upload-Offset. With Upload-Checksum ("sha1 <base64>") the chunk is verified before it is stored and rejected with 460 on mismatch. If creating the podcast fails after the last chunk, an empty PATCH at the final offset retries it.
+// @Tags         tus
+// @Accept       application/offset+octet-stream
+// @Param        Tus-Resumable    header  string  true   "1.0.0"
+// @Param        Upload-Offset    header  int     true   "Current offset"
+// @Param        Upload-Checksum  header  string  false  "Checksum algorithm and base64 digest of the chunk"
+// @Param        id               path    string  true   "Upload ID"
+// @Success      204
+// @Failure      400  {object}  map[string]string  "Hatalı istek"
+// @Failure      404  {object}  map[string]string  "Yükleme bulunamadı"
+// @Failure      409  {object}  map[string]string  "Yükleme konumu eşleşmiyor"
+// @Failure      410  {object}  map[string]string  "Yüklemenin süresi dolmuş"
+// @Failure      415  {object}  map[string]string  "Geçersiz içerik türü"
+// @Failure      423  {object}  map[string]string  "Yükleme başka bir istek tarafından yazılıyor"
+// @Failure      460  {object}  map[string]string  "Checksum eşleşmiyor"
+// @Router       /podcasts/tus/{id} [patch]
+func (h *TusHandler) Patch(c *fiber.Ctx) error {
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userID := uint(claims["user_id"].(float64))
+
+	if c.Get(fiber.HeaderContentType) != "application/offset+octet-stream" {
+		return c.Status(fiber.StatusUnsupportedMediaType).JSON(fiber.Map{
+			"error": "Content-Type application/offset+octet-stream olmalı",
+		})
+	}
+	offset, err := strconv.ParseInt(c.Get("Upload-Offset"), 10, 64)
+	if err != nil || offset < 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Geçersiz Upload-Offset başlığı",
+		})
+	}
+
+	upload, err := h.tusService.WriteChunk(c.Params("id"), userID, offset, c.Body(), c.Get("Upload-Checksum"))
+	if err != nil {
+		return tusError(c, err)
+	}
+
+	setUploadHeaders(c, upload)
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// setUploadHeaders, yüklemenin konumunu, bitiş zamanını ve varsa oluşturulan podcast'i yazar
+func setUploadHeaders(c *fiber.Ctx, upload *model.TusUpload) {
+	c.Set("Upload-Offset", strconv.FormatInt(upload.Offset, 10))
+	c.Set("Upload-Expires", upload.ExpiresAt.UTC().Format(http.TimeFormat))
+	if upload.PodcastID != nil {
+		c.Set("Podcast-Id", strconv.FormatUint(uint64(*upload.PodcastID), 10))
+	}
+}
+
+func tusError(c *fiber.Ctx, err error) error {
+	switch {
+	case err.Error() == "yükleme bulunamadı":
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Yükleme bulunamadı"})
+	case err.Error() == "yüklemenin süresi dolmuş":
+		return c.Status(fiber.StatusGone).JSON(fiber.Map{"error": err.Error()})
+	case err.Error() == "yükleme konumu eşleşmiyor":
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+	case err.Error() == "yükleme boyutu aşıldı":
+		return c.Status(fiber.StatusRequestEntityTooLarge).JSON(fiber.Map{"error": err.Error()})
+	case err.Error() == "yükleme başka bir istek tarafından yazılıyor":
+		return c.Status(fiber.StatusLocked).JSON(fiber.Map{"error": err.Error()})
+	case err.Error() == "checksum eşleşmiyor":
+		return c.Status(statusChecksumMismatch).JSON(fiber.Map{"error": err.Error()})
+	case err.Error() == "desteklenmeyen checksum algoritması" || err.Error() == "geçersiz Upload-Checksum başlığı":
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	case isUploadValidationError(err):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+}
+
+// parseUploadMetadata, "anahtar base64değer" çiftlerinden oluşan Upload-Metadata başlığını çözer
+func parseUploadMetadata(header string) (map[string]string, error) {
+	metadata := make(map[string]string)
+	for _, pair := range strings.Split(header, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		key, encoded, _ := strings.Cut(pair, " ")
+		value, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, err
+		}
+		metadata[key] = string(value)
+	}
+	return metadata, nil
+}
@@ -4,8 +4,10 @@ import (
 	"shortcast/internal/dto"
 	"shortcast/internal/service"
 	"shortcast/internal/utils"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
 )
 
 type UserHandler struct {
@@ -19,7 +21,7 @@ func NewUserService(userService *service.UserService) *UserHandler {
 // GetByID godoc
 //
 //	@Summary		Get user by ID
-//	@Description	Get user details by user ID
+//	@Description	Get user details by user ID. The email address is only included when users view themselves.
 //	@Tags			user
 //	@Accept			json
 //	@Produce		json
@@ -36,22 +38,291 @@ func (h *UserHandler) GetByID(c *fiber.Ctx) error {
 		})
 	}
 
-	user, err := h.userService.GetUserById(id)
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	viewerID := uint(claims["user_id"].(float64))
+
+	userResponse, err := h.userService.GetUser(id, viewerID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"user": userResponse,
+	})
+}
+
+// GetProfile godoc
+//
+//	@Summary		Get creator profile
+//	@Description	Get a user's public profile with podcast, follower and received like counts
+//	@Tags			user
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		int	true	"User ID"
+//	@Success		200	{object}	dto.ProfileResponse
+//	@Failure		400	{object}	map[string]string	"error"
+//	@Failure		404	{object}	map[string]string	"error"
+//	@Router			/users/{id}/profile [get]
+func (h *UserHandler) GetProfile(c *fiber.Ctx) error {
+	id, err := utils.ParamAsUint(c, "id")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	viewerID := uint(claims["user_id"].(float64))
+
+	profile, err := h.userService.GetProfile(id, viewerID)
+	if err != nil {
+		if err.Error() == "kullanıcı bulunamadı" {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Profil getirilirken bir hata oluştu",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"profile": profile,
+	})
+}
+
+// GetMe godoc
+//
+//	@Summary		Get current user
+//	@Description	Get the authenticated user's details
+//	@Tags			user
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	dto.UserResponse
+//	@Failure		404	{object}	map[string]string	"error"
+//	@Router			/users/me [get]
+func (h *UserHandler) GetMe(c *fiber.Ctx) error {
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userID := uint(claims["user_id"].(float64))
+
+	userResponse, err := h.userService.GetUser(userID, userID)
 	if err != nil {
 		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
 
-	userDto := &dto.UserResponse{
-		ID:        user.ID,
-		Firstname: user.FirstName,
-		Lastname:  user.LastName,
-		Username:  user.Username,
-		Email:     user.Email,
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"user": userResponse,
+	})
+}
+
+// UpdateMe godoc
+//
+//	@Summary		Update profile
+//	@Description	Update the authenticated user's name, username or email. Only the fields sent are changed. Changing the email marks it unverified and sends a new verification link.
+//	@Tags			user
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			profile	body		dto.UpdateProfileRequest	true	"Profile fields"
+//	@Success		200		{object}	dto.UserResponse
+//	@Failure		400		{object}	map[string]string	"error"
+//	@Failure		409		{object}	map[string]string	"error"
+//	@Router			/users/me [patch]
+func (h *UserHandler) UpdateMe(c *fiber.Ctx) error {
+	var request dto.UpdateProfileRequest
+
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Geçersiz istek verisi",
+		})
+	}
+
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userID := uint(claims["user_id"].(float64))
+
+	updated, err := h.userService.UpdateProfile(userID, &request)
+	if err != nil {
+		status := fiber.StatusBadRequest
+		if err.Error() == "bu kullanıcı adı zaten kullanılıyor" || err.Error() == "bu email adresi zaten kullanılıyor" {
+			status = fiber.StatusConflict
+		}
+		return c.Status(status).JSON(fiber.Map{
+			"error": err.Error(),
+		})
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"user": userDto,
+		"user": updated,
 	})
 }
+
+// UpdateAvatar godoc
+//
+//	@Summary		Update avatar
+//	@Description	Upload a new profile picture (JPEG, PNG or WebP, max 5 MB)
+//	@Tags			user
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			avatar	formData	file	true	"Profile picture"
+//	@Success		200		{object}	dto.UserResponse
+//	@Failure		400		{object}	map[string]string	"error"
+//	@Failure		500		{object}	map[string]string	"error"
+//	@Router			/users/me/avatar [put]
+func (h *UserHandler) UpdateAvatar(c *fiber.Ctx) error {
+	avatarFile, err := c.FormFile("avatar")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Profil fotoğrafı gerekli",
+		})
+	}
+
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userID := uint(claims["user_id"].(float64))
+
+	userResponse, err := h.userService.UpdateAvatar(userID, avatarFile)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "profil fotoğrafı") {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Profil fotoğrafı güncellenirken bir hata oluştu",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"user": userResponse,
+	})
+}
+
+// DeleteAvatar godoc
+//
+//	@Summary		Remove avatar
+//	@Description	Remove the authenticated user's profile picture
+//	@Tags			user
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	dto.UserResponse
+//	@Failure		500	{object}	map[string]string	"error"
+//	@Router			/users/me/avatar [delete]
+func (h *UserHandler) DeleteAvatar(c *fiber.Ctx) error {
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userID := uint(claims["user_id"].(float64))
+
+	userResponse, err := h.userService.DeleteAvatar(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Profil fotoğrafı kaldırılırken bir hata oluştu",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"user": userResponse,
+	})
+}
+
+// ChangePassword godoc
+//
+//	@Summary		Change password
+//	@Description	Change the authenticated user's password. All other sessions are signed out. Accounts created through an external provider without a password can set one without current_password, but only from a session opened by signing in within the last 10 minutes.
+//	@Tags			user
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			password	body		dto.ChangePasswordRequest	true	"Current and new password"
+//	@Success		200			{object}	map[string]string			"message"
+//	@Failure		400			{object}	map[string]string			"error"
+//	@Failure		401			{object}	map[string]string			"error"
+//	@Router			/users/me/password [put]
+func (h *UserHandler) ChangePassword(c *fiber.Ctx) error {
+	var request dto.ChangePasswordRequest
+
+	if err := c.BodyParser(&request); err != nil || request.NewPassword == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Geçersiz istek verisi",
+		})
+	}
+
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userID := uint(claims["user_id"].(float64))
+
+	var currentSessionID uint
+	if sid, ok := claims["sid"].(float64); ok {
+		currentSessionID = uint(sid)
+	}
+
+	if err := h.userService.ChangePassword(userID, currentSessionID, &request); err != nil {
+		if err.Error() == "şifre yanlış" || err.Error() == "bu işlem için yeniden giriş yapmalısınız" {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Şifre değiştirildi, diğer oturumlar sonlandırıldı",
+	})
+}
+
+// DeleteMe godoc
+//
+//	@Summary		Delete account
+//	@Description	Permanently delete the authenticated user's account together with their podcasts, likes, comments and uploaded files. The current password is required if the account has one; accounts without a password must use a session opened by signing in within the last 10 minutes.
+//	@Tags			user
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			account	body		dto.DeleteAccountRequest	false	"Current password"
+//	@Success		204		{object}	nil
+//	@Failure		400		{object}	map[string]string	"error"
+//	@Failure		401		{object}	map[string]string	"error"
+//	@Router			/users/me [delete]
+func (h *UserHandler) DeleteMe(c *fiber.Ctx) error {
+	var request dto.DeleteAccountRequest
+
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&request); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Geçersiz istek verisi",
+			})
+		}
+	}
+
+	user := c.Locals("user").(*jwt.Token)
+	claims := user.Claims.(jwt.MapClaims)
+	userID := uint(claims["user_id"].(float64))
+
+	var currentSessionID uint
+	if sid, ok := claims["sid"].(float64); ok {
+		currentSessionID = uint(sid)
+	}
+
+	if err := h.userService.DeleteAccount(userID, currentSessionID, request.Password); err != nil {
+		if err.Error() == "şifre yanlış" || err.Error() == "bu işlem için yeniden giriş yapmalısınız" {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Hesap silinirken bir hata oluştu",
+		})
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,46 @@
+package mailer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FileMailer, e-postaları göndermek yerine outbox dizinine .eml dosyası olarak yazar.
+// Yerel geliştirmede bir mail sağlayıcısına ihtiyaç duymadan linkleri görmek için kullanılır.
+type FileMailer struct {
+	dir  string
+	from string
+}
+
+func NewFileMailer(dir, from string) (*FileMailer, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("outbox dizini oluşturulamadı: %w", err)
+	}
+	return &FileMailer{dir: dir, from: from}, nil
+}
+
+func (m *FileMailer) Send(msg Message) error {
+	name := fmt.Sprintf("%d_%s.eml", time.Now().UnixNano(), sanitizeAddress(msg.To))
+	path := filepath.Join(m.dir, name)
+
+	if err := os.WriteFile(path, buildRFC822(m.from, msg), 0o644); err != nil {
+		return fmt.Errorf("e-posta outbox'a yazılamadı: %w", err)
+	}
+
+	fmt.Printf("Mailer - E-posta outbox'a yazıldı: %s\n", path)
+	return nil
+}
+
+func sanitizeAddress(address string) string {
+	out := make([]rune, 0, len(address))
+	for _, r := range address {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '.' || r == '-' || r == '_' {
+			out = append(out, r)
+		} else {
+			out = append(out, '_')
+		}
+	}
+	return string(out)
+}
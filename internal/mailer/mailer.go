@@ -0,0 +1,40 @@
+package mailer
+
+import (
+	"fmt"
+	"shortcast/internal/config"
+	"time"
+)
+
+// Message, gönderilecek bir e-postayı temsil eder
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer, e-posta gönderim altyapısını soyutlar. Üretimde SMTP, yerelde
+// dosya ya da bellek tabanlı outbox kullanılabilir.
+type Mailer interface {
+	Send(msg Message) error
+}
+
+// New, yapılandırmadaki sürücüye göre uygun Mailer'ı döndürür
+func New(cfg config.MailConfig) (Mailer, error) {
+	switch cfg.Driver {
+	case "smtp":
+		return NewSMTPMailer(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.From), nil
+	case "file", "":
+		return NewFileMailer(cfg.OutboxDir, cfg.From)
+	case "memory":
+		return NewMemoryMailer(), nil
+	default:
+		return nil, fmt.Errorf("bilinmeyen mail sürücüsü: %s", cfg.Driver)
+	}
+}
+
+// buildRFC822, mesajı SMTP ve .eml dosyaları için ortak formatta oluşturur
+func buildRFC822(from string, msg Message) []byte {
+	return []byte(fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nDate: %s\r\nMIME-Version: 1.0\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s\r\n",
+		from, msg.To, msg.Subject, time.Now().Format(time.RFC1123Z), msg.Body))
+}
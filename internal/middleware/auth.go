@@ -2,7 +2,11 @@ package middleware
 
 import (
 	"shortcast/internal/config"
+	"shortcast/internal/policy"
 	"shortcast/internal/repository"
+	"shortcast/internal/service"
+	"shortcast/internal/utils"
+	"strings"
 
 	jwtware "github.com/gofiber/contrib/jwt"
 	"github.com/gofiber/fiber/v2"
@@ -10,20 +14,26 @@ import (
 )
 
 type AuthMiddleware struct {
-	cfg      *config.Config
-	authRepo *repository.AuthRepository
+	cfg           *config.Config
+	keys          *utils.KeySet
+	authRepo      *repository.AuthRepository
+	userRepo      *repository.UserRepository
+	apiKeyService *service.APIKeyService
 }
 
-func NewAuthMiddleware(cfg *config.Config, authRepo *repository.AuthRepository) *AuthMiddleware {
+func NewAuthMiddleware(cfg *config.Config, keys *utils.KeySet, authRepo *repository.AuthRepository, userRepo *repository.UserRepository, apiKeyService *service.APIKeyService) *AuthMiddleware {
 	return &AuthMiddleware{
-		cfg:      cfg,
-		authRepo: authRepo,
+		cfg:           cfg,
+		keys:          keys,
+		authRepo:      authRepo,
+		userRepo:      userRepo,
+		apiKeyService: apiKeyService,
 	}
 }
 
 func (am *AuthMiddleware) JWTMiddleware() fiber.Handler {
 	return jwtware.New(jwtware.Config{
-		SigningKey: jwtware.SigningKey{Key: []byte(am.cfg.SecretKey)},
+		KeyFunc: am.keys.Keyfunc,
 		SuccessHandler: func(c *fiber.Ctx) error {
 			// Token'ı kontrol et
 			token := c.Get("Authorization")
@@ -45,6 +55,32 @@ func (am *AuthMiddleware) JWTMiddleware() fiber.Handler {
 				})
 			}
 
+			// Yalnızca access token'lar API'ye erişebilir; 2FA challenge
+			// token'ı gibi yarım kimlik doğrulamalar reddedilir
+			userToken := c.Locals("user").(*jwt.Token)
+			claims := userToken.Claims.(jwt.MapClaims)
+			if claims["typ"] != utils.TokenTypeAccess || !am.keys.ValidIssuer(userToken) {
+				return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+					"error": "Geçersiz token",
+				})
+			}
+
+			// Oturum iptal edilmiş mi kontrol et
+			if sid, ok := claims["sid"].(float64); ok {
+				revoked, err := am.authRepo.IsSessionRevoked(uint(sid))
+				if err != nil {
+					return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+						"error": "Token kontrolü yapılamadı",
+					})
+				}
+
+				if revoked {
+					return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+						"error": "Oturum sonlandırılmış",
+					})
+				}
+			}
+
 			return c.Next()
 		},
 	})
@@ -69,16 +105,118 @@ func (am *AuthMiddleware) GuestMiddleware() fiber.Handler {
 		}
 
 		// Token'ı doğrula
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-			return []byte(am.cfg.SecretKey), nil
-		})
+		token, err := jwt.Parse(tokenString, am.keys.Keyfunc, jwt.WithValidMethods(am.keys.ValidMethods()))
 
-		if err != nil || !token.Valid {
+		if err != nil || !token.Valid || !am.keys.ValidIssuer(token) {
 			return c.Next() // Geçersiz token ise guest olarak kabul et
 		}
 
+		// Access token dışındaki token'lar (ör. 2FA challenge) oturum açılmış sayılmaz
+		if claims, ok := token.Claims.(jwt.MapClaims); !ok || claims["typ"] != utils.TokenTypeAccess {
+			return c.Next()
+		}
+
 		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
 			"error": "Giriş yapmış kullanıcılar bu API'yi kullanamaz!",
 		})
 	}
 }
+
+// Authenticate, Bearer JWT'lerin yanında X-API-Key header'ı veya "Bearer sc_..."
+// şeklinde gönderilen API anahtarlarını da kabul eder. API anahtarıyla gelen
+// isteklerde handler'ların aynı şekilde çalışabilmesi için "user" local'ine
+// anahtar sahibinin bilgileriyle bir token yerleştirilir.
+func (am *AuthMiddleware) Authenticate() fiber.Handler {
+	jwtHandler := am.JWTMiddleware()
+
+	return func(c *fiber.Ctx) error {
+		rawKey := c.Get("X-API-Key")
+		if rawKey == "" {
+			authorization := c.Get("Authorization")
+			if strings.HasPrefix(authorization, "Bearer "+service.APIKeyPrefix) {
+				rawKey = authorization[7:]
+			}
+		}
+
+		if rawKey == "" {
+			return jwtHandler(c)
+		}
+
+		key, err := am.apiKeyService.Authenticate(rawKey)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error": "Geçersiz API anahtarı",
+			})
+		}
+
+		scopes := strings.Split(key.Scopes, ",")
+		c.Locals("user", &jwt.Token{
+			Valid: true,
+			Claims: jwt.MapClaims{
+				"user_id": float64(key.UserID),
+				"typ":     "api_key",
+				"key_id":  float64(key.ID),
+			},
+		})
+		c.Locals("api_key_scopes", scopes)
+
+		return c.Next()
+	}
+}
+
+// RequireScope, API anahtarıyla gelen isteklerde anahtarın ilgili kapsama
+// sahip olmasını şart koşar. JWT ile giriş yapmış kullanıcılar tüm kapsamlara sahiptir.
+func (am *AuthMiddleware) RequireScope(scope string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		scopes, ok := c.Locals("api_key_scopes").([]string)
+		if !ok {
+			return c.Next()
+		}
+
+		for _, s := range scopes {
+			if s == scope {
+				return c.Next()
+			}
+		}
+
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "API anahtarının bu işlem için yetkisi yok: " + scope,
+		})
+	}
+}
+
+// RequireSession, şifre veya hesap silme gibi hesap düzeyindeki işlemlerin
+// API anahtarıyla değil, yalnızca oturum açmış kullanıcı tarafından yapılmasını sağlar
+func (am *AuthMiddleware) RequireSession() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if _, ok := c.Locals("api_key_scopes").([]string); ok {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": "Bu işlem API anahtarıyla yapılamaz",
+			})
+		}
+		return c.Next()
+	}
+}
+
+// RequireRole, kullanıcının verilen rollerden birine sahip olmasını şart koşar.
+// Rol değişikliklerinin hemen geçerli olması için rol token'dan değil veritabanından okunur.
+func (am *AuthMiddleware) RequireRole(roles ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		token, ok := c.Locals("user").(*jwt.Token)
+		if !ok {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error": "Geçersiz token",
+			})
+		}
+
+		claims := token.Claims.(jwt.MapClaims)
+		user, err := am.userRepo.GetUserByID(uint(claims["user_id"].(float64)))
+		if err != nil || !policy.HasRole(user, roles...) {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": "Bu işlem için yetkiniz yok",
+			})
+		}
+
+		return c.Next()
+	}
+}
@@ -0,0 +1,37 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	ScopePodcastsRead  = "podcasts:read"
+	ScopePodcastsWrite = "podcasts:write"
+	ScopeCommentsWrite = "comments:write"
+	ScopeUsersRead     = "users:read"
+)
+
+// APIScopes, API anahtarlarına verilebilecek tüm yetki kapsamlarıdır
+var APIScopes = []string{
+	ScopePodcastsRead,
+	ScopePodcastsWrite,
+	ScopeCommentsWrite,
+	ScopeUsersRead,
+}
+
+// APIKey, script ve CI işleri gibi otomasyon istemcileri için kullanıcıya ait
+// kişisel erişim anahtarıdır. Anahtarın kendisi yalnızca oluşturulurken gösterilir.
+type APIKey struct {
+	gorm.Model
+	UserID     uint   `gorm:"not null;index"`
+	User       User   `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Name       string `gorm:"type:varchar(100);not null"`
+	Prefix     string `gorm:"type:varchar(16);not null"`
+	KeyHash    string `gorm:"type:varchar(64);uniqueIndex;not null"`
+	Scopes     string `gorm:"type:varchar(255);not null"` // Virgülle ayrılmış kapsam listesi
+	LastUsedAt *time.Time
+	ExpiresAt  *time.Time
+	RevokedAt  *time.Time
+}
@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+// Blob, içerik adresli bir dosyanın kaç kayıt (podcast sesi, kapak, avatar) tarafından
+// kullanıldığını tutar. Anahtar içeriğin SHA-256 özetinden türetildiğinden aynı dosya
+// tekrar yüklendiğinde mevcut nesne kullanılır; nesne son referans bırakıldığında silinir.
+// Nesnesi silinemeyen kayıtlar RefCount 0 olarak kalır ve sahipsiz dosya temizliğinde silinir.
+type Blob struct {
+	Key       string `gorm:"type:varchar(255);primaryKey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Size      int64 `gorm:"not null;default:0"`
+	RefCount  int64 `gorm:"not null;default:0"`
+	// Stored, nesnenin depoya yüklendiğini belirtir; ilk referansı alan yükleme worker'da
+	// tamamlanana kadar false'tur
+	Stored bool `gorm:"not null;default:false"`
+}
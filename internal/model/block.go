@@ -0,0 +1,23 @@
+package model
+
+import "gorm.io/gorm"
+
+// Block, BlockerID kullanıcısının BlockedID kullanıcısını engellediğini belirtir.
+// Engellenen kullanıcı engelleyenin podcastlerini göremez, beğenemez ve yorum yapamaz.
+type Block struct {
+	gorm.Model
+	BlockerID uint `gorm:"not null;uniqueIndex:idx_block_pair"`
+	Blocker   User `gorm:"foreignKey:BlockerID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	BlockedID uint `gorm:"not null;uniqueIndex:idx_block_pair;index"`
+	Blocked   User `gorm:"foreignKey:BlockedID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+// Mute, MuterID kullanıcısının MutedID kullanıcısının podcastlerini keşfet ve
+// takip akışında görmek istemediğini belirtir. Sessize alınan kullanıcı bundan etkilenmez.
+type Mute struct {
+	gorm.Model
+	MuterID uint `gorm:"not null;uniqueIndex:idx_mute_pair"`
+	Muter   User `gorm:"foreignKey:MuterID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	MutedID uint `gorm:"not null;uniqueIndex:idx_mute_pair;index"`
+	Muted   User `gorm:"foreignKey:MutedID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
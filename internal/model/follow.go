@@ -0,0 +1,12 @@
+package model
+
+import "gorm.io/gorm"
+
+// Follow, FollowerID kullanıcısının FollowingID kullanıcısını takip ettiğini belirtir
+type Follow struct {
+	gorm.Model
+	FollowerID  uint `gorm:"not null;uniqueIndex:idx_follow_pair"`
+	Follower    User `gorm:"foreignKey:FollowerID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	FollowingID uint `gorm:"not null;uniqueIndex:idx_follow_pair;index"`
+	Following   User `gorm:"foreignKey:FollowingID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
@@ -0,0 +1,29 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PendingUpload, istemcinin imzalı URL'lerle doğrudan R2'ye yüklediği ancak henüz
+// tamamlamadığı podcast yüklemesidir. Dosyalar tamamlanana kadar uploads/ altındaki
+// geçici anahtarlarda durur; süresi dolan kayıtlar dosyalarıyla birlikte silinir.
+type PendingUpload struct {
+	gorm.Model
+	UserID           uint      `gorm:"not null;index"`
+	User             User      `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Title            string    `gorm:"type:varchar(255);not null"`
+	Category         string    `gorm:"type:varchar(100);not null"`
+	AudioFilename    string    `gorm:"type:varchar(255);not null"`
+	AudioKey         string    `gorm:"type:varchar(255);not null"`
+	AudioSize        int64     `gorm:"not null"`
+	AudioContentType string    `gorm:"type:varchar(100);not null"`
+	CoverFilename    string    `gorm:"type:varchar(255);not null"`
+	CoverKey         string    `gorm:"type:varchar(255);not null"`
+	CoverSize        int64     `gorm:"not null"`
+	CoverContentType string    `gorm:"type:varchar(100);not null"`
+	ExpiresAt        time.Time `gorm:"not null;index"`
+	// ClaimedAt, yükleme tamamlanırken doldurulur; aynı yüklemenin iki kez tamamlanmasını önler
+	ClaimedAt *time.Time
+}
@@ -2,12 +2,44 @@ package model
 
 import "gorm.io/gorm"
 
+// Podcast durumları. Yeni yüklenen podcastler ses ve kapak dosyaları arka planda
+// işlenene kadar "processing" durumundadır; yalnızca "ready" podcastler listelenir.
+const (
+	PodcastStatusProcessing = "processing"
+	PodcastStatusReady      = "ready"
+	PodcastStatusFailed     = "failed"
+)
+
 type Podcast struct {
 	gorm.Model
 	Title    string `gorm:"type:varchar(255);not null"`
 	Category string `gorm:"type:varchar(100);not null"`
 	AudioKey string `gorm:"type:varchar(255);not null"`
-	CoverKey string `gorm:"type:varchar(255);not null"`
-	UserID   uint   `gorm:"not null;index"`
-	User     User   `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	MimeType string `gorm:"type:varchar(100);not null;default:'audio/mpeg'"`
+	// Ses bilgileri; bu alanlardan önce yüklenen podcastlerde cmd/backfill çalıştırılana kadar sıfırdır
+	DurationMs int64  `gorm:"not null;default:0"`
+	Bitrate    int    `gorm:"not null;default:0"`
+	SampleRate int    `gorm:"not null;default:0"`
+	Channels   int    `gorm:"not null;default:0"`
+	SizeBytes  int64  `gorm:"not null;default:0"`
+	Codec      string `gorm:"type:varchar(32);not null;default:''"`
+	CoverKey   string `gorm:"type:varchar(255);not null"`
+	// HasCoverRenditions, kapağın 150/300/600 px kare sürümlerinin üretildiğini belirtir.
+	// Sürümlerin anahtarları cover.RenditionKey ile kapak anahtarından türetilir.
+	HasCoverRenditions bool `gorm:"not null;default:false"`
+	// WaveformKey, ses dosyasının yanında saklanan dalga formu JSON'ının anahtarıdır.
+	// Çözücüsü olmayan formatlarda (M4A, Opus) boştur.
+	WaveformKey string `gorm:"type:varchar(255);not null;default:''"`
+	// Ses yüksekliği ölçümü (LUFS ve dBTP); ölçülmemiş podcastlerde nil'dir
+	LoudnessLUFS *float64
+	LoudnessPeak *float64
+	// NormalizedAudioKey, hedef ses yüksekliğine getirilmiş ve sessizlikleri kırpılmış
+	// sürümün anahtarıdır. ffmpeg bulunmadığında boştur.
+	NormalizedAudioKey string `gorm:"type:varchar(255);not null;default:''"`
+	// HLSKey, hls/<podcast_id>/ altındaki master playlist'in anahtarıdır. Sürümlerin
+	// playlist'leri ve segmentleri aynı dizindedir. HLS paketleme kapalıyken boştur.
+	HLSKey string `gorm:"column:hls_key;type:varchar(255);not null;default:''"`
+	Status string `gorm:"type:varchar(20);not null;default:'ready';index"`
+	UserID uint   `gorm:"not null;index"`
+	User   User   `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
 }
@@ -0,0 +1,17 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// RecoveryCode, iki adımlı doğrulamada authenticator cihazı kaybedildiğinde
+// kullanılabilecek tek kullanımlık kodların hash'lerini tutar
+type RecoveryCode struct {
+	gorm.Model
+	UserID   uint   `gorm:"not null;index"`
+	User     User   `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	CodeHash string `gorm:"type:varchar(64);not null;index"`
+	UsedAt   *time.Time
+}
@@ -0,0 +1,31 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Session, bir kullanıcının tek bir cihazdaki oturumunu temsil eder
+type Session struct {
+	gorm.Model
+	UserID     uint       `gorm:"not null;index"`
+	User       User       `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	UserAgent  string     `gorm:"type:varchar(255)"`
+	IPAddress  string     `gorm:"type:varchar(64)"`
+	LastUsedAt time.Time  `gorm:"not null"`
+	ExpiresAt  time.Time  `gorm:"not null"`
+	RevokedAt  *time.Time `gorm:"index"`
+}
+
+// RefreshToken, bir oturuma ait refresh token'ın hash'ini tutar.
+// Her yenilemede token döndürülür; kullanılmış bir token tekrar gelirse
+// token çalınmış kabul edilir ve oturum iptal edilir.
+type RefreshToken struct {
+	gorm.Model
+	SessionID uint      `gorm:"not null;index"`
+	Session   Session   `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	TokenHash string    `gorm:"type:varchar(64);uniqueIndex;not null"`
+	ExpiresAt time.Time `gorm:"not null"`
+	UsedAt    *time.Time
+}
@@ -0,0 +1,36 @@
+package model
+
+import "time"
+
+// TusUpload, tus protokolüyle parça parça yüklenen bir dosyadır. Podcast için önce kapak
+// fotoğrafı, ardından kapak yüklemesine başvuran ses dosyası yüklenir; ses dosyası
+// tamamlandığında podcast oluşturulur. Kimlik, yükleme URL'inde kullanıldığından tahmin
+// edilemeyen rastgele bir değerdir.
+type TusUpload struct {
+	ID            string `gorm:"type:varchar(32);primaryKey"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	UserID        uint   `gorm:"not null;index"`
+	User          User   `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Kind          string `gorm:"type:varchar(10);not null"` // audio veya cover
+	Filename      string `gorm:"type:varchar(255);not null"`
+	Title         string `gorm:"type:varchar(255)"`
+	Category      string `gorm:"type:varchar(100)"`
+	CoverUploadID string `gorm:"type:varchar(32)"`
+	StartMs       *int64
+	EndMs         *int64
+	Length        int64 `gorm:"column:upload_length;not null"`
+	Offset        int64 `gorm:"column:upload_offset;not null;default:0"`
+	// StorageKey, MultipartID, PartCount ve PartialSize depolama sürücüsünün durumudur.
+	// R2'de 5 MB'tan küçük parçalar bir sonraki PATCH'e kadar ayrı bir nesnede bekletilir.
+	StorageKey  string    `gorm:"type:varchar(255);not null"`
+	MultipartID string    `gorm:"type:varchar(255)"`
+	PartCount   int       `gorm:"not null;default:0"`
+	PartialSize int64     `gorm:"not null;default:0"`
+	ExpiresAt   time.Time `gorm:"not null;index"`
+	// LockedUntil, bir parça yazılırken doldurulur; aynı yüklemeye eş zamanlı yazılmasını önler
+	LockedUntil *time.Time
+	// PodcastID, ses yüklemesinden podcast oluşturulduğunda doldurulur. Dosyalar spool
+	// dizinine alındıktan sonra depodan silinir; kayıt süresi dolana kadar durur.
+	PodcastID *uint
+}
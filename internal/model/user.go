@@ -1,13 +1,31 @@
 package model
 
-import "gorm.io/gorm"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	RoleUser      = "user"
+	RoleModerator = "moderator"
+	RoleAdmin     = "admin"
+)
 
 type User struct {
 	gorm.Model
-	FirstName string `gorm:"type:varchar(100);not null"`
-	LastName  string `gorm:"type:varchar(100);not null"`
-	Username  string `gorm:"type:varchar(100);uniqueIndex;not null"`
-	Email     string `gorm:"type:varchar(100);uniqueIndex;not null"`
-	Password  string `gorm:"type:varchar(255);not null"`
-	Podcasts []Podcast `gorm:"foreignKey:UserID"`
+	FirstName       string `gorm:"type:varchar(100);not null"`
+	LastName        string `gorm:"type:varchar(100);not null"`
+	Username        string `gorm:"type:varchar(100);uniqueIndex;not null"`
+	Email           string `gorm:"type:varchar(100);uniqueIndex;not null"`
+	Password        string `gorm:"type:varchar(255);not null"`
+	Role            string `gorm:"type:varchar(20);not null;default:'user'"`
+	EmailVerifiedAt *time.Time
+	TOTPSecret      string    `gorm:"type:varchar(64)"`
+	TOTPEnabled     bool      `gorm:"not null;default:false"`
+	Bio             string    `gorm:"type:varchar(500)"`
+	AvatarKey       string    `gorm:"type:varchar(255)"`
+	Website         string    `gorm:"type:varchar(255)"`
+	Location        string    `gorm:"type:varchar(100)"`
+	Podcasts        []Podcast `gorm:"foreignKey:UserID"`
 }
@@ -0,0 +1,14 @@
+package model
+
+import "gorm.io/gorm"
+
+// UserIdentity, bir kullanıcıyı harici bir OpenID Connect sağlayıcısındaki hesabına bağlar.
+// Bir kullanıcının şifresinin yanında birden fazla bağlı sağlayıcısı olabilir.
+type UserIdentity struct {
+	gorm.Model
+	UserID   uint   `gorm:"not null;index"`
+	User     User   `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Provider string `gorm:"type:varchar(50);not null;uniqueIndex:idx_identity_provider_subject"`
+	Subject  string `gorm:"type:varchar(255);not null;uniqueIndex:idx_identity_provider_subject"`
+	Email    string `gorm:"type:varchar(100)"`
+}
@@ -0,0 +1,24 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	TokenPurposeEmailVerification = "email_verification"
+	TokenPurposePasswordReset     = "password_reset"
+)
+
+// UserToken, e-posta doğrulama ve şifre sıfırlama gibi tek kullanımlık
+// token'ların hash'lerini tutar. Token'ın kendisi yalnızca e-postada bulunur.
+type UserToken struct {
+	gorm.Model
+	UserID    uint      `gorm:"not null;index"`
+	User      User      `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Purpose   string    `gorm:"type:varchar(50);not null;index"`
+	TokenHash string    `gorm:"type:varchar(64);uniqueIndex;not null"`
+	ExpiresAt time.Time `gorm:"not null"`
+	UsedAt    *time.Time
+}
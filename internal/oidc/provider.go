@@ -0,0 +1,274 @@
+package oidc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"shortcast/internal/config"
+	"shortcast/internal/utils"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MicahParks/keyfunc/v2"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Provider, tek bir OpenID Connect sağlayıcısıyla authorization code + PKCE akışını yürütür.
+// Discovery dokümanı ve JWKS ilk ihtiyaç duyulduğunda yüklenir; böylece sağlayıcıya
+// erişilemese bile uygulama açılabilir.
+type Provider struct {
+	cfg    config.OIDCProviderConfig
+	client *http.Client
+
+	mu        sync.Mutex
+	discovery *discoveryDocument
+	jwks      *keyfunc.JWKS
+}
+
+type discoveryDocument struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type tokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// Claims, ID token'dan kullanıcı eşleştirmesi için ihtiyaç duyulan alanlardır
+type Claims struct {
+	Subject           string
+	Email             string
+	EmailVerified     bool
+	GivenName         string
+	FamilyName        string
+	Name              string
+	PreferredUsername string
+}
+
+func NewProvider(cfg config.OIDCProviderConfig) *Provider {
+	return &Provider{
+		cfg:    cfg,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (p *Provider) Name() string {
+	return p.cfg.Name
+}
+
+// AuthCodeURL, kullanıcının yönlendirileceği sağlayıcı giriş adresini üretir
+func (p *Provider) AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error) {
+	doc, err := p.loadDiscovery(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	params := url.Values{}
+	params.Set("response_type", "code")
+	params.Set("client_id", p.cfg.ClientID)
+	params.Set("redirect_uri", p.cfg.RedirectURL)
+	params.Set("scope", strings.Join(p.cfg.Scopes, " "))
+	params.Set("state", state)
+	params.Set("nonce", nonce)
+	params.Set("code_challenge", codeChallenge)
+	params.Set("code_challenge_method", "S256")
+
+	separator := "?"
+	if strings.Contains(doc.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+	return doc.AuthorizationEndpoint + separator + params.Encode(), nil
+}
+
+// Exchange, callback'e gelen kodu code verifier ile birlikte token endpoint'ine gönderir ve ID token'ı döndürür
+func (p *Provider) Exchange(ctx context.Context, code, codeVerifier string) (string, error) {
+	doc, err := p.loadDiscovery(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.cfg.RedirectURL)
+	form.Set("code_verifier", codeVerifier)
+	form.Set("client_id", p.cfg.ClientID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, doc.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if p.cfg.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(p.cfg.ClientID), url.QueryEscape(p.cfg.ClientSecret))
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", err
+	}
+
+	var token tokenResponse
+	if err := json.Unmarshal(body, &token); err != nil {
+		return "", fmt.Errorf("token yanıtı okunamadı: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		if token.Error != "" {
+			return "", fmt.Errorf("token alınamadı: %s", strings.TrimSpace(token.Error+" "+token.ErrorDescription))
+		}
+		return "", fmt.Errorf("token alınamadı: HTTP %d", resp.StatusCode)
+	}
+	if token.IDToken == "" {
+		return "", errors.New("sağlayıcı ID token döndürmedi")
+	}
+
+	return token.IDToken, nil
+}
+
+// VerifyIDToken, ID token'ın imzasını, issuer, audience, süre ve nonce alanlarını doğrular
+func (p *Provider) VerifyIDToken(ctx context.Context, rawIDToken, nonce string) (*Claims, error) {
+	doc, err := p.loadDiscovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+	jwks, err := p.loadJWKS(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(rawIDToken, claims, jwks.Keyfunc,
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "PS256", "ES256", "ES384", "EdDSA"}),
+		jwt.WithIssuer(doc.Issuer),
+		jwt.WithAudience(p.cfg.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(time.Minute),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("ID token doğrulanamadı: %w", err)
+	}
+
+	if tokenNonce, _ := claims["nonce"].(string); tokenNonce == "" || tokenNonce != nonce {
+		return nil, errors.New("ID token nonce değeri eşleşmiyor")
+	}
+
+	result := &Claims{
+		Subject:           stringClaim(claims, "sub"),
+		Email:             strings.ToLower(stringClaim(claims, "email")),
+		GivenName:         stringClaim(claims, "given_name"),
+		FamilyName:        stringClaim(claims, "family_name"),
+		Name:              stringClaim(claims, "name"),
+		PreferredUsername: stringClaim(claims, "preferred_username"),
+	}
+	// Bazı sağlayıcılar email_verified alanını string olarak gönderir
+	switch verified := claims["email_verified"].(type) {
+	case bool:
+		result.EmailVerified = verified
+	case string:
+		result.EmailVerified = verified == "true"
+	}
+
+	if result.Subject == "" {
+		return nil, errors.New("ID token sub alanı içermiyor")
+	}
+
+	return result, nil
+}
+
+func (p *Provider) loadDiscovery(ctx context.Context) (*discoveryDocument, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+
+	wellKnown := strings.TrimSuffix(p.cfg.Issuer, "/") + "/.well-known/openid-configuration"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, wellKnown, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("OIDC discovery dokümanı alınamadı: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("OIDC discovery dokümanı alınamadı: HTTP %d", resp.StatusCode)
+	}
+
+	var doc discoveryDocument
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("OIDC discovery dokümanı okunamadı: %w", err)
+	}
+
+	// Issuer, yapılandırılan adresle birebir eşleşmeli (OIDC Discovery 4.3)
+	if strings.TrimSuffix(doc.Issuer, "/") != strings.TrimSuffix(p.cfg.Issuer, "/") {
+		return nil, fmt.Errorf("OIDC issuer eşleşmiyor: %s", doc.Issuer)
+	}
+	if doc.AuthorizationEndpoint == "" || doc.TokenEndpoint == "" || doc.JWKSURI == "" {
+		return nil, errors.New("OIDC discovery dokümanı eksik")
+	}
+
+	p.discovery = &doc
+	return p.discovery, nil
+}
+
+func (p *Provider) loadJWKS(doc *discoveryDocument) (*keyfunc.JWKS, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.jwks != nil {
+		return p.jwks, nil
+	}
+
+	jwks, err := keyfunc.Get(doc.JWKSURI, keyfunc.Options{
+		Client:            p.client,
+		RefreshInterval:   time.Hour,
+		RefreshRateLimit:  5 * time.Minute,
+		RefreshUnknownKID: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("OIDC JWKS alınamadı: %w", err)
+	}
+
+	p.jwks = jwks
+	return p.jwks, nil
+}
+
+// GenerateCodeVerifier, PKCE için rastgele bir code verifier üretir
+func GenerateCodeVerifier() (string, error) {
+	return utils.GenerateRandomToken(32)
+}
+
+// CodeChallenge, code verifier'ın S256 challenge değerini döndürür
+func CodeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func stringClaim(claims jwt.MapClaims, key string) string {
+	value, _ := claims[key].(string)
+	return value
+}
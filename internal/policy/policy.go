@@ -0,0 +1,64 @@
+package policy
+
+import "shortcast/internal/model"
+
+// Action, yetki kontrolü yapılan bir işlemi tanımlar
+type Action string
+
+const (
+	UpdatePodcast Action = "podcast:update"
+	DeletePodcast Action = "podcast:delete"
+	DeleteComment Action = "comment:delete"
+	ManageUsers   Action = "users:manage"
+)
+
+// moderatorActions, moderatörlerin kaynağın sahibi olmasalar da yapabileceği işlemlerdir
+var moderatorActions = map[Action]bool{
+	DeletePodcast: true,
+	DeleteComment: true,
+}
+
+// Can, kullanıcının sahibi ownerID olan bir kaynak üzerinde işlemi yapıp yapamayacağını döndürür.
+// Sahiplik gerektirmeyen işlemler için ownerID olarak 0 verilebilir.
+func Can(user *model.User, action Action, ownerID uint) bool {
+	if user == nil {
+		return false
+	}
+
+	if user.Role == model.RoleAdmin {
+		return true
+	}
+
+	if action == ManageUsers {
+		return false
+	}
+
+	if user.Role == model.RoleModerator && moderatorActions[action] {
+		return true
+	}
+
+	return ownerID != 0 && user.ID == ownerID
+}
+
+// IsValidRole, rolün tanımlı rollerden biri olup olmadığını kontrol eder
+func IsValidRole(role string) bool {
+	switch role {
+	case model.RoleUser, model.RoleModerator, model.RoleAdmin:
+		return true
+	default:
+		return false
+	}
+}
+
+// HasRole, kullanıcının verilen rollerden birine sahip olup olmadığını kontrol eder
+func HasRole(user *model.User, roles ...string) bool {
+	if user == nil {
+		return false
+	}
+	for _, role := range roles {
+		if user.Role == role {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,244 @@
+// Package queue, Redis üzerinde çalışan basit ve kalıcı bir iş kuyruğudur.
+//
+// Bekleyen işler "<ad>:pending" listesinde tutulur. Bir worker işi aldığında iş atomik
+// olarak kira süresinin bitiş zamanıyla "<ad>:leased" sıralı kümesine taşınır ve başarıyla
+// tamamlanınca oradan silinir. Worker iş sürdükçe kirayı uzatır; kirası dolan işler (ör. işi
+// alan süreç çöktüyse) tekrar bekleyen işlere alınır. Böylece aynı kuyruğu birden fazla süreç
+// güvenle işleyebilir. Başarısız işler artan bekleme süreleriyle "<ad>:delayed" sıralı
+// kümesine, deneme hakkı biten işler ise "<ad>:dead" listesine (dead letter) yazılır.
+package queue
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Job, kuyruktaki bir iştir. Payload, işin türüne göre handler tarafından çözülür.
+type Job struct {
+	ID          string          `json:"id"`
+	Type        string          `json:"type"`
+	Payload     json.RawMessage `json:"payload"`
+	Attempts    int             `json:"attempts"`
+	MaxAttempts int             `json:"max_attempts"`
+	LastError   string          `json:"last_error,omitempty"`
+	EnqueuedAt  time.Time       `json:"enqueued_at"`
+}
+
+// Decode, işin payload'ını v'ye çözer
+func (j *Job) Decode(v interface{}) error {
+	return json.Unmarshal(j.Payload, v)
+}
+
+type Queue struct {
+	client      *redis.Client
+	name        string
+	maxAttempts int
+}
+
+// New, verilen adla bir kuyruk oluşturur. maxAttempts, bir işin dead letter listesine
+// taşınmadan önce en fazla kaç kez deneneceğidir.
+func New(client *redis.Client, name string, maxAttempts int) *Queue {
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+	return &Queue{
+		client:      client,
+		name:        name,
+		maxAttempts: maxAttempts,
+	}
+}
+
+func (q *Queue) pendingKey() string { return q.name + ":pending" }
+func (q *Queue) leasedKey() string  { return q.name + ":leased" }
+func (q *Queue) delayedKey() string { return q.name + ":delayed" }
+func (q *Queue) deadKey() string    { return q.name + ":dead" }
+
+// Enqueue, yeni bir işi kuyruğa ekler
+func (q *Queue) Enqueue(ctx context.Context, jobType string, payload interface{}) (*Job, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+
+	job := &Job{
+		ID:          hex.EncodeToString(id),
+		Type:        jobType,
+		Payload:     data,
+		MaxAttempts: q.maxAttempts,
+		EnqueuedAt:  time.Now(),
+	}
+
+	raw, err := json.Marshal(job)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := q.client.LPush(ctx, q.pendingKey(), raw).Err(); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// reserveScript, bekleyen en eski işi alır ve ARGV[1] zamanına kadar kiralanmış işlere ekler
+var reserveScript = redis.NewScript(`
+local raw = redis.call('RPOP', KEYS[1])
+if raw then
+	redis.call('ZADD', KEYS[2], ARGV[1], raw)
+end
+return raw
+`)
+
+// replaceScript, kiralanmış işin kaydını güncellenmiş haliyle değiştirir. Kira bu arada
+// dolup iş tekrar kuyruğa alındıysa 0 döner.
+var replaceScript = redis.NewScript(`
+if redis.call('ZREM', KEYS[1], ARGV[1]) == 0 then
+	return 0
+end
+redis.call('ZADD', KEYS[1], ARGV[3], ARGV[2])
+return 1
+`)
+
+// reserve, bekleyen bir işi lease süresi boyunca kiralayarak alır. timeout içinde iş
+// gelmezse nil döner. İşin deneme sayısı kiralanırken artırılır; böylece işi alan süreci
+// çökerten bir iş, kirası dolup tekrar kuyruğa alındığında da deneme hakkını tüketir.
+func (q *Queue) reserve(ctx context.Context, lease, timeout time.Duration) (*Job, string, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		raw, err := reserveScript.Run(ctx, q.client, []string{q.pendingKey(), q.leasedKey()},
+			time.Now().Add(lease).UnixMilli()).Text()
+		if err == redis.Nil {
+			wait := min(pollInterval, time.Until(deadline))
+			if wait <= 0 {
+				return nil, "", nil
+			}
+			select {
+			case <-ctx.Done():
+				return nil, "", ctx.Err()
+			case <-time.After(wait):
+			}
+			continue
+		}
+		if err != nil {
+			return nil, "", err
+		}
+
+		var job Job
+		if err := json.Unmarshal([]byte(raw), &job); err != nil {
+			// Çözülemeyen kayıt tekrar denense de düzelmez, doğrudan dead letter'a taşınır
+			q.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+				pipe.ZRem(ctx, q.leasedKey(), raw)
+				pipe.LPush(ctx, q.deadKey(), raw)
+				return nil
+			})
+			return nil, "", nil
+		}
+
+		job.Attempts++
+		updated, err := json.Marshal(&job)
+		if err != nil {
+			return nil, "", err
+		}
+		replaced, err := replaceScript.Run(ctx, q.client, []string{q.leasedKey()},
+			raw, updated, time.Now().Add(lease).UnixMilli()).Int()
+		if err != nil {
+			return nil, "", err
+		}
+		if replaced == 0 {
+			continue
+		}
+		return &job, string(updated), nil
+	}
+}
+
+// extend, kiralanmış işin kirasını until zamanına kadar uzatır. Kira zaten dolup iş tekrar
+// kuyruğa alındıysa false döner.
+func (q *Queue) extend(ctx context.Context, raw string, until time.Time) (bool, error) {
+	changed, err := q.client.ZAddArgs(ctx, q.leasedKey(), redis.ZAddArgs{
+		XX:      true,
+		Ch:      true,
+		Members: []redis.Z{{Score: float64(until.UnixMilli()), Member: raw}},
+	}).Result()
+	return changed == 1, err
+}
+
+// ack, tamamlanan işi kiralanmış işlerden siler
+func (q *Queue) ack(ctx context.Context, raw string) error {
+	return q.client.ZRem(ctx, q.leasedKey(), raw).Err()
+}
+
+// retry, başarısız işi readyAt zamanında tekrar denenmek üzere ertelenmiş işlere taşır
+func (q *Queue) retry(ctx context.Context, raw string, job *Job, readyAt time.Time) error {
+	updated, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+
+	_, err = q.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZRem(ctx, q.leasedKey(), raw)
+		pipe.ZAdd(ctx, q.delayedKey(), redis.Z{Score: float64(readyAt.UnixMilli()), Member: updated})
+		return nil
+	})
+	return err
+}
+
+// bury, deneme hakkı biten işi dead letter listesine taşır
+func (q *Queue) bury(ctx context.Context, raw string, job *Job) error {
+	updated, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+
+	_, err = q.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZRem(ctx, q.leasedKey(), raw)
+		pipe.LPush(ctx, q.deadKey(), updated)
+		return nil
+	})
+	return err
+}
+
+// promoteScript, sıralı kümede zamanı gelen işleri atomik olarak bekleyen işlere taşır
+var promoteScript = redis.NewScript(`
+local jobs = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1], 'LIMIT', 0, 100)
+for _, job in ipairs(jobs) do
+	redis.call('ZREM', KEYS[1], job)
+	redis.call('LPUSH', KEYS[2], job)
+end
+return #jobs
+`)
+
+func (q *Queue) promoteDelayed(ctx context.Context) error {
+	return promoteScript.Run(ctx, q.client, []string{q.delayedKey(), q.pendingKey()}, time.Now().UnixMilli()).Err()
+}
+
+// reclaimExpired, kirası dolmuş işleri tekrar bekleyen işlere taşır. Kira, işi alan worker
+// çalıştığı sürece uzatıldığından bu işler yalnızca süreci yarıda kesilen işlerdir.
+func (q *Queue) reclaimExpired(ctx context.Context) (int, error) {
+	return promoteScript.Run(ctx, q.client, []string{q.leasedKey(), q.pendingKey()}, time.Now().UnixMilli()).Int()
+}
+
+// DeadJobs, dead letter listesindeki en son işleri döndürür
+func (q *Queue) DeadJobs(ctx context.Context, limit int) ([]Job, error) {
+	raws, err := q.client.LRange(ctx, q.deadKey(), 0, int64(limit)-1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]Job, 0, len(raws))
+	for _, raw := range raws {
+		var job Job
+		if err := json.Unmarshal([]byte(raw), &job); err == nil {
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs, nil
+}
@@ -0,0 +1,214 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// HandlerFunc, bir işi çalıştırır. Dönen hata işin tekrar denenmesine neden olur.
+type HandlerFunc func(ctx context.Context, job *Job) error
+
+// DeadLetterFunc, deneme hakkı biten bir iş dead letter listesine taşındığında çağrılır
+type DeadLetterFunc func(ctx context.Context, job *Job, err error)
+
+// Worker, kuyruktaki işleri belirli sayıda goroutine ile paralel olarak işler
+type Worker struct {
+	queue       *Queue
+	concurrency int
+	handlers    map[string]HandlerFunc
+	onDead      DeadLetterFunc
+	wg          sync.WaitGroup
+}
+
+const (
+	reserveTimeout = 5 * time.Second
+	pollInterval   = 500 * time.Millisecond
+	// leaseDuration, bir işin kirası uzatılmadan sürebileceği en uzun süredir. Kira
+	// renewEvery aralıklarla uzatıldığından uzun süren işler de başka worker'a geçmez.
+	leaseDuration = time.Minute
+	renewEvery    = leaseDuration / 3
+	promoteEvery  = time.Second
+	baseBackoff   = 2 * time.Second
+	maxBackoff    = 5 * time.Minute
+)
+
+func NewWorker(queue *Queue, concurrency int) *Worker {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	return &Worker{
+		queue:       queue,
+		concurrency: concurrency,
+		handlers:    make(map[string]HandlerFunc),
+	}
+}
+
+// Handle, verilen türdeki işler için handler kaydeder. Start'tan önce çağrılmalıdır.
+func (w *Worker) Handle(jobType string, handler HandlerFunc) {
+	w.handlers[jobType] = handler
+}
+
+// OnDeadLetter, deneme hakkı biten işler için çağrılacak fonksiyonu belirler
+func (w *Worker) OnDeadLetter(fn DeadLetterFunc) {
+	w.onDead = fn
+}
+
+// Start, worker goroutine'lerini ve ertelenmiş işlerle kirası dolan işleri zamanı
+// geldiğinde kuyruğa alan zamanlayıcıyı başlatır. ctx iptal edildiğinde yeni iş alınmaz;
+// Wait ile çalışmakta olan işlerin bitmesi beklenebilir.
+func (w *Worker) Start(ctx context.Context) {
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		ticker := time.NewTicker(promoteEvery)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := w.queue.promoteDelayed(ctx); err != nil && ctx.Err() == nil {
+					fmt.Printf("Queue - HATA: Ertelenmiş işler kuyruğa alınamadı: %v\n", err)
+				}
+				n, err := w.queue.reclaimExpired(ctx)
+				if err != nil && ctx.Err() == nil {
+					fmt.Printf("Queue - HATA: Kirası dolan işler kuyruğa alınamadı: %v\n", err)
+				} else if n > 0 {
+					fmt.Printf("Queue - Kirası dolan %d iş tekrar kuyruğa alındı\n", n)
+				}
+			}
+		}
+	}()
+
+	for i := 0; i < w.concurrency; i++ {
+		w.wg.Add(1)
+		go func() {
+			defer w.wg.Done()
+			w.loop(ctx)
+		}()
+	}
+}
+
+// Wait, Start ile başlatılan tüm goroutine'lerin bitmesini bekler
+func (w *Worker) Wait() {
+	w.wg.Wait()
+}
+
+func (w *Worker) loop(ctx context.Context) {
+	for ctx.Err() == nil {
+		job, raw, err := w.queue.reserve(ctx, leaseDuration, reserveTimeout)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			fmt.Printf("Queue - HATA: İş alınamadı: %v\n", err)
+			time.Sleep(time.Second)
+			continue
+		}
+		if job == nil {
+			continue
+		}
+
+		// İş, sunucu kapanırken yarıda kesilmesin diye iptal edilmeyen bir context ile çalıştırılır
+		w.process(context.WithoutCancel(ctx), job, raw)
+	}
+}
+
+func (w *Worker) process(ctx context.Context, job *Job, raw string) {
+	handler, ok := w.handlers[job.Type]
+	var err error
+	switch {
+	case job.Attempts > job.MaxAttempts:
+		// Deneme hakkı, kirası dolup tekrar kuyruğa alınan denemelerle tükenmiş
+		err = errors.New("iş, kira süresi içinde tamamlanamadı")
+	case !ok:
+		// Bilinmeyen iş türü tekrar denense de işlenemez
+		err = fmt.Errorf("bilinmeyen iş türü: %s", job.Type)
+		job.Attempts = job.MaxAttempts
+	default:
+		stop := w.renewLease(ctx, job, raw)
+		err = safeRun(ctx, handler, job)
+		stop()
+	}
+
+	if err == nil {
+		if err := w.queue.ack(ctx, raw); err != nil {
+			fmt.Printf("Queue - HATA: İş tamamlandı olarak işaretlenemedi. ID: %s, Hata: %v\n", job.ID, err)
+		}
+		return
+	}
+
+	job.LastError = err.Error()
+	if job.Attempts >= job.MaxAttempts {
+		fmt.Printf("Queue - HATA: İş dead letter listesine taşındı. ID: %s, Tür: %s, Deneme: %d, Hata: %v\n", job.ID, job.Type, job.Attempts, err)
+		if err := w.queue.bury(ctx, raw, job); err != nil {
+			fmt.Printf("Queue - HATA: İş dead letter listesine taşınamadı. ID: %s, Hata: %v\n", job.ID, err)
+		}
+		if w.onDead != nil {
+			w.onDead(ctx, job, err)
+		}
+		return
+	}
+
+	delay := backoff(job.Attempts)
+	fmt.Printf("Queue - İş başarısız oldu, %s sonra tekrar denenecek. ID: %s, Tür: %s, Deneme: %d/%d, Hata: %v\n", delay, job.ID, job.Type, job.Attempts, job.MaxAttempts, err)
+	if err := w.queue.retry(ctx, raw, job, time.Now().Add(delay)); err != nil {
+		fmt.Printf("Queue - HATA: İş tekrar denenmek üzere ertelenemedi. ID: %s, Hata: %v\n", job.ID, err)
+	}
+}
+
+// renewLease, handler çalıştığı sürece işin kirasını düzenli aralıklarla uzatır. Dönen
+// fonksiyon uzatmayı durdurur.
+func (w *Worker) renewLease(ctx context.Context, job *Job, raw string) func() {
+	done := make(chan struct{})
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		ticker := time.NewTicker(renewEvery)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				ok, err := w.queue.extend(ctx, raw, time.Now().Add(leaseDuration))
+				if err != nil {
+					fmt.Printf("Queue - HATA: İşin kirası uzatılamadı. ID: %s, Hata: %v\n", job.ID, err)
+				} else if !ok {
+					fmt.Printf("Queue - HATA: İşin kirası dolmuş, iş tekrar kuyruğa alınmış olabilir. ID: %s\n", job.ID)
+					return
+				}
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-finished
+	}
+}
+
+// safeRun, handler'daki panikleri hataya çevirir
+func safeRun(ctx context.Context, handler HandlerFunc, job *Job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.New(fmt.Sprint("panic: ", r))
+		}
+	}()
+	return handler(ctx, job)
+}
+
+// backoff, n. başarısız denemeden sonra beklenecek süreyi üstel olarak artırır
+func backoff(attempt int) time.Duration {
+	delay := baseBackoff
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	return delay
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"errors"
+	"shortcast/internal/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type APIKeyRepository struct {
+	db *gorm.DB
+}
+
+func NewAPIKeyRepository(db *gorm.DB) *APIKeyRepository {
+	return &APIKeyRepository{db: db}
+}
+
+func (r *APIKeyRepository) CreateAPIKey(key *model.APIKey) error {
+	return r.db.Create(key).Error
+}
+
+func (r *APIKeyRepository) GetAPIKeysByUserID(userID uint) (*[]model.APIKey, error) {
+	var keys []model.APIKey
+	err := r.db.Where("user_id = ? AND revoked_at IS NULL", userID).
+		Order("created_at desc").Find(&keys).Error
+	return &keys, err
+}
+
+// GetAPIKeyByHash, anahtarı döndürür. Sahibi silinmiş anahtarlar bulunamamış sayılır.
+func (r *APIKeyRepository) GetAPIKeyByHash(hash string) (*model.APIKey, error) {
+	var key model.APIKey
+	err := r.db.Joins("JOIN users ON users.id = api_keys.user_id AND users.deleted_at IS NULL").
+		Where("api_keys.key_hash = ?", hash).
+		First(&key).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.New("API anahtarı bulunamadı")
+		}
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *APIKeyRepository) RevokeAPIKey(id, userID uint) error {
+	result := r.db.Model(&model.APIKey{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", id, userID).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("API anahtarı bulunamadı")
+	}
+	return nil
+}
+
+func (r *APIKeyRepository) TouchAPIKey(id uint, lastUsedAt time.Time) error {
+	return r.db.Model(&model.APIKey{}).Where("id = ?", id).Update("last_used_at", lastUsedAt).Error
+}
@@ -2,6 +2,8 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"shortcast/internal/model"
 	"time"
 
@@ -38,3 +40,224 @@ func (r *AuthRepository) IsTokenBlacklisted(token string) (bool, error) {
 	exists, err := r.redis.Exists(ctx, "blacklist:"+token).Result()
 	return exists == 1, err
 }
+
+func (r *AuthRepository) CreateSession(session *model.Session) error {
+	return r.db.Create(session).Error
+}
+
+func (r *AuthRepository) GetSessionByID(id uint) (*model.Session, error) {
+	var session model.Session
+	if err := r.db.First(&session, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.New("oturum bulunamadı")
+		}
+		return nil, err
+	}
+	return &session, nil
+}
+
+// GetActiveSessionsByUserID, kullanıcının iptal edilmemiş ve süresi dolmamış oturumlarını döndürür
+func (r *AuthRepository) GetActiveSessionsByUserID(userID uint) (*[]model.Session, error) {
+	var sessions []model.Session
+	err := r.db.Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, time.Now()).
+		Order("last_used_at desc").Find(&sessions).Error
+	return &sessions, err
+}
+
+// TouchSession, oturumun son kullanım zamanını ve son kullanıldığı cihaz bilgilerini günceller
+func (r *AuthRepository) TouchSession(id uint, lastUsedAt time.Time, userAgent, ip string) error {
+	return r.db.Model(&model.Session{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"last_used_at": lastUsedAt,
+		"user_agent":   userAgent,
+		"ip_address":   ip,
+	}).Error
+}
+
+// RevokeSession, oturumu iptal eder ve bu oturuma ait access token'ların
+// süreleri dolana kadar reddedilmesi için Redis'e işaret bırakır
+func (r *AuthRepository) RevokeSession(id uint, accessTTL time.Duration) error {
+	now := time.Now()
+	err := r.db.Model(&model.Session{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", now).Error
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	return r.redis.Set(ctx, fmt.Sprintf("revoked_session:%d", id), true, accessTTL).Err()
+}
+
+// RevokeUserSessions, kullanıcının exceptID dışındaki tüm aktif oturumlarını iptal eder
+func (r *AuthRepository) RevokeUserSessions(userID, exceptID uint, accessTTL time.Duration) error {
+	var ids []uint
+	err := r.db.Model(&model.Session{}).
+		Where("user_id = ? AND id <> ? AND revoked_at IS NULL", userID, exceptID).
+		Pluck("id", &ids).Error
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if err := r.RevokeSession(id, accessTTL); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *AuthRepository) IsSessionRevoked(id uint) (bool, error) {
+	ctx := context.Background()
+	exists, err := r.redis.Exists(ctx, fmt.Sprintf("revoked_session:%d", id)).Result()
+	return exists == 1, err
+}
+
+func (r *AuthRepository) CreateRefreshToken(token *model.RefreshToken) error {
+	return r.db.Create(token).Error
+}
+
+func (r *AuthRepository) GetRefreshTokenByHash(hash string) (*model.RefreshToken, error) {
+	var token model.RefreshToken
+	if err := r.db.Preload("Session").Where("token_hash = ?", hash).First(&token).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.New("refresh token bulunamadı")
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+// errRefreshTokenUsed, RotateRefreshToken işleminin geri alınması için kullanılır
+var errRefreshTokenUsed = errors.New("refresh token zaten kullanılmış")
+
+// RotateRefreshToken, eski token'ı kullanılmış olarak işaretler ve yeni token'ı aynı
+// işlemde kaydeder. Token daha önce kullanılmışsa false döner; böylece eşzamanlı iki yenileme
+// isteğinden yalnızca biri kazanır. Yeni token kaydedilemezse eski token kullanılmamış kalır
+// ve istemci aynı token'la tekrar deneyebilir.
+func (r *AuthRepository) RotateRefreshToken(usedID uint, next *model.RefreshToken) (bool, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.RefreshToken{}).
+			Where("id = ? AND used_at IS NULL", usedID).
+			Update("used_at", time.Now())
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != 1 {
+			return errRefreshTokenUsed
+		}
+		return tx.Create(next).Error
+	})
+	if errors.Is(err, errRefreshTokenUsed) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// CreateUserToken, aynı amaçla daha önce üretilmiş kullanılmamış token'ları
+// geçersiz kılarak yeni token'ı kaydeder
+func (r *AuthRepository) CreateUserToken(token *model.UserToken) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("user_id = ? AND purpose = ? AND used_at IS NULL", token.UserID, token.Purpose).
+			Delete(&model.UserToken{}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(token).Error
+	})
+}
+
+func (r *AuthRepository) GetUserTokenByHash(hash, purpose string) (*model.UserToken, error) {
+	var token model.UserToken
+	err := r.db.Where("token_hash = ? AND purpose = ?", hash, purpose).First(&token).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.New("token bulunamadı")
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+// MarkUserTokenUsed, token'ı kullanılmış olarak işaretler; token zaten
+// kullanılmışsa false döner
+func (r *AuthRepository) MarkUserTokenUsed(id uint) (bool, error) {
+	result := r.db.Model(&model.UserToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// SetPendingTOTPSecret, kullanıcı onaylayana kadar 2FA'yı etkinleştirmeden secret'ı kaydeder
+func (r *AuthRepository) SetPendingTOTPSecret(userID uint, secret string) error {
+	return r.db.Model(&model.User{}).Where("id = ?", userID).
+		Updates(map[string]interface{}{"totp_secret": secret, "totp_enabled": false}).Error
+}
+
+// EnableTOTP, 2FA'yı etkinleştirir ve eski kurtarma kodlarını yenileriyle değiştirir
+func (r *AuthRepository) EnableTOTP(userID uint, codes []model.RecoveryCode) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("user_id = ?", userID).Delete(&model.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&codes).Error; err != nil {
+			return err
+		}
+		return tx.Model(&model.User{}).Where("id = ?", userID).Update("totp_enabled", true).Error
+	})
+}
+
+func (r *AuthRepository) DisableTOTP(userID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("user_id = ?", userID).Delete(&model.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+		return tx.Model(&model.User{}).Where("id = ?", userID).
+			Updates(map[string]interface{}{"totp_secret": "", "totp_enabled": false}).Error
+	})
+}
+
+// UseRecoveryCode, kullanılmamış bir kurtarma kodunu tüketir; kod geçersizse false döner
+func (r *AuthRepository) UseRecoveryCode(userID uint, codeHash string) (bool, error) {
+	result := r.db.Model(&model.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// MarkTOTPStepUsed, aynı TOTP kodunun geçerlilik süresi içinde ikinci kez kullanılmasını engeller
+func (r *AuthRepository) MarkTOTPStepUsed(userID uint, step int64) (bool, error) {
+	ctx := context.Background()
+	return r.redis.SetNX(ctx, fmt.Sprintf("totp_used:%d:%d", userID, step), true, 2*time.Minute).Result()
+}
+
+// incrementWithTTLScript, sayacı artırır ve ilk artışta süresini ayarlar. İkisi tek komutta
+// çalıştığından süresiz kalan bir sayaç kullanıcıyı kalıcı olarak kilitleyemez.
+var incrementWithTTLScript = redis.NewScript(`
+local count = redis.call('INCR', KEYS[1])
+if count == 1 or redis.call('PTTL', KEYS[1]) < 0 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+return count
+`)
+
+// IncrementTwoFactorAttempts, ikinci adım denemelerini sayar; kaba kuvvet denemelerini sınırlamak için kullanılır
+func (r *AuthRepository) IncrementTwoFactorAttempts(userID uint, window time.Duration) (int64, error) {
+	ctx := context.Background()
+	key := fmt.Sprintf("2fa_attempts:%d", userID)
+	return incrementWithTTLScript.Run(ctx, r.redis, []string{key}, window.Milliseconds()).Int64()
+}
+
+// ResetTwoFactorAttempts, başarılı bir girişten sonra deneme sayacını sıfırlar
+func (r *AuthRepository) ResetTwoFactorAttempts(userID uint) error {
+	ctx := context.Background()
+	return r.redis.Del(ctx, fmt.Sprintf("2fa_attempts:%d", userID)).Err()
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"errors"
+	"shortcast/internal/model"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type BlobRepository struct {
+	db *gorm.DB
+}
+
+func NewBlobRepository(db *gorm.DB) *BlobRepository {
+	return &BlobRepository{db: db}
+}
+
+// AcquireBlob, dosyanın referans sayısını artırır; kayıt yoksa oluşturur. Dönen kayıttaki
+// Stored alanı nesnenin daha önce yüklenip yüklenmediğini belirtir.
+func (r *BlobRepository) AcquireBlob(key string, size int64) (*model.Blob, error) {
+	var blob model.Blob
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "key"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"ref_count":  gorm.Expr("blobs.ref_count + 1"),
+				"updated_at": time.Now(),
+			}),
+		}).Create(&model.Blob{Key: key, Size: size, RefCount: 1}).Error
+		if err != nil {
+			return err
+		}
+		return tx.First(&blob, "key = ?", key).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &blob, nil
+}
+
+func (r *BlobRepository) GetBlob(key string) (*model.Blob, error) {
+	var blob model.Blob
+	err := r.db.First(&blob, "key = ?", key).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("dosya kaydı bulunamadı")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &blob, nil
+}
+
+// MarkBlobStored, nesnenin depoya yüklendiğini kaydeder
+func (r *BlobRepository) MarkBlobStored(key string) error {
+	return r.db.Model(&model.Blob{}).Where("key = ?", key).Update("stored", true).Error
+}
+
+// ReleaseBlob, dosyanın referans sayısını azaltır ve son referans bırakıldıysa true döner.
+// Kaydı olmayan (hiç referans alınmamış ya da zaten bırakılmış) dosyalarda hiçbir şey yapılmaz.
+func (r *BlobRepository) ReleaseBlob(key string) (bool, error) {
+	var last bool
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		last, err = releaseBlob(tx, key)
+		return err
+	})
+	return last, err
+}
+
+// releaseBlob, ReleaseBlob'u verilen transaction içinde yapar; böylece referanslar onları
+// tutan kaydın silinmesiyle birlikte bırakılır. Son referansı bırakılan kayıt silinmez,
+// nesnesi PurgeBlob ile silinene kadar ref_count 0 olarak bekler. stored false yapıldığından
+// dosya bu arada yeniden yüklenirse nesne silinmiş sayılıp tekrar yüklenir.
+func releaseBlob(tx *gorm.DB, key string) (bool, error) {
+	if key == "" {
+		return false, nil
+	}
+
+	var blob model.Blob
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&blob, "key = ?", key).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	switch {
+	case blob.RefCount > 1:
+		return false, tx.Model(&blob).Update("ref_count", gorm.Expr("ref_count - 1")).Error
+	case blob.RefCount == 1:
+		err := tx.Model(&blob).Updates(map[string]interface{}{"ref_count": 0, "stored": false}).Error
+		return err == nil, err
+	default:
+		return false, nil
+	}
+}
+
+// PurgeBlob, referansı kalmamış dosyanın nesnesini onDelete ile siler ve varsa kaydını kaldırır.
+// onDelete satır kilitliyken çalıştığından aynı dosyayı eşzamanlı olarak yeniden yükleyen
+// istek, nesne silinene kadar bekler. Dosya yeniden referans aldıysa hiçbir şey yapılmaz.
+// onDelete hata dönerse kayıt silinmek üzere bekler; nesne sahipsiz dosya temizliğinde silinir.
+func (r *BlobRepository) PurgeBlob(key string, onDelete func() error) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var blob model.Blob
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&blob, "key = ?", key).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return onDelete()
+		}
+		if err != nil {
+			return err
+		}
+		if blob.RefCount > 0 {
+			return nil
+		}
+
+		if err := onDelete(); err != nil {
+			return err
+		}
+		return tx.Delete(&blob).Error
+	})
+}
+
+// GetReferencedKeys, anahtarı prefix ile başlayan ve en az bir referansı olan dosyaların anahtarlarıdır
+func (r *BlobRepository) GetReferencedKeys(prefix string) ([]string, error) {
+	var keys []string
+	err := r.db.Model(&model.Blob{}).
+		Where("key LIKE ? AND ref_count > 0", prefix+"%").
+		Pluck("key", &keys).Error
+	return keys, err
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"shortcast/internal/model"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+// OIDCState, sağlayıcıya yönlendirme ile callback arasında saklanan giriş isteği bilgisidir
+type OIDCState struct {
+	Provider     string `json:"provider"`
+	CodeVerifier string `json:"code_verifier"`
+	Nonce        string `json:"nonce"`
+	LinkUserID   uint   `json:"link_user_id,omitempty"`
+	// LinkSessionID, bağlama isteğini başlatan oturumdur; callback'te hâlâ açık olmalıdır
+	LinkSessionID uint `json:"link_session_id,omitempty"`
+}
+
+type IdentityRepository struct {
+	db    *gorm.DB
+	redis *redis.Client
+}
+
+func NewIdentityRepository(db *gorm.DB, redis *redis.Client) *IdentityRepository {
+	return &IdentityRepository{
+		db:    db,
+		redis: redis,
+	}
+}
+
+func (r *IdentityRepository) GetIdentity(provider, subject string) (*model.UserIdentity, error) {
+	var identity model.UserIdentity
+	err := r.db.Where("provider = ? AND subject = ?", provider, subject).First(&identity).Error
+	if err != nil {
+		return nil, err
+	}
+	return &identity, nil
+}
+
+func (r *IdentityRepository) GetIdentitiesByUserID(userID uint) (*[]model.UserIdentity, error) {
+	var identities []model.UserIdentity
+	err := r.db.Where("user_id = ?", userID).Order("created_at ASC").Find(&identities).Error
+	return &identities, err
+}
+
+func (r *IdentityRepository) CountIdentitiesByUserID(userID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.UserIdentity{}).Where("user_id = ?", userID).Count(&count).Error
+	return count, err
+}
+
+func (r *IdentityRepository) CreateIdentity(identity *model.UserIdentity) error {
+	return r.db.Create(identity).Error
+}
+
+// CreateUserWithIdentity, harici sağlayıcıyla ilk kez giriş yapan kullanıcıyı bağlı kimliğiyle birlikte oluşturur
+func (r *IdentityRepository) CreateUserWithIdentity(user *model.User, identity *model.UserIdentity) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+		identity.UserID = user.ID
+		return tx.Create(identity).Error
+	})
+}
+
+func (r *IdentityRepository) DeleteIdentity(id, userID uint) error {
+	result := r.db.Unscoped().Where("id = ? AND user_id = ?", id, userID).Delete(&model.UserIdentity{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("bağlı hesap bulunamadı")
+	}
+	return nil
+}
+
+func (r *IdentityRepository) SaveState(state string, data OIDCState, ttl time.Duration) error {
+	ctx := context.Background()
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return r.redis.Set(ctx, "oidc_state:"+state, payload, ttl).Err()
+}
+
+// ConsumeState, state'i okuyup siler; aynı callback'in ikinci kez kullanılmasını engeller
+func (r *IdentityRepository) ConsumeState(state string) (*OIDCState, error) {
+	ctx := context.Background()
+	payload, err := r.redis.GetDel(ctx, "oidc_state:"+state).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, errors.New("geçersiz veya süresi dolmuş giriş isteği")
+		}
+		return nil, err
+	}
+
+	var data OIDCState
+	if err := json.Unmarshal(payload, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
@@ -1,148 +1,430 @@
-package repository
-
-import (
-	"errors"
-	"fmt"
-	"shortcast/internal/model"
-
-	"gorm.io/gorm"
-)
-
-type PodcastRepository struct {
-	db *gorm.DB
-}
-
-func NewPodcastRepository(db *gorm.DB) *PodcastRepository {
-	return &PodcastRepository{db: db}
-}
-
-func (r *PodcastRepository) SavePodcast(podcast *model.Podcast) error {
-	return r.db.Create(podcast).Error
-}
-
-func (r *PodcastRepository) GetPodcastByID(id uint) (*model.Podcast, error) {
-	var podcast model.Podcast
-	if err := r.db.Preload("User").First(&podcast, id).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return nil, errors.New("podcast bulunamadı")
-		}
-		return nil, err
-	}
-
-	return &podcast, nil
-}
-
-func (r *PodcastRepository) GetPodcastsByUserID(userId uint) (*[]model.Podcast, error) {
-	var podcasts []model.Podcast
-	err := r.db.Preload("User").Where("user_id = ?", userId).Find(&podcasts).Error
-	if err != nil {
-		return nil, fmt.Errorf("veritabanından podcastler alınırken hata: %v", err)
-	}
-
-	if len(podcasts) == 0 {
-		return &[]model.Podcast{}, nil
-	}
-
-	return &podcasts, nil
-}
-
-func (r *PodcastRepository) DiscoverPodcasts(cursor *uint, direction string, limit int) (*[]model.Podcast, error) {
-	var podcasts []model.Podcast
-	query := r.db.Model(&model.Podcast{}).Preload("User")
-
-	if cursor != nil {
-		if direction == "next" {
-			query = query.Where("id > ?", *cursor)
-		} else {
-			query = query.Where("id < ?", *cursor)
-		}
-	}
-
-	if direction == "prev" {
-		query = query.Order("id DESC")
-	} else {
-		query = query.Order("id ASC")
-	}
-
-	err := query.Limit(limit + 1).Find(&podcasts).Error // Bir fazla alıyoruz ki sonraki sayfa var mı bilelim
-	if err != nil {
-		return nil, err
-	}
-
-	return &podcasts, nil
-}
-
-func (r *PodcastRepository) UpdatePodcast(id uint, podcast *model.Podcast) error {
-	result := r.db.Model(&model.Podcast{}).Where("id = ?", id).Updates(podcast)
-	if result.Error != nil {
-		return result.Error
-	}
-	if result.RowsAffected == 0 {
-		return errors.New("podcast bulunamadı")
-	}
-	return nil
-}
-
-func (r *PodcastRepository) DeletePodcast(id uint) error {
-	result := r.db.Delete(&model.Podcast{}, id)
-	if result.Error != nil {
-		return result.Error
-	}
-	if result.RowsAffected == 0 {
-		return errors.New("podcast bulunamadı")
-	}
-	return nil
-}
-
-func (r *PodcastRepository) LikePodcast(podcastID, userID uint) (bool, error) {
-	var like model.Like
-
-	// Önce mevcut like'ı kontrol et
-	result := r.db.Where("podcast_id = ? AND user_id = ?", podcastID, userID).First(&like)
-
-	if result.Error == nil {
-		// Like zaten var, unlike yap (soft delete)
-		if err := r.db.Delete(&like).Error; err != nil {
-			return false, err
-		}
-		return false, nil // false = unliked
-	}
-
-	// Like yok, yeni like ekle
-	newLike := model.Like{
-		PodcastID: podcastID,
-		UserID:    userID,
-	}
-
-	if err := r.db.Create(&newLike).Error; err != nil {
-		return false, err
-	}
-
-	return true, nil // true = liked
-}
-
-func (r *PodcastRepository) GetLikedPodcasts(userID uint) (*[]model.Podcast, error) {
-	var podcasts []model.Podcast
-	err := r.db.Preload("User").
-		Joins("JOIN likes ON likes.podcast_id = podcasts.id").
-		Where("likes.user_id = ? AND likes.deleted_at IS NULL", userID).
-		Find(&podcasts).Error
-	return &podcasts, err
-}
-
-func (r *PodcastRepository) GetPodcastsByCategory(category string) (*[]model.Podcast, error) {
-	var podcasts []model.Podcast
-	err := r.db.Preload("User").Where("category = ?", category).Find(&podcasts).Error
-	return &podcasts, err
-}
-
-func (r *PodcastRepository) AddComment(comment *model.Comment) error {
-	return r.db.Create(comment).Error
-}
-
-func (r *PodcastRepository) GetComments(podcastID uint) (*[]model.Comment, error) {
-	var comments []model.Comment
-	err := r.db.Joins("User").Where("podcast_id = ?", podcastID).
-		Order("created_at desc").Find(&comments).Error
-	return &comments, err
-}
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"shortcast/internal/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type PodcastRepository struct {
+	db *gorm.DB
+}
+
+func NewPodcastRepository(db *gorm.DB) *PodcastRepository {
+	return &PodcastRepository{db: db}
+}
+
+func (r *PodcastRepository) SavePodcast(podcast *model.Podcast) error {
+	return r.db.Create(podcast).Error
+}
+
+func (r *PodcastRepository) GetPodcastByID(id uint) (*model.Podcast, error) {
+	var podcast model.Podcast
+	if err := r.db.Preload("User").First(&podcast, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.New("podcast bulunamadı")
+		}
+		return nil, err
+	}
+
+	return &podcast, nil
+}
+
+// GetPodcastsByUserID, kullanıcının podcastlerini döndürür. onlyReady true ise
+// işlenmekte olan veya işlenemeyen podcastler hariç tutulur.
+func (r *PodcastRepository) GetPodcastsByUserID(userId uint, onlyReady bool) (*[]model.Podcast, error) {
+	var podcasts []model.Podcast
+	query := r.db.Preload("User").Where("user_id = ?", userId)
+	if onlyReady {
+		query = query.Where("status = ?", model.PodcastStatusReady)
+	}
+	err := query.Find(&podcasts).Error
+	if err != nil {
+		return nil, fmt.Errorf("veritabanından podcastler alınırken hata: %v", err)
+	}
+
+	if len(podcasts) == 0 {
+		return &[]model.Podcast{}, nil
+	}
+
+	return &podcasts, nil
+}
+
+func (r *PodcastRepository) DiscoverPodcasts(viewerID uint, cursor *uint, direction string, limit int) (*[]model.Podcast, error) {
+	var podcasts []model.Podcast
+	query := r.db.Model(&model.Podcast{}).Preload("User").Scopes(r.visibleTo(viewerID, true))
+
+	if cursor != nil {
+		if direction == "next" {
+			query = query.Where("id > ?", *cursor)
+		} else {
+			query = query.Where("id < ?", *cursor)
+		}
+	}
+
+	if direction == "prev" {
+		query = query.Order("id DESC")
+	} else {
+		query = query.Order("id ASC")
+	}
+
+	err := query.Limit(limit + 1).Find(&podcasts).Error // Bir fazla alıyoruz ki sonraki sayfa var mı bilelim
+	if err != nil {
+		return nil, err
+	}
+
+	return &podcasts, nil
+}
+
+// GetFeed, kullanıcının takip ettiği yaratıcıların podcastlerini en yeniden eskiye döndürür.
+// cursor verilirse yalnızca ondan eski podcastler getirilir.
+func (r *PodcastRepository) GetFeed(userID uint, cursor *uint, limit int) (*[]model.Podcast, error) {
+	var podcasts []model.Podcast
+
+	following := r.db.Model(&model.Follow{}).Select("following_id").Where("follower_id = ?", userID)
+	query := r.db.Model(&model.Podcast{}).Preload("User").
+		Where("user_id IN (?)", following).
+		Scopes(r.visibleTo(userID, true))
+
+	if cursor != nil {
+		query = query.Where("id < ?", *cursor)
+	}
+
+	err := query.Order("id DESC").Limit(limit + 1).Find(&podcasts).Error // Bir fazla alıyoruz ki sonraki sayfa var mı bilelim
+	if err != nil {
+		return nil, err
+	}
+
+	return &podcasts, nil
+}
+
+func (r *PodcastRepository) UpdatePodcast(id uint, podcast *model.Podcast) error {
+	result := r.db.Model(&model.Podcast{}).Where("id = ?", id).Updates(podcast)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("podcast bulunamadı")
+	}
+	return nil
+}
+
+// GetPodcastsWithoutAudioMetadata, ses bilgileri henüz doldurulmamış podcastleri ID sırasıyla döndürür
+func (r *PodcastRepository) GetPodcastsWithoutAudioMetadata(afterID uint, limit int) (*[]model.Podcast, error) {
+	var podcasts []model.Podcast
+	err := r.db.Where("duration_ms = 0 AND id > ?", afterID).
+		Order("id ASC").
+		Limit(limit).
+		Find(&podcasts).Error
+	if err != nil {
+		return nil, err
+	}
+	return &podcasts, nil
+}
+
+// GetMediaKeys, podcastlerin depodaki dosya anahtarlarını ID sırasıyla döndürür. Silinmiş
+// (soft delete) podcastler dahil edilmez; dosyaları sahipsiz sayılır.
+func (r *PodcastRepository) GetMediaKeys(afterID uint, limit int) (*[]model.Podcast, error) {
+	var podcasts []model.Podcast
+	err := r.db.Select("id", "audio_key", "cover_key", "has_cover_renditions", "waveform_key", "normalized_audio_key").
+		Where("id > ?", afterID).
+		Order("id ASC").
+		Limit(limit).
+		Find(&podcasts).Error
+	if err != nil {
+		return nil, err
+	}
+	return &podcasts, nil
+}
+
+// UpdateAudioMetadata, yalnızca ses dosyasından okunan alanları günceller
+func (r *PodcastRepository) UpdateAudioMetadata(podcast *model.Podcast) error {
+	return r.db.Model(&model.Podcast{}).Where("id = ?", podcast.ID).Updates(map[string]interface{}{
+		"mime_type":   podcast.MimeType,
+		"duration_ms": podcast.DurationMs,
+		"bitrate":     podcast.Bitrate,
+		"sample_rate": podcast.SampleRate,
+		"channels":    podcast.Channels,
+		"size_bytes":  podcast.SizeBytes,
+		"codec":       podcast.Codec,
+	}).Error
+}
+
+// MarkPodcastReady, işlenmesi tamamlanan podcast'i arka planda üretilen alanlarla birlikte yayına alır
+func (r *PodcastRepository) MarkPodcastReady(podcast *model.Podcast) error {
+	return r.updateStatus(podcast.ID, map[string]interface{}{
+		"status":               model.PodcastStatusReady,
+		"waveform_key":         podcast.WaveformKey,
+		"has_cover_renditions": podcast.HasCoverRenditions,
+		"loudness_lufs":        podcast.LoudnessLUFS,
+		"loudness_peak":        podcast.LoudnessPeak,
+		"normalized_audio_key": podcast.NormalizedAudioKey,
+		"hls_key":              podcast.HLSKey,
+	})
+}
+
+// MarkPodcastFailed, işlenemeyen podcast'i başarısız olarak işaretler
+func (r *PodcastRepository) MarkPodcastFailed(id uint) error {
+	return r.updateStatus(id, map[string]interface{}{
+		"status": model.PodcastStatusFailed,
+	})
+}
+
+func (r *PodcastRepository) updateStatus(id uint, updates map[string]interface{}) error {
+	result := r.db.Model(&model.Podcast{}).Where("id = ?", id).Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("podcast bulunamadı")
+	}
+	return nil
+}
+
+// DeletePodcast, podcast'i siler ve aynı transaction içinde keys ile verilen dosyaların
+// referanslarını bırakır. Son referansı bırakılan anahtarları döndürür; bu dosyalar
+// transaction tamamlandıktan sonra silinmelidir. Podcast zaten silinmişse referanslara
+// dokunulmaz, böylece yeniden denenen bir silme işlemi referansları iki kez bırakmaz.
+func (r *PodcastRepository) DeletePodcast(id uint, keys ...string) ([]string, error) {
+	var released []string
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&model.Podcast{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("podcast bulunamadı")
+		}
+
+		for _, key := range keys {
+			last, err := releaseBlob(tx, key)
+			if err != nil {
+				return err
+			}
+			if last {
+				released = append(released, key)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return released, nil
+}
+
+func (r *PodcastRepository) LikePodcast(podcastID, userID uint) (bool, error) {
+	var like model.Like
+
+	// Önce mevcut like'ı kontrol et
+	result := r.db.Where("podcast_id = ? AND user_id = ?", podcastID, userID).First(&like)
+
+	if result.Error == nil {
+		// Like zaten var, unlike yap (soft delete)
+		if err := r.db.Delete(&like).Error; err != nil {
+			return false, err
+		}
+		return false, nil // false = unliked
+	}
+
+	// Like yok, yeni like ekle
+	newLike := model.Like{
+		PodcastID: podcastID,
+		UserID:    userID,
+	}
+
+	if err := r.db.Create(&newLike).Error; err != nil {
+		return false, err
+	}
+
+	return true, nil // true = liked
+}
+
+// GetLikedPodcasts, kullanıcının beğendiği podcastlerden görebildiklerini döndürür
+func (r *PodcastRepository) GetLikedPodcasts(userID uint) (*[]model.Podcast, error) {
+	var podcasts []model.Podcast
+	err := r.db.Preload("User").Scopes(r.visibleTo(userID, false)).
+		Joins("JOIN likes ON likes.podcast_id = podcasts.id").
+		Where("likes.user_id = ? AND likes.deleted_at IS NULL", userID).
+		Find(&podcasts).Error
+	return &podcasts, err
+}
+
+func (r *PodcastRepository) GetPodcastsByCategory(viewerID uint, category string) (*[]model.Podcast, error) {
+	var podcasts []model.Podcast
+	err := r.db.Preload("User").Scopes(r.visibleTo(viewerID, false)).Where("category = ?", category).Find(&podcasts).Error
+	return &podcasts, err
+}
+
+func (r *PodcastRepository) AddComment(comment *model.Comment) error {
+	return r.db.Create(comment).Error
+}
+
+func (r *PodcastRepository) GetComments(podcastID uint) (*[]model.Comment, error) {
+	var comments []model.Comment
+	err := r.db.Joins("User").Where("podcast_id = ?", podcastID).
+		Order("created_at desc").Find(&comments).Error
+	return &comments, err
+}
+
+func (r *PodcastRepository) GetCommentByID(id uint) (*model.Comment, error) {
+	var comment model.Comment
+	if err := r.db.First(&comment, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.New("yorum bulunamadı")
+		}
+		return nil, err
+	}
+	return &comment, nil
+}
+
+func (r *PodcastRepository) DeleteComment(id uint) error {
+	result := r.db.Delete(&model.Comment{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("yorum bulunamadı")
+	}
+	return nil
+}
+
+// visibleTo, henüz hazır olmayan podcastleri ve izleyiciyi engelleyen veya izleyicinin
+// engellediği kullanıcıların podcastlerini sonuçlardan çıkarır. hideMuted true ise
+// izleyicinin sessize aldığı kullanıcılar da çıkarılır.
+func (r *PodcastRepository) visibleTo(viewerID uint, hideMuted bool) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		db = db.Where("podcasts.status = ?", model.PodcastStatusReady)
+
+		blockedBy := r.db.Model(&model.Block{}).Select("blocker_id").Where("blocked_id = ?", viewerID)
+		blocked := r.db.Model(&model.Block{}).Select("blocked_id").Where("blocker_id = ?", viewerID)
+		db = db.Where("podcasts.user_id NOT IN (?) AND podcasts.user_id NOT IN (?)", blockedBy, blocked)
+
+		if hideMuted {
+			muted := r.db.Model(&model.Mute{}).Select("muted_id").Where("muter_id = ?", viewerID)
+			db = db.Where("podcasts.user_id NOT IN (?)", muted)
+		}
+		return db
+	}
+}
+
+func (r *PodcastRepository) SavePendingUpload(upload *model.PendingUpload) error {
+	return r.db.Create(upload).Error
+}
+
+func (r *PodcastRepository) GetPendingUpload(id uint) (*model.PendingUpload, error) {
+	var upload model.PendingUpload
+	if err := r.db.First(&upload, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.New("yükleme bulunamadı")
+		}
+		return nil, err
+	}
+	return &upload, nil
+}
+
+// ClaimPendingUpload, yüklemeyi tamamlanıyor olarak işaretler. Yükleme başka bir istek
+// tarafından tamamlanıyorsa false döner.
+func (r *PodcastRepository) ClaimPendingUpload(id uint) (bool, error) {
+	result := r.db.Model(&model.PendingUpload{}).
+		Where("id = ? AND claimed_at IS NULL", id).
+		Update("claimed_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// ReleasePendingUpload, tamamlanamayan yüklemenin yeniden denenebilmesini sağlar
+func (r *PodcastRepository) ReleasePendingUpload(id uint) error {
+	return r.db.Model(&model.PendingUpload{}).Where("id = ?", id).Update("claimed_at", nil).Error
+}
+
+func (r *PodcastRepository) DeletePendingUpload(id uint) error {
+	return r.db.Unscoped().Delete(&model.PendingUpload{}, id).Error
+}
+
+// GetExpiredPendingUploads, süresi dolmuş yüklemeleri döndürür
+func (r *PodcastRepository) GetExpiredPendingUploads(now time.Time, limit int) (*[]model.PendingUpload, error) {
+	var uploads []model.PendingUpload
+	err := r.db.Where("expires_at < ?", now).Order("id ASC").Limit(limit).Find(&uploads).Error
+	if err != nil {
+		return nil, err
+	}
+	return &uploads, nil
+}
+
+// GetPendingUploadKeys, tamamlanmamış yüklemelerin uploads/ altındaki geçici dosya anahtarlarıdır
+func (r *PodcastRepository) GetPendingUploadKeys() ([]string, error) {
+	var uploads []model.PendingUpload
+	if err := r.db.Select("audio_key", "cover_key").Find(&uploads).Error; err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(uploads)*2)
+	for _, upload := range uploads {
+		keys = append(keys, upload.AudioKey, upload.CoverKey)
+	}
+	return keys, nil
+}
+
+func (r *PodcastRepository) SaveTusUpload(upload *model.TusUpload) error {
+	return r.db.Create(upload).Error
+}
+
+func (r *PodcastRepository) GetTusUpload(id string) (*model.TusUpload, error) {
+	var upload model.TusUpload
+	if err := r.db.Where("id = ?", id).First(&upload).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, errors.New("yükleme bulunamadı")
+		}
+		return nil, err
+	}
+	return &upload, nil
+}
+
+// UpdateTusUpload, yüklemenin konumunu, depolama durumunu ve podcast bağlantısını kaydeder
+func (r *PodcastRepository) UpdateTusUpload(upload *model.TusUpload) error {
+	return r.db.Model(upload).Select("upload_offset", "multipart_id", "part_count", "partial_size", "podcast_id").Updates(upload).Error
+}
+
+// LockTusUpload, yüklemeyi until zamanına kadar kilitler. Yükleme başka bir istek
+// tarafından kilitlenmişse false döner.
+func (r *PodcastRepository) LockTusUpload(id string, until time.Time) (bool, error) {
+	result := r.db.Model(&model.TusUpload{}).
+		Where("id = ? AND (locked_until IS NULL OR locked_until < ?)", id, time.Now()).
+		Update("locked_until", until)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *PodcastRepository) UnlockTusUpload(id string) error {
+	return r.db.Model(&model.TusUpload{}).Where("id = ?", id).Update("locked_until", nil).Error
+}
+
+func (r *PodcastRepository) DeleteTusUpload(id string) error {
+	return r.db.Where("id = ?", id).Delete(&model.TusUpload{}).Error
+}
+
+// GetExpiredTusUploads, süresi dolmuş tus yüklemelerini döndürür
+func (r *PodcastRepository) GetExpiredTusUploads(now time.Time, limit int) (*[]model.TusUpload, error) {
+	var uploads []model.TusUpload
+	err := r.db.Where("expires_at < ?", now).Order("expires_at ASC").Limit(limit).Find(&uploads).Error
+	if err != nil {
+		return nil, err
+	}
+	return &uploads, nil
+}
+
+// ExtendTusUpload, yüklemenin bitiş zamanını expiresAt'e kadar uzatır; daha geç bir
+// bitiş zamanı varsa değiştirmez
+func (r *PodcastRepository) ExtendTusUpload(id string, expiresAt time.Time) error {
+	return r.db.Model(&model.TusUpload{}).
+		Where("id = ? AND expires_at < ?", id, expiresAt).
+		Update("expires_at", expiresAt).Error
+}
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"shortcast/internal/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SocialRepository struct {
+	db *gorm.DB
+}
+
+func NewSocialRepository(db *gorm.DB) *SocialRepository {
+	return &SocialRepository{db: db}
+}
+
+// Follow, takip ilişkisini oluşturur; zaten takip ediliyorsa bir şey yapmaz
+func (r *SocialRepository) Follow(followerID, followingID uint) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&model.Follow{
+		FollowerID:  followerID,
+		FollowingID: followingID,
+	}).Error
+}
+
+// Unfollow, takip ilişkisini kalıcı olarak siler ki aynı kullanıcı tekrar takip edilebilsin
+func (r *SocialRepository) Unfollow(followerID, followingID uint) error {
+	return r.db.Unscoped().
+		Where("follower_id = ? AND following_id = ?", followerID, followingID).
+		Delete(&model.Follow{}).Error
+}
+
+func (r *SocialRepository) IsFollowing(followerID, followingID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&model.Follow{}).
+		Where("follower_id = ? AND following_id = ?", followerID, followingID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// GetFollowers, kullanıcıyı takip edenleri en yeni takipçi önce olacak şekilde sayfalı döndürür
+func (r *SocialRepository) GetFollowers(userID uint, offset, limit int) (*[]model.User, int64, error) {
+	return r.listRelatedUsers("follows", "follows.follower_id", "follows.following_id", userID, offset, limit)
+}
+
+// GetFollowing, kullanıcının takip ettiklerini en son takip edilen önce olacak şekilde sayfalı döndürür
+func (r *SocialRepository) GetFollowing(userID uint, offset, limit int) (*[]model.User, int64, error) {
+	return r.listRelatedUsers("follows", "follows.following_id", "follows.follower_id", userID, offset, limit)
+}
+
+func (r *SocialRepository) CountFollowers(userID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.Follow{}).Where("following_id = ?", userID).Count(&count).Error
+	return count, err
+}
+
+func (r *SocialRepository) CountFollowing(userID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.Follow{}).Where("follower_id = ?", userID).Count(&count).Error
+	return count, err
+}
+
+// Block, kullanıcıyı engeller ve iki yöndeki takip ilişkilerini kaldırır
+func (r *SocialRepository) Block(blockerID, blockedID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&model.Block{
+			BlockerID: blockerID,
+			BlockedID: blockedID,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Unscoped().
+			Where("(follower_id = ? AND following_id = ?) OR (follower_id = ? AND following_id = ?)", blockerID, blockedID, blockedID, blockerID).
+			Delete(&model.Follow{}).Error
+	})
+}
+
+func (r *SocialRepository) Unblock(blockerID, blockedID uint) error {
+	return r.db.Unscoped().
+		Where("blocker_id = ? AND blocked_id = ?", blockerID, blockedID).
+		Delete(&model.Block{}).Error
+}
+
+// IsBlockedBetween, iki kullanıcıdan birinin diğerini engelleyip engellemediğini döndürür
+func (r *SocialRepository) IsBlockedBetween(userID, otherID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&model.Block{}).
+		Where("(blocker_id = ? AND blocked_id = ?) OR (blocker_id = ? AND blocked_id = ?)", userID, otherID, otherID, userID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// HasBlocked, blockerID kullanıcısının blockedID kullanıcısını engelleyip engellemediğini döndürür
+func (r *SocialRepository) HasBlocked(blockerID, blockedID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&model.Block{}).
+		Where("blocker_id = ? AND blocked_id = ?", blockerID, blockedID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *SocialRepository) GetBlockedUsers(userID uint, offset, limit int) (*[]model.User, int64, error) {
+	return r.listRelatedUsers("blocks", "blocks.blocked_id", "blocks.blocker_id", userID, offset, limit)
+}
+
+func (r *SocialRepository) Mute(muterID, mutedID uint) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&model.Mute{
+		MuterID: muterID,
+		MutedID: mutedID,
+	}).Error
+}
+
+func (r *SocialRepository) Unmute(muterID, mutedID uint) error {
+	return r.db.Unscoped().
+		Where("muter_id = ? AND muted_id = ?", muterID, mutedID).
+		Delete(&model.Mute{}).Error
+}
+
+func (r *SocialRepository) GetMutedUsers(userID uint, offset, limit int) (*[]model.User, int64, error) {
+	return r.listRelatedUsers("mutes", "mutes.muted_id", "mutes.muter_id", userID, offset, limit)
+}
+
+// listRelatedUsers, verilen ilişki tablosu üzerinden kullanıcıya bağlı kullanıcıları en yeni ilişki önce olacak şekilde döndürür
+func (r *SocialRepository) listRelatedUsers(table, joinColumn, filterColumn string, userID uint, offset, limit int) (*[]model.User, int64, error) {
+	var users []model.User
+	var total int64
+
+	query := func() *gorm.DB {
+		return r.db.Model(&model.User{}).
+			Joins("JOIN "+table+" ON "+joinColumn+" = users.id AND "+table+".deleted_at IS NULL").
+			Where(filterColumn+" = ?", userID)
+	}
+
+	if err := query().Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query().Order(table + ".created_at DESC").Offset(offset).Limit(limit).Find(&users).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return &users, total, nil
+}
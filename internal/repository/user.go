@@ -3,6 +3,7 @@ package repository
 import (
 	"errors"
 	"shortcast/internal/model"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -45,3 +46,130 @@ func (r *UserRepository) GetUserByID(id uint) (*model.User, error) {
 
 	return &user, nil
 }
+
+func (r *UserRepository) UpdatePassword(id uint, hashedPassword string) error {
+	return r.db.Model(&model.User{}).Where("id = ?", id).Update("password", hashedPassword).Error
+}
+
+func (r *UserRepository) MarkEmailVerified(id uint) error {
+	return r.db.Model(&model.User{}).
+		Where("id = ? AND email_verified_at IS NULL", id).
+		Update("email_verified_at", time.Now()).Error
+}
+
+// ListUsers, kullanıcıları ID sırasına göre sayfalı olarak döndürür
+func (r *UserRepository) ListUsers(offset, limit int) (*[]model.User, int64, error) {
+	var users []model.User
+	var total int64
+
+	if err := r.db.Model(&model.User{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := r.db.Order("id ASC").Offset(offset).Limit(limit).Find(&users).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return &users, total, nil
+}
+
+func (r *UserRepository) UpdateRole(id uint, role string) error {
+	result := r.db.Model(&model.User{}).Where("id = ?", id).Update("role", role)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("kullanıcı bulunamadı")
+	}
+	return nil
+}
+
+// UpdateProfile, verilen alanları günceller. E-posta değiştiyse yeni adres doğrulanana kadar
+// hesap doğrulanmamış sayılır.
+func (r *UserRepository) UpdateProfile(id uint, updates map[string]interface{}) error {
+	if _, ok := updates["email"]; ok {
+		updates["email_verified_at"] = nil
+	}
+	return r.db.Model(&model.User{}).Where("id = ?", id).Updates(updates).Error
+}
+
+func (r *UserRepository) UpdateAvatarKey(id uint, key string) error {
+	return r.db.Model(&model.User{}).Where("id = ?", id).Update("avatar_key", key).Error
+}
+
+// CountPodcasts, kullanıcının yayındaki podcast sayısını döndürür
+func (r *UserRepository) CountPodcasts(id uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.Podcast{}).
+		Where("user_id = ? AND status = ?", id, model.PodcastStatusReady).
+		Count(&count).Error
+	return count, err
+}
+
+// CountLikesReceived, kullanıcının podcastlerine gelen toplam beğeni sayısını döndürür
+func (r *UserRepository) CountLikesReceived(id uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&model.Like{}).
+		Joins("JOIN podcasts ON podcasts.id = likes.podcast_id AND podcasts.deleted_at IS NULL").
+		Where("podcasts.user_id = ?", id).
+		Count(&count).Error
+	return count, err
+}
+
+// GetAvatarKeys, profil fotoğrafı olan kullanıcıların avatar anahtarlarıdır
+func (r *UserRepository) GetAvatarKeys() ([]string, error) {
+	var keys []string
+	err := r.db.Model(&model.User{}).
+		Where("avatar_key <> ''").
+		Pluck("avatar_key", &keys).Error
+	return keys, err
+}
+
+// DeleteAccount, kullanıcıyı podcastleri, beğenileri, yorumları ve API anahtarlarıyla birlikte
+// kalıcı olarak siler. Oturumlar ve bağlı hesaplar gibi kullanıcıya bağlı diğer kayıtlar
+// veritabanındaki ON DELETE CASCADE kısıtlarıyla silinir. keys ile verilen dosyaların
+// referansları aynı transaction içinde bırakılır; son referansı bırakılan anahtarlar döner.
+func (r *UserRepository) DeleteAccount(id uint, keys ...string) ([]string, error) {
+	var released []string
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		userPodcasts := tx.Unscoped().Model(&model.Podcast{}).Select("id").Where("user_id = ?", id)
+
+		if err := tx.Unscoped().Where("user_id = ? OR podcast_id IN (?)", id, userPodcasts).Delete(&model.Like{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("user_id = ? OR podcast_id IN (?)", id, userPodcasts).Delete(&model.Comment{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("user_id = ?", id).Delete(&model.Podcast{}).Error; err != nil {
+			return err
+		}
+		// API anahtarları kullanıcı silindikten sonra kullanılamasın diye açıkça silinir
+		if err := tx.Unscoped().Where("user_id = ?", id).Delete(&model.APIKey{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Unscoped().Delete(&model.User{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.New("kullanıcı bulunamadı")
+		}
+
+		for _, key := range keys {
+			last, err := releaseBlob(tx, key)
+			if err != nil {
+				return err
+			}
+			if last {
+				released = append(released, key)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return released, nil
+}
@@ -2,6 +2,7 @@ package router
 
 import (
 	"shortcast/internal/container"
+	"shortcast/internal/model"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
@@ -12,13 +13,16 @@ func SetupAPIRoutes(app *fiber.App, cont *container.Container) {
 
 	app.Use(cors.New(cors.Config{
 		AllowOrigins:  "*",
-		AllowMethods:  "GET, POST, PUT, DELETE, OPTIONS",
-		AllowHeaders:  "Origin, Content-Type, Accept, Authorization",
-		ExposeHeaders: "Content-Length",
+		AllowMethods:  "GET, HEAD, POST, PUT, PATCH, DELETE, OPTIONS",
+		AllowHeaders:  "Origin, Content-Type, Accept, Authorization, X-API-Key, Tus-Resumable, Upload-Length, Upload-Metadata, Upload-Offset, Upload-Checksum, Upload-Defer-Length",
+		ExposeHeaders: "Content-Length, Location, Tus-Resumable, Tus-Version, Tus-Extension, Tus-Max-Size, Tus-Checksum-Algorithm, Upload-Offset, Upload-Length, Upload-Expires, Podcast-Id",
 		// AllowCredentials: true,
 		MaxAge: 3000,
 	}))
 
+	// Diğer servislerin token doğrulaması için public anahtarlar
+	app.Get("/.well-known/jwks.json", cont.AuthHandler.JWKS)
+
 	// API routes
 	api := app.Group("/api")
 
@@ -28,31 +32,114 @@ func SetupAPIRoutes(app *fiber.App, cont *container.Container) {
 
 	auth := api.Group("/auth")
 	auth.Post("/login", cont.AuthMiddleware.GuestMiddleware(), cont.AuthHandler.Login)
+	auth.Post("/login/2fa", cont.AuthMiddleware.GuestMiddleware(), cont.AuthHandler.LoginTwoFactor)
 	auth.Post("/register", cont.AuthMiddleware.GuestMiddleware(), cont.AuthHandler.Register)
 	auth.Post("/logout", cont.AuthMiddleware.JWTMiddleware(), cont.AuthHandler.Logout)
+	auth.Post("/refresh", cont.AuthHandler.Refresh)
+	auth.Get("/verify", cont.AuthHandler.VerifyEmail)
+	auth.Post("/verify", cont.AuthHandler.VerifyEmail)
+	auth.Post("/verify/resend", cont.AuthMiddleware.GuestMiddleware(), cont.AuthHandler.ResendVerification)
+	auth.Post("/forgot-password", cont.AuthMiddleware.GuestMiddleware(), cont.AuthHandler.ForgotPassword)
+	auth.Post("/reset-password", cont.AuthMiddleware.GuestMiddleware(), cont.AuthHandler.ResetPassword)
+	auth.Post("/2fa/setup", cont.AuthMiddleware.JWTMiddleware(), cont.AuthHandler.SetupTOTP)
+	auth.Post("/2fa/confirm", cont.AuthMiddleware.JWTMiddleware(), cont.AuthHandler.ConfirmTOTP)
+	auth.Post("/2fa/disable", cont.AuthMiddleware.JWTMiddleware(), cont.AuthHandler.DisableTOTP)
+	auth.Get("/sessions", cont.AuthMiddleware.JWTMiddleware(), cont.AuthHandler.GetSessions)
+	auth.Delete("/sessions/:id", cont.AuthMiddleware.JWTMiddleware(), cont.AuthHandler.RevokeSession)
+	auth.Get("/oidc/providers", cont.OIDCHandler.GetProviders)
+	auth.Get("/oidc/:provider/login", cont.AuthMiddleware.GuestMiddleware(), cont.OIDCHandler.Login)
+	auth.Get("/oidc/:provider/callback", cont.OIDCHandler.Callback)
+	auth.Post("/oidc/:provider/link", cont.AuthMiddleware.JWTMiddleware(), cont.OIDCHandler.Link)
+	auth.Get("/identities", cont.AuthMiddleware.JWTMiddleware(), cont.OIDCHandler.GetIdentities)
+	auth.Delete("/identities/:id", cont.AuthMiddleware.JWTMiddleware(), cont.OIDCHandler.Unlink)
+
+	apiKeys := api.Group("/api-keys")
+	apiKeys.Use(cont.AuthMiddleware.JWTMiddleware())
+	apiKeys.Get("/", cont.APIKeyHandler.GetAPIKeys)
+	apiKeys.Post("/", cont.APIKeyHandler.CreateAPIKey)
+	apiKeys.Delete("/:id", cont.APIKeyHandler.RevokeAPIKey)
+
+	// Kullanıcı ve podcast route'ları JWT'nin yanında API anahtarlarını da kabul eder;
+	// API anahtarının kapsamı her route'ta ayrıca kontrol edilir
+	readPodcasts := cont.AuthMiddleware.RequireScope(model.ScopePodcastsRead)
+	writePodcasts := cont.AuthMiddleware.RequireScope(model.ScopePodcastsWrite)
+	writeComments := cont.AuthMiddleware.RequireScope(model.ScopeCommentsWrite)
+	readUsers := cont.AuthMiddleware.RequireScope(model.ScopeUsersRead)
 
 	user := api.Group("/users")
-	user.Use(cont.AuthMiddleware.JWTMiddleware())
-	user.Get("/:id", cont.UserHandler.GetByID)
-	user.Get("/:user_id/podcasts", cont.PodcastHandler.GetUserPodcasts)
+	user.Use(cont.AuthMiddleware.Authenticate())
+
+	// /me route'ları /:id'den önce tanımlanmalı
+	user.Get("/me", readUsers, cont.UserHandler.GetMe)
+	user.Patch("/me", cont.AuthMiddleware.RequireSession(), cont.UserHandler.UpdateMe)
+	user.Put("/me/password", cont.AuthMiddleware.RequireSession(), cont.UserHandler.ChangePassword)
+	user.Delete("/me", cont.AuthMiddleware.RequireSession(), cont.UserHandler.DeleteMe)
+	user.Put("/me/avatar", cont.AuthMiddleware.RequireSession(), cont.UserHandler.UpdateAvatar)
+	user.Delete("/me/avatar", cont.AuthMiddleware.RequireSession(), cont.UserHandler.DeleteAvatar)
+	user.Get("/me/blocks", cont.AuthMiddleware.RequireSession(), cont.SocialHandler.GetBlockedUsers)
+	user.Get("/me/mutes", cont.AuthMiddleware.RequireSession(), cont.SocialHandler.GetMutedUsers)
+
+	user.Get("/:id", readUsers, cont.UserHandler.GetByID)
+	user.Get("/:id/profile", readUsers, cont.UserHandler.GetProfile)
+	user.Get("/:id/followers", readUsers, cont.SocialHandler.GetFollowers)
+	user.Get("/:id/following", readUsers, cont.SocialHandler.GetFollowing)
+	user.Post("/:id/follow", cont.AuthMiddleware.RequireSession(), cont.SocialHandler.Follow)
+	user.Delete("/:id/follow", cont.AuthMiddleware.RequireSession(), cont.SocialHandler.Unfollow)
+	user.Post("/:id/block", cont.AuthMiddleware.RequireSession(), cont.SocialHandler.Block)
+	user.Delete("/:id/block", cont.AuthMiddleware.RequireSession(), cont.SocialHandler.Unblock)
+	user.Post("/:id/mute", cont.AuthMiddleware.RequireSession(), cont.SocialHandler.Mute)
+	user.Delete("/:id/mute", cont.AuthMiddleware.RequireSession(), cont.SocialHandler.Unmute)
+	user.Get("/:user_id/podcasts", readPodcasts, cont.PodcastHandler.GetUserPodcasts)
+
+	// local ve memory depolama sürücülerinin imzalı URL'leri; imza token yerine geçer
+	api.Get("/files/*", cont.FileHandler.GetFile)
+	api.Put("/files/*", cont.FileHandler.PutFile)
+
+	// HLS oynatıcıları başlık gönderemediğinden playlist'ler ve segmentler token yerine stream_url'deki
+	// imzayla yetkilendirilir; bu route /podcasts grubundaki kimlik doğrulamasından önce tanımlanmalı
+	api.Get("/podcasts/:id/hls/*", cont.PodcastHandler.GetHLS)
+
+	// tus route'ları /podcasts grubundan önce tanımlanmalı; OPTIONS isteği kimlik doğrulaması gerektirmez
+	tus := api.Group("/podcasts/tus")
+	tus.Use(cont.TusHandler.Resumable)
+	tus.Options("/", cont.TusHandler.Options)
+	tus.Options("/:id", cont.TusHandler.Options)
+	tus.Post("/", cont.AuthMiddleware.Authenticate(), writePodcasts, cont.TusHandler.Create)
+	tus.Head("/:id", cont.AuthMiddleware.Authenticate(), writePodcasts, cont.TusHandler.Head)
+	tus.Patch("/:id", cont.AuthMiddleware.Authenticate(), writePodcasts, cont.TusHandler.Patch)
 
 	podcast := api.Group("/podcasts")
-	podcast.Use(cont.AuthMiddleware.JWTMiddleware())
+	podcast.Use(cont.AuthMiddleware.Authenticate())
 
 	// Önce spesifik route'ları tanımla
-	podcast.Get("/liked", cont.PodcastHandler.GetLikedPodcasts)
-	podcast.Get("/discover", cont.PodcastHandler.DiscoverPodcasts)
-	podcast.Get("/category/:category", cont.PodcastHandler.GetPodcastsByCategory)
+	podcast.Get("/liked", readPodcasts, cont.PodcastHandler.GetLikedPodcasts)
+	podcast.Get("/discover", readPodcasts, cont.PodcastHandler.DiscoverPodcasts)
+	podcast.Get("/feed", readPodcasts, cont.PodcastHandler.GetFeed)
+	podcast.Get("/category/:category", readPodcasts, cont.PodcastHandler.GetPodcastsByCategory)
 
 	// Sonra parametreli route'ları tanımla
-	podcast.Get("/:id", cont.PodcastHandler.GetPodcastByID)
-	podcast.Post("/:id/like", cont.PodcastHandler.LikePodcast)
-	podcast.Post("/:id/comments", cont.PodcastHandler.AddComment)
-	podcast.Get("/:id/comments", cont.PodcastHandler.GetComments)
-	podcast.Put("/:id", cont.PodcastHandler.UpdatePodcast)
-	podcast.Delete("/:id", cont.PodcastHandler.DeletePodcast)
-	podcast.Put("/:id/cover", cont.PodcastHandler.UpdatePodcastCover)
+	podcast.Get("/:id", readPodcasts, cont.PodcastHandler.GetPodcastByID)
+	podcast.Post("/:id/like", writePodcasts, cont.PodcastHandler.LikePodcast)
+	podcast.Post("/:id/comments", writeComments, cont.PodcastHandler.AddComment)
+	podcast.Get("/:id/comments", readPodcasts, cont.PodcastHandler.GetComments)
+	podcast.Get("/:id/audio", readPodcasts, cont.PodcastHandler.GetAudio)
+	podcast.Get("/:id/waveform", readPodcasts, cont.PodcastHandler.GetWaveform)
+	podcast.Get("/:id/status", readPodcasts, cont.PodcastHandler.GetStatus)
+	podcast.Get("/:id/status/stream", readPodcasts, cont.PodcastHandler.StreamStatus)
+	podcast.Delete("/:id/comments/:comment_id", writeComments, cont.PodcastHandler.DeleteComment)
+	podcast.Put("/:id", writePodcasts, cont.PodcastHandler.UpdatePodcast)
+	podcast.Delete("/:id", writePodcasts, cont.PodcastHandler.DeletePodcast)
+	podcast.Put("/:id/cover", writePodcasts, cont.PodcastHandler.UpdatePodcastCover)
 
 	// En son genel route'ları tanımla
-	podcast.Post("/", cont.PodcastHandler.UploadPodcast)
+	podcast.Post("/", writePodcasts, cont.PodcastHandler.UploadPodcast)
+	podcast.Post("/uploads", writePodcasts, cont.PodcastHandler.CreateUpload)
+	podcast.Post("/uploads/:id/complete", writePodcasts, cont.PodcastHandler.CompleteUpload)
+
+	admin := api.Group("/admin")
+	admin.Use(cont.AuthMiddleware.JWTMiddleware(), cont.AuthMiddleware.RequireRole(model.RoleAdmin))
+	admin.Get("/users", cont.AdminHandler.ListUsers)
+	admin.Get("/users/:id", cont.AdminHandler.GetUser)
+	admin.Put("/users/:id/role", cont.AdminHandler.UpdateUserRole)
+	admin.Delete("/users/:id", cont.AdminHandler.DeleteUser)
 }
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"errors"
+	"shortcast/internal/dto"
+	"shortcast/internal/model"
+	"shortcast/internal/policy"
+	"shortcast/internal/repository"
+)
+
+type AdminService struct {
+	userRepo    *repository.UserRepository
+	userService *UserService
+}
+
+func NewAdminService(userRepo *repository.UserRepository, userService *UserService) *AdminService {
+	return &AdminService{
+		userRepo:    userRepo,
+		userService: userService,
+	}
+}
+
+func (s *AdminService) ListUsers(req *dto.AdminUserListRequest) (*dto.AdminUserListResponse, error) {
+	page := req.Page
+	if page <= 0 {
+		page = 1
+	}
+	limit := req.Limit
+	if limit <= 0 || limit > 100 {
+		limit = 20 // Varsayılan limit
+	}
+
+	users, total, err := s.userRepo.ListUsers((page-1)*limit, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.AdminUserListResponse{
+		Users: make([]dto.AdminUserResponse, 0),
+		Page:  page,
+		Limit: limit,
+		Total: total,
+	}
+	for _, user := range *users {
+		response.Users = append(response.Users, toAdminUserResponse(&user))
+	}
+
+	return response, nil
+}
+
+func (s *AdminService) GetUser(id uint) (*dto.AdminUserResponse, error) {
+	user, err := s.userRepo.GetUserByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toAdminUserResponse(user)
+	return &response, nil
+}
+
+// UpdateUserRole, kullanıcının rolünü değiştirir. Adminin kendi rolünü
+// değiştirerek sistemde admin bırakmamasını önlemek için kendi rolü değiştirilemez.
+func (s *AdminService) UpdateUserRole(actorID, userID uint, role string) (*dto.AdminUserResponse, error) {
+	if !policy.IsValidRole(role) {
+		return nil, errors.New("geçersiz rol")
+	}
+
+	if actorID == userID {
+		return nil, errors.New("kendi rolünüzü değiştiremezsiniz")
+	}
+
+	if err := s.userRepo.UpdateRole(userID, role); err != nil {
+		return nil, err
+	}
+
+	return s.GetUser(userID)
+}
+
+// DeleteUser, kullanıcının hesabını kendi hesabını sildiğinde olduğu gibi podcastleri,
+// beğenileri, yorumları, API anahtarları ve dosyalarıyla birlikte siler; oturumlarını sonlandırır
+func (s *AdminService) DeleteUser(actorID, userID uint) error {
+	if actorID == userID {
+		return errors.New("kendi hesabınızı bu yolla silemezsiniz")
+	}
+
+	return s.userService.RemoveUser(userID)
+}
+
+func toAdminUserResponse(user *model.User) dto.AdminUserResponse {
+	return dto.AdminUserResponse{
+		ID:            user.ID,
+		FirstName:     user.FirstName,
+		LastName:      user.LastName,
+		Username:      user.Username,
+		Email:         user.Email,
+		Role:          user.Role,
+		EmailVerified: user.EmailVerifiedAt != nil,
+		TOTPEnabled:   user.TOTPEnabled,
+		CreatedAt:     user.CreatedAt,
+	}
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"shortcast/internal/dto"
+	"shortcast/internal/model"
+	"shortcast/internal/repository"
+	"shortcast/internal/utils"
+	"strings"
+	"time"
+)
+
+const (
+	// APIKeyPrefix, API anahtarlarını JWT'lerden ayırt etmeye yarar
+	APIKeyPrefix = "sc_"
+	// apiKeyTouchInterval, her istekte veritabanına yazmamak için son kullanım zamanının güncellenme aralığı
+	apiKeyTouchInterval = time.Minute
+)
+
+type APIKeyService struct {
+	apiKeyRepo *repository.APIKeyRepository
+}
+
+func NewAPIKeyService(apiKeyRepo *repository.APIKeyRepository) *APIKeyService {
+	return &APIKeyService{apiKeyRepo: apiKeyRepo}
+}
+
+func (s *APIKeyService) CreateAPIKey(userID uint, req *dto.CreateAPIKeyRequest) (*dto.CreateAPIKeyResponse, error) {
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, errors.New("anahtar adı gerekli")
+	}
+
+	scopes, err := normalizeScopes(req.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	rawKey := APIKeyPrefix + secret
+
+	key := &model.APIKey{
+		UserID:  userID,
+		Name:    strings.TrimSpace(req.Name),
+		Prefix:  rawKey[:len(APIKeyPrefix)+8],
+		KeyHash: utils.HashToken(rawKey),
+		Scopes:  strings.Join(scopes, ","),
+	}
+
+	if req.ExpiresInDays > 0 {
+		expiresAt := time.Now().AddDate(0, 0, req.ExpiresInDays)
+		key.ExpiresAt = &expiresAt
+	}
+
+	if err := s.apiKeyRepo.CreateAPIKey(key); err != nil {
+		return nil, err
+	}
+
+	return &dto.CreateAPIKeyResponse{
+		APIKeyResponse: toAPIKeyResponse(key),
+		Key:            rawKey,
+	}, nil
+}
+
+func (s *APIKeyService) GetAPIKeys(userID uint) ([]dto.APIKeyResponse, error) {
+	keys, err := s.apiKeyRepo.GetAPIKeysByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.APIKeyResponse, 0)
+	for _, key := range *keys {
+		response = append(response, toAPIKeyResponse(&key))
+	}
+	return response, nil
+}
+
+func (s *APIKeyService) RevokeAPIKey(id, userID uint) error {
+	return s.apiKeyRepo.RevokeAPIKey(id, userID)
+}
+
+// Authenticate, ham anahtarı doğrular ve anahtarın son kullanım zamanını günceller.
+// İptal edilmiş, süresi dolmuş veya sahibi silinmiş anahtarlar reddedilir.
+func (s *APIKeyService) Authenticate(rawKey string) (*model.APIKey, error) {
+	if !strings.HasPrefix(rawKey, APIKeyPrefix) {
+		return nil, errors.New("geçersiz API anahtarı")
+	}
+
+	key, err := s.apiKeyRepo.GetAPIKeyByHash(utils.HashToken(rawKey))
+	if err != nil {
+		return nil, errors.New("geçersiz API anahtarı")
+	}
+
+	now := time.Now()
+	if key.RevokedAt != nil || (key.ExpiresAt != nil && now.After(*key.ExpiresAt)) {
+		return nil, errors.New("geçersiz API anahtarı")
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) > apiKeyTouchInterval {
+		if err := s.apiKeyRepo.TouchAPIKey(key.ID, now); err != nil {
+			// Kritik değil, isteği engelleme
+			fmt.Printf("APIKey - HATA: Son kullanım zamanı güncellenemedi. KeyID: %d, Hata: %v\n", key.ID, err)
+		}
+	}
+
+	return key, nil
+}
+
+func normalizeScopes(scopes []string) ([]string, error) {
+	if len(scopes) == 0 {
+		return nil, errors.New("en az bir kapsam seçilmeli")
+	}
+
+	valid := make(map[string]bool, len(model.APIScopes))
+	for _, scope := range model.APIScopes {
+		valid[scope] = true
+	}
+
+	seen := make(map[string]bool)
+	result := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		scope = strings.TrimSpace(scope)
+		if !valid[scope] {
+			return nil, fmt.Errorf("geçersiz kapsam: %s", scope)
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			result = append(result, scope)
+		}
+	}
+	return result, nil
+}
+
+func toAPIKeyResponse(key *model.APIKey) dto.APIKeyResponse {
+	return dto.APIKeyResponse{
+		ID:         key.ID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     strings.Split(key.Scopes, ","),
+		CreatedAt:  key.CreatedAt,
+		LastUsedAt: key.LastUsedAt,
+		ExpiresAt:  key.ExpiresAt,
+	}
+}
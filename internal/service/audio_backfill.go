@@ -0,0 +1,77 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"shortcast/internal/audio"
+	"shortcast/internal/repository"
+)
+
+// AudioBackfillService, ses bilgileri kaydedilmeden önce yüklenmiş podcastlerin dosyalarını
+// R2'den indirerek süre, bitrate, örnekleme hızı gibi bilgilerini doldurur
+type AudioBackfillService struct {
+	podcastRepo    *repository.PodcastRepository
+	StorageService *StorageService
+}
+
+type AudioBackfillResult struct {
+	Updated int
+	Failed  int
+}
+
+func NewAudioBackfillService(podcastRepo *repository.PodcastRepository, storageService *StorageService) *AudioBackfillService {
+	return &AudioBackfillService{
+		podcastRepo:    podcastRepo,
+		StorageService: storageService,
+	}
+}
+
+// Run, bilgileri eksik podcastleri batchSize'lık gruplar halinde işler. Okunamayan dosyalar
+// raporlanıp atlanır; dryRun true ise veritabanına yazılmaz.
+func (s *AudioBackfillService) Run(batchSize int, dryRun bool) (*AudioBackfillResult, error) {
+	result := &AudioBackfillResult{}
+	var lastID uint
+
+	for {
+		podcasts, err := s.podcastRepo.GetPodcastsWithoutAudioMetadata(lastID, batchSize)
+		if err != nil {
+			return result, err
+		}
+		if len(*podcasts) == 0 {
+			return result, nil
+		}
+
+		for i := range *podcasts {
+			podcast := &(*podcasts)[i]
+			lastID = podcast.ID
+
+			data, err := s.StorageService.DownloadFile(podcast.AudioKey)
+			if err != nil {
+				fmt.Printf("Backfill - HATA: Podcast %d dosyası indirilemedi. Key: %s, Hata: %v\n", podcast.ID, podcast.AudioKey, err)
+				result.Failed++
+				continue
+			}
+
+			info, err := audio.Probe(bytes.NewReader(data))
+			if err != nil {
+				fmt.Printf("Backfill - HATA: Podcast %d dosyası okunamadı. Key: %s, Hata: %v\n", podcast.ID, podcast.AudioKey, err)
+				result.Failed++
+				continue
+			}
+
+			applyAudioInfo(podcast, info)
+			fmt.Printf("Backfill - Podcast %d: %s, %d ms, %d bit/s, %d Hz, %d kanal, %d bayt\n",
+				podcast.ID, podcast.Codec, podcast.DurationMs, podcast.Bitrate, podcast.SampleRate, podcast.Channels, podcast.SizeBytes)
+
+			if dryRun {
+				result.Updated++
+				continue
+			}
+
+			if err := s.podcastRepo.UpdateAudioMetadata(podcast); err != nil {
+				return result, err
+			}
+			result.Updated++
+		}
+	}
+}
@@ -2,8 +2,11 @@ package service
 
 import (
 	"errors"
+	"fmt"
+	"net/url"
 	"shortcast/internal/config"
 	"shortcast/internal/dto"
+	"shortcast/internal/mailer"
 	"shortcast/internal/model"
 	"shortcast/internal/repository"
 	"shortcast/internal/utils"
@@ -14,20 +17,41 @@ import (
 	"gorm.io/gorm"
 )
 
+const (
+	emailVerificationTTL  = 24 * time.Hour
+	passwordResetTTL      = time.Hour
+	twoFactorChallengeTTL = 5 * time.Minute
+	maxTwoFactorAttempts  = 5
+	recoveryCodeCount     = 10
+	totpIssuer            = "Shortcast"
+	// recentLoginWindow, şifresiz hesaplarda şifre belirleme, 2FA'yı kapatma ve hesap silme
+	// için girişin üzerinden geçebilecek en uzun süredir
+	recentLoginWindow = 10 * time.Minute
+)
+
 type AuthService struct {
 	authRepo *repository.AuthRepository
 	userRepo *repository.UserRepository
+	mailer   mailer.Mailer
+	keys     *utils.KeySet
 	cfg      *config.Config
 }
 
-func NewAuthService(authRepo *repository.AuthRepository, userRepo *repository.UserRepository, cfg *config.Config) *AuthService {
+func NewAuthService(authRepo *repository.AuthRepository, userRepo *repository.UserRepository, mailer mailer.Mailer, keys *utils.KeySet, cfg *config.Config) *AuthService {
 	return &AuthService{
 		authRepo: authRepo,
 		userRepo: userRepo,
+		mailer:   mailer,
+		keys:     keys,
 		cfg:      cfg,
 	}
 }
 
+// JWKS, diğer servislerin Shortcast token'larını doğrulayabilmesi için public anahtarları döndürür
+func (s *AuthService) JWKS() utils.JWKSet {
+	return s.keys.JWKS()
+}
+
 func (s *AuthService) Register(req dto.RegisterRequest) error {
 
 	_, err := s.userRepo.GetUserByEmail(req.Email)
@@ -58,12 +82,23 @@ func (s *AuthService) Register(req dto.RegisterRequest) error {
 		Username:  req.Username,
 		Email:     req.Email,
 		Password:  string(hashedPassword),
+		Role:      model.RoleUser,
+	}
+
+	if err := s.authRepo.CreateUser(&user); err != nil {
+		return err
+	}
+
+	// E-posta gönderilemese bile kayıt tamamlanır; kullanıcı şifre sıfırlama
+	// akışıyla da adresini doğrulayabilir
+	if err := s.SendVerificationEmail(&user); err != nil {
+		fmt.Printf("Auth - HATA: Doğrulama e-postası gönderilemedi. UserID: %d, Hata: %v\n", user.ID, err)
 	}
 
-	return s.authRepo.CreateUser(&user)
+	return nil
 }
 
-func (s *AuthService) Login(emailOrUsername, password string) (string, error) {
+func (s *AuthService) Login(emailOrUsername, password, userAgent, ip string) (*dto.LoginResponse, error) {
 	var user *model.User
 	var err error
 
@@ -71,20 +106,342 @@ func (s *AuthService) Login(emailOrUsername, password string) (string, error) {
 	if err != nil {
 		user, err = s.userRepo.GetUserByUsername(emailOrUsername)
 		if err != nil {
-			return "", errors.New("kullanıcı bulunamadı")
+			return nil, errors.New("kullanıcı bulunamadı")
 		}
 	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
-		return "", errors.New("şifre yanlış")
+		return nil, errors.New("şifre yanlış")
+	}
+
+	return s.StartLogin(user, userAgent, ip)
+}
+
+// StartLogin, kimliği doğrulanmış kullanıcı için oturum açar. Şifreli girişin yanında
+// harici sağlayıcı girişleri de aynı e-posta doğrulama ve 2FA kontrollerinden geçer.
+func (s *AuthService) StartLogin(user *model.User, userAgent, ip string) (*dto.LoginResponse, error) {
+	if s.cfg.RequireEmailVerification && user.EmailVerifiedAt == nil {
+		return nil, errors.New("e-posta adresi doğrulanmamış")
+	}
+
+	// 2FA açıksa oturum açmak yerine ikinci adım için challenge token döndür
+	if user.TOTPEnabled {
+		challengeToken, err := utils.GenerateChallengeJWT(s.keys, user.ID, twoFactorChallengeTTL)
+		if err != nil {
+			return nil, errors.New("token oluşturulurken bir hata oluştu")
+		}
+
+		return &dto.LoginResponse{
+			TwoFactorRequired: true,
+			ChallengeToken:    challengeToken,
+		}, nil
 	}
 
-	token, err := utils.GenerateJWT(user.ID, s.cfg.SecretKey)
+	tokens, err := s.createSession(user.ID, userAgent, ip)
 	if err != nil {
-		return "", errors.New("token oluşturulurken bir hata oluştu")
+		return nil, err
 	}
 
-	return token, nil
+	return &dto.LoginResponse{
+		Token:        tokens.Token,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresIn:    tokens.ExpiresIn,
+	}, nil
+}
+
+// LoginWithTwoFactor, challenge token ile birlikte gelen TOTP veya kurtarma
+// koduyla girişin ikinci adımını tamamlar
+func (s *AuthService) LoginWithTwoFactor(challengeToken, code, userAgent, ip string) (*dto.TokenResponse, error) {
+	claims, err := utils.ParseJWT(s.keys, challengeToken, utils.TokenTypeTwoFactorChallenge)
+	if err != nil {
+		return nil, errors.New("geçersiz veya süresi dolmuş doğrulama isteği")
+	}
+	userID := uint(claims["user_id"].(float64))
+
+	attempts, err := s.authRepo.IncrementTwoFactorAttempts(userID, twoFactorChallengeTTL)
+	if err != nil {
+		return nil, err
+	}
+	if attempts > maxTwoFactorAttempts {
+		return nil, errors.New("çok fazla hatalı deneme, lütfen daha sonra tekrar deneyin")
+	}
+
+	user, err := s.userRepo.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.TOTPEnabled {
+		return nil, errors.New("iki adımlı doğrulama etkin değil")
+	}
+
+	if err := s.verifySecondFactor(user, code); err != nil {
+		return nil, err
+	}
+
+	// Hatalı denemeler yalnızca art arda yapıldığında kilitlemeli
+	if err := s.authRepo.ResetTwoFactorAttempts(user.ID); err != nil {
+		fmt.Printf("Auth - HATA: 2FA deneme sayacı sıfırlanamadı. Kullanıcı: %d, Hata: %v\n", user.ID, err)
+	}
+
+	return s.createSession(user.ID, userAgent, ip)
+}
+
+// SetupTOTP, yeni bir TOTP secret'ı üretir. 2FA, ConfirmTOTP ile bir kod doğrulanana kadar etkinleşmez.
+func (s *AuthService) SetupTOTP(userID uint) (*dto.TOTPSetupResponse, error) {
+	user, err := s.userRepo.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.TOTPEnabled {
+		return nil, errors.New("iki adımlı doğrulama zaten etkin")
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.authRepo.SetPendingTOTPSecret(user.ID, secret); err != nil {
+		return nil, err
+	}
+
+	return &dto.TOTPSetupResponse{
+		Secret:     secret,
+		OTPAuthURI: utils.TOTPURI(totpIssuer, user.Email, secret),
+	}, nil
+}
+
+// ConfirmTOTP, authenticator uygulamasından gelen ilk kodu doğrulayıp 2FA'yı etkinleştirir
+// ve kullanıcıya bir kez gösterilecek kurtarma kodlarını döndürür
+func (s *AuthService) ConfirmTOTP(userID uint, code string) (*dto.TOTPConfirmResponse, error) {
+	user, err := s.userRepo.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.TOTPEnabled {
+		return nil, errors.New("iki adımlı doğrulama zaten etkin")
+	}
+	if user.TOTPSecret == "" {
+		return nil, errors.New("önce iki adımlı doğrulama kurulumu başlatılmalı")
+	}
+
+	step, ok := utils.ValidateTOTP(user.TOTPSecret, code, time.Now())
+	if !ok {
+		return nil, errors.New("doğrulama kodu hatalı")
+	}
+	if _, err := s.authRepo.MarkTOTPStepUsed(user.ID, step); err != nil {
+		return nil, err
+	}
+
+	plainCodes := make([]string, 0, recoveryCodeCount)
+	recoveryCodes := make([]model.RecoveryCode, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		recoveryCode, err := utils.GenerateRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		plainCodes = append(plainCodes, recoveryCode)
+		recoveryCodes = append(recoveryCodes, model.RecoveryCode{
+			UserID:   user.ID,
+			CodeHash: utils.HashToken(recoveryCode),
+		})
+	}
+
+	if err := s.authRepo.EnableTOTP(user.ID, recoveryCodes); err != nil {
+		return nil, err
+	}
+
+	return &dto.TOTPConfirmResponse{RecoveryCodes: plainCodes}, nil
+}
+
+// DisableTOTP, geçerli bir ikinci adım koduyla 2FA'yı kapatır. Kimlik Reauthenticate ile
+// yeniden doğrulanır; şifresi olmayan hesaplarda yakın zamanda giriş yapılmış olması yeterlidir.
+func (s *AuthService) DisableTOTP(userID, sessionID uint, password, code string) error {
+	user, err := s.userRepo.GetUserByID(userID)
+	if err != nil {
+		return err
+	}
+
+	if !user.TOTPEnabled {
+		return errors.New("iki adımlı doğrulama etkin değil")
+	}
+
+	if err := s.Reauthenticate(user, sessionID, password); err != nil {
+		return err
+	}
+
+	if err := s.verifySecondFactor(user, code); err != nil {
+		return err
+	}
+
+	return s.authRepo.DisableTOTP(user.ID)
+}
+
+// verifySecondFactor, kodu önce TOTP olarak, olmazsa kurtarma kodu olarak doğrular
+func (s *AuthService) verifySecondFactor(user *model.User, code string) error {
+	if step, ok := utils.ValidateTOTP(user.TOTPSecret, code, time.Now()); ok {
+		fresh, err := s.authRepo.MarkTOTPStepUsed(user.ID, step)
+		if err != nil {
+			return err
+		}
+		if !fresh {
+			return errors.New("doğrulama kodu zaten kullanıldı")
+		}
+		return nil
+	}
+
+	used, err := s.authRepo.UseRecoveryCode(user.ID, utils.HashToken(utils.NormalizeRecoveryCode(code)))
+	if err != nil {
+		return err
+	}
+	if !used {
+		return errors.New("doğrulama kodu hatalı")
+	}
+	return nil
+}
+
+// Refresh, refresh token'ı döndürerek yeni bir access token üretir.
+// Daha önce kullanılmış bir token gelirse oturum tamamen iptal edilir.
+func (s *AuthService) Refresh(refreshToken, userAgent, ip string) (*dto.TokenResponse, error) {
+	stored, err := s.authRepo.GetRefreshTokenByHash(utils.HashToken(refreshToken))
+	if err != nil {
+		return nil, errors.New("geçersiz refresh token")
+	}
+
+	session := stored.Session
+	if session.RevokedAt != nil || time.Now().After(session.ExpiresAt) {
+		return nil, errors.New("oturum sonlandırılmış")
+	}
+
+	if stored.UsedAt != nil {
+		return nil, s.revokeReusedSession(session.ID)
+	}
+
+	if time.Now().After(stored.ExpiresAt) {
+		return nil, errors.New("refresh token süresi dolmuş")
+	}
+
+	nextToken, next, err := newRefreshToken(session.ID, session.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+	rotated, err := s.authRepo.RotateRefreshToken(stored.ID, next)
+	if err != nil {
+		return nil, err
+	}
+	if !rotated {
+		return nil, s.revokeReusedSession(session.ID)
+	}
+
+	// Son kullanım bilgisi yalnızca oturum listesinde gösterildiğinden güncellenemezse
+	// yenileme başarısız sayılmaz
+	if err := s.authRepo.TouchSession(session.ID, time.Now(), truncate(userAgent, 255), ip); err != nil {
+		fmt.Printf("Auth - HATA: Oturum güncellenemedi. Session: %d, Hata: %v\n", session.ID, err)
+	}
+
+	accessToken, err := utils.GenerateJWT(s.keys, session.UserID, session.ID, s.accessTTL())
+	if err != nil {
+		return nil, errors.New("token oluşturulurken bir hata oluştu")
+	}
+
+	return &dto.TokenResponse{
+		Token:        accessToken,
+		RefreshToken: nextToken,
+		ExpiresIn:    s.cfg.JWTExpiration,
+	}, nil
+}
+
+// IsSessionActive, oturumun kullanıcıya ait, iptal edilmemiş ve süresi dolmamış olduğunu kontrol eder
+func (s *AuthService) IsSessionActive(userID, sessionID uint) (bool, error) {
+	session, err := s.activeSession(userID, sessionID)
+	return session != nil, err
+}
+
+// IsRecentLogin, oturumun açık olduğunu ve son window içinde bir girişle açıldığını kontrol
+// eder. Refresh token ile yenilenen oturumların açılış zamanı değişmez.
+func (s *AuthService) IsRecentLogin(userID, sessionID uint, window time.Duration) (bool, error) {
+	session, err := s.activeSession(userID, sessionID)
+	if err != nil || session == nil {
+		return false, err
+	}
+	return time.Since(session.CreatedAt) <= window, nil
+}
+
+// Reauthenticate, hesap düzeyindeki işlemlerden önce kimliği yeniden doğrular. Şifresi olan
+// hesaplarda mevcut şifre, şifresi olmayan hesaplarda son recentLoginWindow içinde sağlayıcıyla
+// giriş yapılarak açılmış bir oturum istenir; böylece çalınmış bir access token yetmez.
+func (s *AuthService) Reauthenticate(user *model.User, sessionID uint, password string) error {
+	if user.Password != "" {
+		if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
+			return errors.New("şifre yanlış")
+		}
+		return nil
+	}
+
+	recent, err := s.IsRecentLogin(user.ID, sessionID, recentLoginWindow)
+	if err != nil {
+		return err
+	}
+	if !recent {
+		return errors.New("bu işlem için yeniden giriş yapmalısınız")
+	}
+	return nil
+}
+
+// activeSession, oturum kullanıcıya ait, iptal edilmemiş ve süresi dolmamışsa döndürür; değilse nil döner
+func (s *AuthService) activeSession(userID, sessionID uint) (*model.Session, error) {
+	if sessionID == 0 {
+		return nil, nil
+	}
+	session, err := s.authRepo.GetSessionByID(sessionID)
+	if err != nil {
+		if err.Error() == "oturum bulunamadı" {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if session.UserID != userID || session.RevokedAt != nil || time.Now().After(session.ExpiresAt) {
+		return nil, nil
+	}
+	return session, nil
+}
+
+func (s *AuthService) GetSessions(userID, currentSessionID uint) ([]dto.SessionResponse, error) {
+	sessions, err := s.authRepo.GetActiveSessionsByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.SessionResponse, 0)
+	for _, session := range *sessions {
+		response = append(response, dto.SessionResponse{
+			ID:         session.ID,
+			UserAgent:  session.UserAgent,
+			IPAddress:  session.IPAddress,
+			CreatedAt:  session.CreatedAt,
+			LastUsedAt: session.LastUsedAt,
+			ExpiresAt:  session.ExpiresAt,
+			Current:    session.ID == currentSessionID,
+		})
+	}
+	return response, nil
+}
+
+func (s *AuthService) RevokeSession(userID, sessionID uint) error {
+	session, err := s.authRepo.GetSessionByID(sessionID)
+	if err != nil {
+		return err
+	}
+
+	// Başka bir kullanıcının oturumunun varlığını belli etmemek için aynı hatayı döndür
+	if session.UserID != userID {
+		return errors.New("oturum bulunamadı")
+	}
+
+	return s.authRepo.RevokeSession(session.ID, s.accessTTL())
 }
 
 func (s *AuthService) Logout(token *jwt.Token) error {
@@ -98,6 +455,223 @@ func (s *AuthService) Logout(token *jwt.Token) error {
 		return errors.New("token süresi alınamadı")
 	}
 
+	// Oturumu da sonlandır ki refresh token ile yeni token alınamasın
+	if sid, ok := claims["sid"].(float64); ok {
+		if err := s.authRepo.RevokeSession(uint(sid), s.accessTTL()); err != nil {
+			return err
+		}
+	}
+
 	// Token'ı blacklist'e ekle
 	return s.authRepo.BlacklistToken(tokenStr, time.Unix(int64(exp), 0))
 }
+
+// SendVerificationEmail, kullanıcıya e-posta doğrulama linki gönderir
+func (s *AuthService) SendVerificationEmail(user *model.User) error {
+	token, err := s.issueUserToken(user.ID, model.TokenPurposeEmailVerification, emailVerificationTTL)
+	if err != nil {
+		return err
+	}
+
+	return s.mailer.Send(mailer.Message{
+		To:      user.Email,
+		Subject: "Shortcast - E-posta adresinizi doğrulayın",
+		Body: fmt.Sprintf("Merhaba %s,\n\nHesabınızı etkinleştirmek için aşağıdaki linke tıklayın:\n%s/api/auth/verify?token=%s\n\nLink 24 saat geçerlidir.",
+			user.FirstName, s.cfg.AppURL, url.QueryEscape(token)),
+	})
+}
+
+// ResendVerificationEmail, adresi henüz doğrulanmamış bir hesaba yeni doğrulama linki gönderir.
+// Önceki linkler geçersiz olur. Hangi adreslerin kayıtlı olduğunu belli etmemek için adres
+// bulunamazsa ya da zaten doğrulanmışsa da hata döndürmez.
+func (s *AuthService) ResendVerificationEmail(email string) error {
+	user, err := s.userRepo.GetUserByEmail(email)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil
+		}
+		return err
+	}
+	if user.EmailVerifiedAt != nil {
+		return nil
+	}
+
+	return s.SendVerificationEmail(user)
+}
+
+func (s *AuthService) VerifyEmail(token string) error {
+	userToken, err := s.consumeUserToken(token, model.TokenPurposeEmailVerification)
+	if err != nil {
+		return err
+	}
+
+	return s.userRepo.MarkEmailVerified(userToken.UserID)
+}
+
+// ForgotPassword, kayıtlı bir adres için şifre sıfırlama linki gönderir.
+// Hangi adreslerin kayıtlı olduğunu belli etmemek için adres bulunamazsa da hata döndürmez.
+func (s *AuthService) ForgotPassword(email string) error {
+	user, err := s.userRepo.GetUserByEmail(email)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil
+		}
+		return err
+	}
+
+	token, err := s.issueUserToken(user.ID, model.TokenPurposePasswordReset, passwordResetTTL)
+	if err != nil {
+		return err
+	}
+
+	return s.mailer.Send(mailer.Message{
+		To:      user.Email,
+		Subject: "Shortcast - Şifre sıfırlama",
+		Body: fmt.Sprintf("Merhaba %s,\n\nŞifrenizi sıfırlamak için aşağıdaki token'ı kullanın:\n%s\n\nToken 1 saat geçerlidir. Bu isteği siz yapmadıysanız bu e-postayı dikkate almayın.",
+			user.FirstName, token),
+	})
+}
+
+// ResetPassword, sıfırlama token'ı ile şifreyi değiştirir ve tüm oturumları sonlandırır
+func (s *AuthService) ResetPassword(token, newPassword string) error {
+	userToken, err := s.consumeUserToken(token, model.TokenPurposePasswordReset)
+	if err != nil {
+		return err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	if err := s.userRepo.UpdatePassword(userToken.UserID, string(hashedPassword)); err != nil {
+		return err
+	}
+
+	// Sıfırlama linkine ulaşabilmek e-posta adresinin sahibi olmayı gerektirir
+	if err := s.userRepo.MarkEmailVerified(userToken.UserID); err != nil {
+		return err
+	}
+
+	return s.authRepo.RevokeUserSessions(userToken.UserID, 0, s.accessTTL())
+}
+
+func (s *AuthService) issueUserToken(userID uint, purpose string, ttl time.Duration) (string, error) {
+	token, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return "", err
+	}
+
+	err = s.authRepo.CreateUserToken(&model.UserToken{
+		UserID:    userID,
+		Purpose:   purpose,
+		TokenHash: utils.HashToken(token),
+		ExpiresAt: time.Now().Add(ttl),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// consumeUserToken, token'ı doğrular ve tek kullanımlık olarak işaretler
+func (s *AuthService) consumeUserToken(token, purpose string) (*model.UserToken, error) {
+	userToken, err := s.authRepo.GetUserTokenByHash(utils.HashToken(token), purpose)
+	if err != nil {
+		return nil, errors.New("geçersiz veya süresi dolmuş token")
+	}
+
+	if userToken.UsedAt != nil || time.Now().After(userToken.ExpiresAt) {
+		return nil, errors.New("geçersiz veya süresi dolmuş token")
+	}
+
+	marked, err := s.authRepo.MarkUserTokenUsed(userToken.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !marked {
+		return nil, errors.New("geçersiz veya süresi dolmuş token")
+	}
+
+	return userToken, nil
+}
+
+func (s *AuthService) accessTTL() time.Duration {
+	return time.Duration(s.cfg.JWTExpiration) * time.Second
+}
+
+// createSession, yeni bir cihaz oturumu açar ve ilk token çiftini üretir
+func (s *AuthService) createSession(userID uint, userAgent, ip string) (*dto.TokenResponse, error) {
+	now := time.Now()
+	session := &model.Session{
+		UserID:     userID,
+		UserAgent:  truncate(userAgent, 255),
+		IPAddress:  ip,
+		LastUsedAt: now,
+		ExpiresAt:  now.Add(time.Duration(s.cfg.RefreshTokenExpiration) * time.Second),
+	}
+
+	if err := s.authRepo.CreateSession(session); err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := s.issueRefreshToken(session.ID, session.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	accessToken, err := utils.GenerateJWT(s.keys, userID, session.ID, s.accessTTL())
+	if err != nil {
+		return nil, errors.New("token oluşturulurken bir hata oluştu")
+	}
+
+	return &dto.TokenResponse{
+		Token:        accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    s.cfg.JWTExpiration,
+	}, nil
+}
+
+func (s *AuthService) issueRefreshToken(sessionID uint, expiresAt time.Time) (string, error) {
+	token, record, err := newRefreshToken(sessionID, expiresAt)
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.authRepo.CreateRefreshToken(record); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// newRefreshToken, yeni bir refresh token ve veritabanına yazılacak kaydını üretir
+func newRefreshToken(sessionID uint, expiresAt time.Time) (string, *model.RefreshToken, error) {
+	token, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return token, &model.RefreshToken{
+		SessionID: sessionID,
+		TokenHash: utils.HashToken(token),
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// revokeReusedSession, tekrar kullanılan bir refresh token tespit edildiğinde
+// oturumu sonlandırır; token çalınmış olabilir
+func (s *AuthService) revokeReusedSession(sessionID uint) error {
+	if err := s.authRepo.RevokeSession(sessionID, s.accessTTL()); err != nil {
+		return err
+	}
+	return errors.New("refresh token tekrar kullanıldı, oturum sonlandırıldı")
+}
+
+func truncate(value string, max int) string {
+	if len(value) > max {
+		return value[:max]
+	}
+	return value
+}
@@ -0,0 +1,676 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"mime/multipart"
+	"os"
+	"path"
+	"path/filepath"
+	"shortcast/internal/audio"
+	"shortcast/internal/cover"
+	"shortcast/internal/dto"
+	"shortcast/internal/model"
+	"shortcast/internal/queue"
+	"shortcast/internal/repository"
+	"strings"
+	"time"
+)
+
+// JobProcessPodcast, yüklenen podcast'in dosyalarını R2'ye aktaran ve dalga formunu üreten iştir
+const JobProcessPodcast = "podcast.process"
+
+const (
+	// waveformPixels, dalga formundaki min/max çifti sayısıdır
+	waveformPixels = 1000
+	// statusTTL, işlenme durumunun Redis'te tutulduğu süredir
+	statusTTL = 24 * time.Hour
+)
+
+// İşlenme aşamaları
+const (
+	StageQueued         = "queued"
+	StageUploadingAudio = "uploading_audio"
+	StageUploadingCover = "uploading_cover"
+	StageWaveform       = "waveform"
+	StageLoudness       = "loudness"
+	StageHLS            = "hls"
+)
+
+type processPodcastPayload struct {
+	PodcastID uint `json:"podcast_id"`
+	// AudioUploadKey ve CoverUploadKey, dosyaların işlenene kadar depoda bekletildiği
+	// uploads/ altındaki geçici anahtarlardır
+	AudioUploadKey string       `json:"audio_upload_key"`
+	CoverUploadKey string       `json:"cover_upload_key"`
+	AudioFormat    audio.Format `json:"audio_format"`
+	// AudioPath ve CoverPath, işi alan worker'ın dosyaları indirdiği yerel yollardır
+	AudioPath string `json:"-"`
+	CoverPath string `json:"-"`
+}
+
+// MediaService, yüklenen dosyaları istek içinde değil iş kuyruğu üzerinden arka planda işler.
+// Dosyalar kuyruğa alınmadan önce depoya yüklenir ve işi alan worker tarafından kendi spool
+// dizinine indirilir; böylece iş, isteği karşılayan sunucudan farklı bir sunucuda çalışabilir.
+type MediaService struct {
+	podcastRepo    *repository.PodcastRepository
+	StorageService *StorageService
+	RedisService   *RedisService
+	queue          *queue.Queue
+	spoolDir       string
+	// loudness nil ise ses yüksekliği ölçülmez
+	loudness       audio.LoudnessProcessor
+	loudnessTarget float64
+	// hls nil ise HLS paketi üretilmez
+	hls *audio.HLSPackager
+}
+
+func NewMediaService(podcastRepo *repository.PodcastRepository, storageService *StorageService, redisService *RedisService, jobQueue *queue.Queue, spoolDir string) *MediaService {
+	return &MediaService{
+		podcastRepo:    podcastRepo,
+		StorageService: storageService,
+		RedisService:   redisService,
+		queue:          jobQueue,
+		spoolDir:       spoolDir,
+	}
+}
+
+// EnableLoudness, işleme adımlarına ses yüksekliği ölçümünü ve normalizasyonu ekler
+func (s *MediaService) EnableLoudness(processor audio.LoudnessProcessor, target float64) {
+	s.loudness = processor
+	s.loudnessTarget = target
+}
+
+// EnableHLS, işleme adımlarına HLS paketlemeyi ekler
+func (s *MediaService) EnableHLS(packager *audio.HLSPackager) {
+	s.hls = packager
+}
+
+// Register, MediaService'in işlerini worker'a kaydeder
+func (s *MediaService) Register(worker *queue.Worker) {
+	worker.Handle(JobProcessPodcast, s.processPodcast)
+	worker.OnDeadLetter(s.onDeadLetter)
+}
+
+// Spool, yüklenen dosyayı işlenene kadar saklanmak üzere spool dizinine kopyalar ve yolunu
+// döndürür. Format içerikten tespit edildiğinden kullanıcının verdiği dosya adı kullanılmaz.
+func (s *MediaService) Spool(file *multipart.FileHeader) (string, error) {
+	src, err := file.Open()
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+
+	return s.spool(src, "")
+}
+
+// SpoolObject, R2'deki dosyayı spool dizinine indirir ve yolunu döndürür
+func (s *MediaService) SpoolObject(key, ext string) (string, error) {
+	src, err := s.StorageService.OpenFile(key)
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+
+	return s.spool(src, ext)
+}
+
+func (s *MediaService) spool(src io.Reader, ext string) (string, error) {
+	if err := os.MkdirAll(s.spoolDir, 0o755); err != nil {
+		return "", err
+	}
+
+	name := make([]byte, 16)
+	if _, err := rand.Read(name); err != nil {
+		return "", err
+	}
+	dst := filepath.Join(s.spoolDir, hex.EncodeToString(name)+strings.ToLower(ext))
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(out, src); err != nil {
+		out.Close()
+		os.Remove(dst)
+		return "", err
+	}
+	if err := out.Close(); err != nil {
+		os.Remove(dst)
+		return "", err
+	}
+
+	return dst, nil
+}
+
+// TrimSpooled, spool dizinindeki MP3 dosyasını [start, end) aralığına kırpar ve kırpılmış
+// dosyanın bilgilerini döndürür. Kırpılmış dosya orijinalin yerine yazılır.
+func (s *MediaService) TrimSpooled(spoolPath string, start, end time.Duration) (*audio.Info, error) {
+	src, err := os.Open(spoolPath)
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+
+	out, err := os.CreateTemp(s.spoolDir, "trimmed-*"+filepath.Ext(spoolPath))
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(out.Name())
+	defer out.Close()
+
+	if _, err := audio.TrimMP3(src, out, start, end); err != nil {
+		return nil, err
+	}
+
+	info, err := audio.Probe(out)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := out.Close(); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(out.Name(), spoolPath); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// EnqueuePodcast, podcast'i işlenmek üzere kuyruğa alır. Depoda zaten bulunan dosyaların
+// (doğrudan yüklemeler) anahtarları işe olduğu gibi verilir; diğerleri spool dizininden
+// uploads/ altına yüklenir. Anahtarların uzantıları içerikten tespit edilen formattan alınır.
+// Hata durumunda yalnızca burada yüklenen dosyalar silinir; spool dosyaları silinmez, çağıran
+// tarafından silinmelidir.
+func (s *MediaService) EnqueuePodcast(podcast *model.Podcast, upload *spooledUpload, format audio.Format, coverExt string) error {
+	prefix, err := newUploadPrefix()
+	if err != nil {
+		return err
+	}
+	payload := processPodcastPayload{
+		PodcastID:      podcast.ID,
+		AudioUploadKey: upload.audioUploadKey,
+		CoverUploadKey: upload.coverUploadKey,
+		AudioFormat:    format,
+	}
+
+	var staged processPodcastPayload
+	if payload.AudioUploadKey == "" {
+		staged.AudioUploadKey = prefix + "/audio." + string(format)
+		if err := s.uploadSpooled(staged.AudioUploadKey, upload.audioPath, format.MimeType()); err != nil {
+			return err
+		}
+		payload.AudioUploadKey = staged.AudioUploadKey
+	}
+	if payload.CoverUploadKey == "" {
+		staged.CoverUploadKey = prefix + "/cover" + coverExt
+		if err := s.uploadSpooled(staged.CoverUploadKey, upload.coverPath, "application/octet-stream"); err != nil {
+			s.removeUploads(&staged)
+			return err
+		}
+		payload.CoverUploadKey = staged.CoverUploadKey
+	}
+
+	if _, err := s.queue.Enqueue(context.Background(), JobProcessPodcast, payload); err != nil {
+		s.removeUploads(&staged)
+		return err
+	}
+	upload.audioUploadKey = payload.AudioUploadKey
+	upload.coverUploadKey = payload.CoverUploadKey
+
+	s.setStatus(&dto.PodcastStatusResponse{
+		PodcastID: podcast.ID,
+		Status:    model.PodcastStatusProcessing,
+		Stage:     StageQueued,
+	})
+	return nil
+}
+
+// GetStatus, podcast'in işlenme durumunu döndürür. Redis'te kayıt yoksa (ör. süresi
+// dolduysa) veritabanındaki durum kullanılır.
+func (s *MediaService) GetStatus(podcast *model.Podcast) *dto.PodcastStatusResponse {
+	data, err := s.RedisService.GetPodcastStatus(podcast.ID)
+	if err != nil {
+		fmt.Printf("Media - HATA: Podcast durumu okunamadı. PodcastID: %d, Hata: %v\n", podcast.ID, err)
+	}
+
+	var status dto.PodcastStatusResponse
+	if data != nil && json.Unmarshal(data, &status) == nil {
+		return &status
+	}
+
+	status = dto.PodcastStatusResponse{
+		PodcastID: podcast.ID,
+		Status:    podcast.Status,
+	}
+	if podcast.Status == model.PodcastStatusReady {
+		status.Progress = 100
+	}
+	return &status
+}
+
+// WatchStatus, podcast'in durum değişikliklerini döndüren bir kanal açar. Kanal, stop
+// çağrıldığında veya ctx iptal edildiğinde kapanır.
+func (s *MediaService) WatchStatus(ctx context.Context, podcastID uint) (<-chan dto.PodcastStatusResponse, func(), error) {
+	ctx, cancel := context.WithCancel(ctx)
+	pubsub, err := s.RedisService.SubscribePodcastStatus(ctx, podcastID)
+	if err != nil {
+		cancel()
+		return nil, nil, err
+	}
+
+	updates := make(chan dto.PodcastStatusResponse)
+	go func() {
+		defer close(updates)
+		for msg := range pubsub.Channel() {
+			var status dto.PodcastStatusResponse
+			if err := json.Unmarshal([]byte(msg.Payload), &status); err != nil {
+				continue
+			}
+			select {
+			case updates <- status:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	stop := func() {
+		cancel()
+		pubsub.Close()
+	}
+	return updates, stop, nil
+}
+
+func (s *MediaService) setStatus(status *dto.PodcastStatusResponse) {
+	data, err := json.Marshal(status)
+	if err != nil {
+		return
+	}
+	if err := s.RedisService.SetPodcastStatus(status.PodcastID, data, statusTTL); err != nil {
+		fmt.Printf("Media - HATA: Podcast durumu kaydedilemedi. PodcastID: %d, Hata: %v\n", status.PodcastID, err)
+	}
+}
+
+func (s *MediaService) setStage(podcastID uint, stage string, progress int) {
+	s.setStatus(&dto.PodcastStatusResponse{
+		PodcastID: podcastID,
+		Status:    model.PodcastStatusProcessing,
+		Stage:     stage,
+		Progress:  progress,
+	})
+}
+
+// processPodcast, depoda bekleyen ses ve kapak dosyalarını indirip kalıcı anahtarlarına
+// yükler, dalga formunu üretir ve podcast'i yayına alır. Dosyalar her denemede aynı
+// anahtarlara yazıldığından iş güvenle tekrar denenebilir.
+func (s *MediaService) processPodcast(ctx context.Context, job *queue.Job) error {
+	var payload processPodcastPayload
+	if err := job.Decode(&payload); err != nil {
+		return err
+	}
+
+	podcast, err := s.podcastRepo.GetPodcastByID(payload.PodcastID)
+	if err != nil {
+		if err.Error() == "podcast bulunamadı" {
+			// Podcast işlenmeden silinmiş
+			s.removeUploads(&payload)
+			return nil
+		}
+		return err
+	}
+	if podcast.Status == model.PodcastStatusReady {
+		// İş önceki bir denemede tamamlanmış ancak kuyruktan silinememiş
+		s.removeUploads(&payload)
+		return nil
+	}
+
+	if err := s.fetchUploads(&payload); err != nil {
+		return s.retrying(podcast.ID, StageQueued, err)
+	}
+	defer s.removeSpool(&payload)
+
+	// Aynı içerik başka bir podcast için zaten yüklenmişse mevcut nesne kullanılır
+	s.setStage(podcast.ID, StageUploadingAudio, 10)
+	err = s.uploadBlob(podcast.AudioKey, func() error {
+		return s.uploadSpooled(podcast.AudioKey, payload.AudioPath, payload.AudioFormat.MimeType())
+	})
+	if err != nil {
+		return s.retrying(podcast.ID, StageUploadingAudio, err)
+	}
+
+	s.setStage(podcast.ID, StageUploadingCover, 50)
+	err = s.uploadBlob(podcast.CoverKey, func() error {
+		return s.uploadSpooledCover(podcast.CoverKey, payload.CoverPath)
+	})
+	if err != nil {
+		return s.retrying(podcast.ID, StageUploadingCover, err)
+	}
+	podcast.HasCoverRenditions = true
+
+	// Dalga formu üretilemezse podcast yine de yayınlanır
+	s.setStage(podcast.ID, StageWaveform, 70)
+	podcast.WaveformKey, err = s.uploadWaveform(payload.AudioPath, payload.AudioFormat, podcast.AudioKey)
+	if err != nil {
+		fmt.Printf("Media - HATA: Dalga formu oluşturulamadı. PodcastID: %d, Hata: %v\n", podcast.ID, err)
+	}
+
+	// Ses yüksekliği de isteğe bağlıdır; ölçülemezse podcast yine de yayınlanır
+	if s.loudness != nil {
+		s.setStage(podcast.ID, StageLoudness, 80)
+		if err := s.processLoudness(ctx, podcast, &payload); err != nil {
+			fmt.Printf("Media - HATA: Ses yüksekliği işlenemedi. PodcastID: %d, Hata: %v\n", podcast.ID, err)
+		}
+	}
+
+	// HLS paketi üretilemezse istemciler ses dosyasını doğrudan çalar
+	if s.hls != nil {
+		s.setStage(podcast.ID, StageHLS, 90)
+		if err := s.processHLS(ctx, podcast, &payload); err != nil {
+			fmt.Printf("Media - HATA: HLS paketi oluşturulamadı. PodcastID: %d, Hata: %v\n", podcast.ID, err)
+		}
+	}
+
+	if err := s.podcastRepo.MarkPodcastReady(podcast); err != nil {
+		if err.Error() == "podcast bulunamadı" {
+			// Podcast işlenirken silinmiş; başka podcastlerin kullanmadığı dosyalar sahipsiz kalmasın
+			s.StorageService.DeleteUnreferenced(podcast.AudioKey, podcast.WaveformKey, podcast.NormalizedAudioKey)
+			s.StorageService.DeleteUnreferenced(podcast.CoverKey, coverRenditionKeys(podcast)...)
+			deleteHLS(s.StorageService, podcast)
+			s.removeUploads(&payload)
+			return nil
+		}
+		return s.retrying(podcast.ID, StageWaveform, err)
+	}
+
+	s.removeUploads(&payload)
+	s.setStatus(&dto.PodcastStatusResponse{
+		PodcastID: podcast.ID,
+		Status:    model.PodcastStatusReady,
+		Progress:  100,
+	})
+	return nil
+}
+
+// retrying, başarısız denemeyi duruma yansıtır ve hatayı tekrar denenmesi için kuyruğa iletir
+func (s *MediaService) retrying(podcastID uint, stage string, err error) error {
+	s.setStatus(&dto.PodcastStatusResponse{
+		PodcastID: podcastID,
+		Status:    model.PodcastStatusProcessing,
+		Stage:     stage,
+		Error:     err.Error(),
+	})
+	return err
+}
+
+// onDeadLetter, deneme hakkı biten işin podcast'ini başarısız olarak işaretler
+func (s *MediaService) onDeadLetter(ctx context.Context, job *queue.Job, err error) {
+	if job.Type != JobProcessPodcast {
+		return
+	}
+
+	var payload processPodcastPayload
+	if decodeErr := job.Decode(&payload); decodeErr != nil {
+		return
+	}
+
+	if markErr := s.podcastRepo.MarkPodcastFailed(payload.PodcastID); markErr != nil {
+		fmt.Printf("Media - HATA: Podcast başarısız olarak işaretlenemedi. PodcastID: %d, Hata: %v\n", payload.PodcastID, markErr)
+	}
+	s.removeUploads(&payload)
+	s.setStatus(&dto.PodcastStatusResponse{
+		PodcastID: payload.PodcastID,
+		Status:    model.PodcastStatusFailed,
+		Error:     err.Error(),
+	})
+}
+
+// uploadBlob, içerik adresli dosya depoda yoksa upload ile yükler ve yüklendi olarak işaretler
+func (s *MediaService) uploadBlob(key string, upload func() error) error {
+	stored, err := s.StorageService.IsBlobStored(key)
+	if err != nil {
+		return err
+	}
+	if stored {
+		return nil
+	}
+	if err := upload(); err != nil {
+		return err
+	}
+	return s.StorageService.MarkBlobStored(key)
+}
+
+func (s *MediaService) uploadSpooled(key, spoolPath, contentType string) error {
+	file, err := os.Open(spoolPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return s.StorageService.UploadStream(key, file, contentType)
+}
+
+// UploadCover, kapak fotoğrafını meta veri olmadan yeniden kodlayarak ve kare sürümleriyle
+// birlikte yükler. Sürümler cover.RenditionKey ile türetilen anahtarlara yazılır.
+func (s *MediaService) UploadCover(img *cover.Image, key string) error {
+	var buf bytes.Buffer
+	if err := img.Encode(&buf); err != nil {
+		return err
+	}
+	if err := s.StorageService.UploadBytes(key, buf.Bytes(), img.ContentType()); err != nil {
+		return err
+	}
+
+	for _, size := range cover.Sizes {
+		buf.Reset()
+		if err := img.EncodeRendition(&buf, size); err != nil {
+			return err
+		}
+		if err := s.StorageService.UploadBytes(cover.RenditionKey(key, size), buf.Bytes(), "image/jpeg"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *MediaService) uploadSpooledCover(key, spoolPath string) error {
+	file, err := os.Open(spoolPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	img, err := cover.Decode(file)
+	if err != nil {
+		return err
+	}
+	return s.UploadCover(img, key)
+}
+
+// uploadWaveform, ses dosyasını çözerek dalga formunu üretir ve ses dosyasının yanına
+// "<ses anahtarı>.waveform.json" olarak yükler. Çözücüsü olmayan formatlarda boş anahtar döner.
+func (s *MediaService) uploadWaveform(spoolPath string, format audio.Format, audioKey string) (string, error) {
+	file, err := os.Open(spoolPath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	decoder, err := audio.NewDecoder(file, format)
+	if errors.Is(err, audio.ErrNoDecoder) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	waveform, err := audio.GenerateWaveform(decoder, waveformPixels)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := json.Marshal(waveform)
+	if err != nil {
+		return "", err
+	}
+
+	key := waveformKey(audioKey)
+	if err := s.StorageService.UploadBytes(key, data, "application/json"); err != nil {
+		return "", err
+	}
+
+	return key, nil
+}
+
+// waveformKey, ses dosyasının yanındaki dalga formu JSON'ının anahtarıdır
+func waveformKey(audioKey string) string {
+	return strings.TrimSuffix(audioKey, path.Ext(audioKey)) + ".waveform.json"
+}
+
+// normalizedAudioKey, ses dosyasının yanındaki normalize edilmiş sürümün anahtarıdır
+func normalizedAudioKey(audioKey string) string {
+	ext := path.Ext(audioKey)
+	return strings.TrimSuffix(audioKey, ext) + ".normalized" + ext
+}
+
+// processLoudness, ses yüksekliğini ölçer ve mümkünse normalize edilmiş sürümü ses dosyasının
+// yanına "<ses anahtarı>.normalized.<uzantı>" olarak yükler
+func (s *MediaService) processLoudness(ctx context.Context, podcast *model.Podcast, payload *processPodcastPayload) error {
+	loudness, err := s.loudness.Measure(ctx, payload.AudioPath, payload.AudioFormat)
+	if err != nil {
+		return err
+	}
+	// Tamamen sessiz dosyalarda ses yüksekliği tanımsızdır
+	if math.IsInf(loudness.Integrated, 0) || math.IsNaN(loudness.Integrated) {
+		return nil
+	}
+	podcast.LoudnessLUFS = &loudness.Integrated
+	if !math.IsInf(loudness.Peak, 0) && !math.IsNaN(loudness.Peak) {
+		podcast.LoudnessPeak = &loudness.Peak
+	}
+
+	ext := path.Ext(podcast.AudioKey)
+	normalized, err := os.CreateTemp(s.spoolDir, "normalized-*"+ext)
+	if err != nil {
+		return err
+	}
+	normalized.Close()
+	defer os.Remove(normalized.Name())
+
+	_, err = s.loudness.Normalize(ctx, payload.AudioPath, normalized.Name(), payload.AudioFormat, audio.NormalizeOptions{
+		Target:     s.loudnessTarget,
+		SampleRate: podcast.SampleRate,
+	})
+	if errors.Is(err, audio.ErrNoNormalizer) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	key := normalizedAudioKey(podcast.AudioKey)
+	if err := s.uploadSpooled(key, normalized.Name(), payload.AudioFormat.MimeType()); err != nil {
+		return err
+	}
+	podcast.NormalizedAudioKey = key
+	return nil
+}
+
+// processHLS, sesi HLS olarak paketler ve hls/<podcast_id>/ altına yükler. Her denemede
+// aynı anahtarlar kullanıldığından iş tekrar denendiğinde dosyalar üzerine yazılır.
+func (s *MediaService) processHLS(ctx context.Context, podcast *model.Podcast, payload *processPodcastPayload) error {
+	dir, err := os.MkdirTemp(s.spoolDir, "hls-*")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	if err := s.hls.Package(ctx, payload.AudioPath, dir); err != nil {
+		return err
+	}
+
+	prefix := hlsPrefix(podcast.ID)
+	err = filepath.WalkDir(dir, func(p string, entry os.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		return s.uploadSpooled(prefix+filepath.ToSlash(rel), p, hlsContentType(p))
+	})
+	if err != nil {
+		return err
+	}
+
+	podcast.HLSKey = prefix + audio.HLSMasterPlaylist
+	return nil
+}
+
+// hlsPrefix, podcast'in HLS dosyalarının bulunduğu dizindir
+func hlsPrefix(podcastID uint) string {
+	return fmt.Sprintf("hls/%d/", podcastID)
+}
+
+func hlsContentType(name string) string {
+	if strings.HasSuffix(name, ".m3u8") {
+		return "application/vnd.apple.mpegurl"
+	}
+	return "video/mp2t"
+}
+
+// deleteHLS, podcast'in HLS paketini siler. Hatalar yalnızca loglanır.
+func deleteHLS(storageService *StorageService, podcast *model.Podcast) {
+	if podcast.HLSKey == "" {
+		return
+	}
+	if _, err := storageService.DeletePrefix(hlsPrefix(podcast.ID)); err != nil {
+		fmt.Printf("Media - HATA: HLS paketi silinemedi. PodcastID: %d, Hata: %v\n", podcast.ID, err)
+	}
+}
+
+// fetchUploads, depoda bekleyen dosyaları işi alan worker'ın spool dizinine indirir
+func (s *MediaService) fetchUploads(payload *processPodcastPayload) error {
+	var err error
+	payload.AudioPath, err = s.SpoolObject(payload.AudioUploadKey, "."+string(payload.AudioFormat))
+	if err != nil {
+		return fmt.Errorf("ses dosyası indirilemedi: %v", err)
+	}
+	payload.CoverPath, err = s.SpoolObject(payload.CoverUploadKey, path.Ext(payload.CoverUploadKey))
+	if err != nil {
+		s.removeSpool(payload)
+		return fmt.Errorf("kapak fotoğrafı indirilemedi: %v", err)
+	}
+	return nil
+}
+
+// removeUploads, dosyaların depodaki geçici kopyalarını siler. Hatalar yalnızca loglanır.
+func (s *MediaService) removeUploads(payload *processPodcastPayload) {
+	for _, key := range []string{payload.AudioUploadKey, payload.CoverUploadKey} {
+		if key == "" {
+			continue
+		}
+		if err := s.StorageService.DeleteFile(key); err != nil {
+			fmt.Printf("Media - HATA: Geçici dosya silinemedi. Key: %s, Hata: %v\n", key, err)
+		}
+	}
+}
+
+func (s *MediaService) removeSpool(payload *processPodcastPayload) {
+	for _, p := range []string{payload.AudioPath, payload.CoverPath} {
+		if p == "" {
+			continue
+		}
+		if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+			fmt.Printf("Media - HATA: Geçici dosya silinemedi. Yol: %s, Hata: %v\n", p, err)
+		}
+	}
+}
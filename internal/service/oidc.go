@@ -0,0 +1,324 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"regexp"
+	"shortcast/internal/config"
+	"shortcast/internal/dto"
+	"shortcast/internal/model"
+	"shortcast/internal/oidc"
+	"shortcast/internal/repository"
+	"shortcast/internal/utils"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// OIDCStateTTL, giriş akışının sağlayıcıdan dönmek için süresidir
+const OIDCStateTTL = 10 * time.Minute
+
+var usernameCleaner = regexp.MustCompile(`[^a-z0-9_.]+`)
+
+type OIDCService struct {
+	providers    map[string]*oidc.Provider
+	identityRepo *repository.IdentityRepository
+	userRepo     *repository.UserRepository
+	authService  *AuthService
+}
+
+func NewOIDCService(providers []config.OIDCProviderConfig, identityRepo *repository.IdentityRepository, userRepo *repository.UserRepository, authService *AuthService) *OIDCService {
+	s := &OIDCService{
+		providers:    make(map[string]*oidc.Provider),
+		identityRepo: identityRepo,
+		userRepo:     userRepo,
+		authService:  authService,
+	}
+	for _, providerCfg := range providers {
+		s.providers[providerCfg.Name] = oidc.NewProvider(providerCfg)
+	}
+	return s
+}
+
+func (s *OIDCService) Providers() []string {
+	names := make([]string, 0, len(s.providers))
+	for name := range s.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// AuthorizationURL, sağlayıcıya yönlendirme adresini ve state'i üretir. State, akışı başlatan
+// tarayıcıya cookie olarak verilmeli ve callback'te geri istenmelidir. linkUserID sıfırdan
+// farklıysa callback'te yeni bir kullanıcı açılmaz, sağlayıcı hesabı linkSessionID oturumu
+// hâlâ açıksa bu kullanıcıya bağlanır.
+func (s *OIDCService) AuthorizationURL(ctx context.Context, providerName string, linkUserID, linkSessionID uint) (string, string, error) {
+	provider, ok := s.providers[providerName]
+	if !ok {
+		return "", "", errors.New("sağlayıcı bulunamadı")
+	}
+
+	state, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return "", "", err
+	}
+	nonce, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return "", "", err
+	}
+	verifier, err := oidc.GenerateCodeVerifier()
+	if err != nil {
+		return "", "", err
+	}
+
+	err = s.identityRepo.SaveState(state, repository.OIDCState{
+		Provider:      providerName,
+		CodeVerifier:  verifier,
+		Nonce:         nonce,
+		LinkUserID:    linkUserID,
+		LinkSessionID: linkSessionID,
+	}, OIDCStateTTL)
+	if err != nil {
+		return "", "", err
+	}
+
+	authURL, err := provider.AuthCodeURL(ctx, state, nonce, oidc.CodeChallenge(verifier))
+	if err != nil {
+		return "", "", err
+	}
+	return authURL, state, nil
+}
+
+// Callback, sağlayıcıdan dönen kodu ID token ile değiştirir, kullanıcıyı bulur, bağlar
+// veya oluşturur ve Shortcast oturumunu açar. browserState, akışı başlatan tarayıcının
+// cookie'sindeki state'tir; başka bir tarayıcıda başlatılmış akışlar (login CSRF) reddedilir.
+func (s *OIDCService) Callback(ctx context.Context, providerName, state, browserState, code, userAgent, ip string) (*dto.LoginResponse, error) {
+	provider, ok := s.providers[providerName]
+	if !ok {
+		return nil, errors.New("sağlayıcı bulunamadı")
+	}
+
+	if browserState == "" || subtle.ConstantTimeCompare([]byte(state), []byte(browserState)) != 1 {
+		return nil, errors.New("geçersiz veya süresi dolmuş giriş isteği")
+	}
+
+	stored, err := s.identityRepo.ConsumeState(state)
+	if err != nil {
+		return nil, err
+	}
+	if stored.Provider != providerName {
+		return nil, errors.New("geçersiz veya süresi dolmuş giriş isteği")
+	}
+
+	rawIDToken, err := provider.Exchange(ctx, code, stored.CodeVerifier)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := provider.VerifyIDToken(ctx, rawIDToken, stored.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	var user *model.User
+	if stored.LinkUserID != 0 {
+		var active bool
+		active, err = s.authService.IsSessionActive(stored.LinkUserID, stored.LinkSessionID)
+		if err != nil {
+			return nil, err
+		}
+		if !active {
+			return nil, errors.New("bağlama isteğini başlatan oturum sonlandırılmış")
+		}
+		user, err = s.linkIdentity(stored.LinkUserID, providerName, claims)
+	} else {
+		user, err = s.resolveUser(providerName, claims)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return s.authService.StartLogin(user, userAgent, ip)
+}
+
+func (s *OIDCService) GetIdentities(userID uint) ([]dto.IdentityResponse, error) {
+	identities, err := s.identityRepo.GetIdentitiesByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.IdentityResponse, 0)
+	for _, identity := range *identities {
+		response = append(response, dto.IdentityResponse{
+			ID:        identity.ID,
+			Provider:  identity.Provider,
+			Email:     identity.Email,
+			CreatedAt: identity.CreatedAt,
+		})
+	}
+	return response, nil
+}
+
+// Unlink, bağlı bir sağlayıcı hesabını kaldırır. Şifresi olmayan bir kullanıcının
+// son bağlı hesabı kaldırılamaz; aksi halde hesaba giriş yolu kalmaz.
+func (s *OIDCService) Unlink(userID, identityID uint) error {
+	user, err := s.userRepo.GetUserByID(userID)
+	if err != nil {
+		return err
+	}
+
+	if user.Password == "" {
+		count, err := s.identityRepo.CountIdentitiesByUserID(userID)
+		if err != nil {
+			return err
+		}
+		if count <= 1 {
+			return errors.New("son bağlı hesap kaldırılamaz, önce bir şifre belirleyin")
+		}
+	}
+
+	return s.identityRepo.DeleteIdentity(identityID, userID)
+}
+
+func (s *OIDCService) linkIdentity(userID uint, providerName string, claims *oidc.Claims) (*model.User, error) {
+	user, err := s.userRepo.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	identity, err := s.identityRepo.GetIdentity(providerName, claims.Subject)
+	if err == nil {
+		if identity.UserID != user.ID {
+			return nil, errors.New("bu sağlayıcı hesabı başka bir kullanıcıya bağlı")
+		}
+		return user, nil
+	}
+	if err != gorm.ErrRecordNotFound {
+		return nil, err
+	}
+
+	err = s.identityRepo.CreateIdentity(&model.UserIdentity{
+		UserID:   user.ID,
+		Provider: providerName,
+		Subject:  claims.Subject,
+		Email:    claims.Email,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// resolveUser, sağlayıcı hesabına bağlı kullanıcıyı döndürür. Bağlı hesap yoksa
+// doğrulanmış aynı e-posta adresine sahip kullanıcıya bağlar, o da yoksa yeni kullanıcı oluşturur.
+func (s *OIDCService) resolveUser(providerName string, claims *oidc.Claims) (*model.User, error) {
+	identity, err := s.identityRepo.GetIdentity(providerName, claims.Subject)
+	if err == nil {
+		return s.userRepo.GetUserByID(identity.UserID)
+	}
+	if err != gorm.ErrRecordNotFound {
+		return nil, err
+	}
+
+	if claims.Email == "" {
+		return nil, errors.New("sağlayıcı e-posta adresi paylaşmadı")
+	}
+
+	existing, err := s.userRepo.GetUserByEmail(claims.Email)
+	if err == nil {
+		// Adresin sahipliği iki tarafta da doğrulanmadan hesaplar birleştirilmez;
+		// aksi halde adresi önceden kaydeden biri hesabı ele geçirebilir
+		if !claims.EmailVerified {
+			return nil, errors.New("bu e-posta adresiyle kayıtlı bir hesap var, giriş yapıp sağlayıcıyı hesabınıza bağlayın")
+		}
+		if existing.EmailVerifiedAt == nil {
+			return nil, errors.New("bu e-posta adresiyle doğrulanmamış bir hesap var, önce şifre sıfırlama ile adresi doğrulayın")
+		}
+		return s.linkIdentity(existing.ID, providerName, claims)
+	}
+	if err != gorm.ErrRecordNotFound {
+		return nil, err
+	}
+
+	return s.createUser(providerName, claims)
+}
+
+func (s *OIDCService) createUser(providerName string, claims *oidc.Claims) (*model.User, error) {
+	username, err := s.availableUsername(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	firstName, lastName := claims.GivenName, claims.FamilyName
+	if firstName == "" && lastName == "" {
+		firstName, lastName, _ = strings.Cut(claims.Name, " ")
+	}
+	if firstName == "" {
+		firstName = username
+	}
+
+	// Şifre boş bırakılır; bcrypt hiçbir girişi boş hash ile eşleştirmez.
+	// Kullanıcı isterse şifre sıfırlama akışıyla şifre belirleyebilir.
+	user := &model.User{
+		FirstName: truncate(firstName, 100),
+		LastName:  truncate(lastName, 100),
+		Username:  username,
+		Email:     claims.Email,
+		Role:      model.RoleUser,
+	}
+	if claims.EmailVerified {
+		now := time.Now()
+		user.EmailVerifiedAt = &now
+	}
+
+	identity := &model.UserIdentity{
+		Provider: providerName,
+		Subject:  claims.Subject,
+		Email:    claims.Email,
+	}
+	if err := s.identityRepo.CreateUserWithIdentity(user, identity); err != nil {
+		return nil, err
+	}
+
+	if user.EmailVerifiedAt == nil {
+		if err := s.authService.SendVerificationEmail(user); err != nil {
+			fmt.Printf("OIDC - HATA: Doğrulama e-postası gönderilemedi. UserID: %d, Hata: %v\n", user.ID, err)
+		}
+	}
+
+	return user, nil
+}
+
+// availableUsername, sağlayıcıdaki kullanıcı adından veya e-posta adresinden boşta olan bir kullanıcı adı türetir
+func (s *OIDCService) availableUsername(claims *oidc.Claims) (string, error) {
+	base := claims.PreferredUsername
+	if base == "" {
+		base, _, _ = strings.Cut(claims.Email, "@")
+	}
+	base = usernameCleaner.ReplaceAllString(strings.ToLower(base), "")
+	if base == "" {
+		base = "user"
+	}
+	base = truncate(base, 30)
+
+	candidate := base
+	for i := 0; i < 5; i++ {
+		_, err := s.userRepo.GetUserByUsername(candidate)
+		if err == gorm.ErrRecordNotFound {
+			return candidate, nil
+		}
+		if err != nil {
+			return "", err
+		}
+
+		candidate = fmt.Sprintf("%s_%04d", base, rand.IntN(10000))
+	}
+
+	return "", errors.New("kullanıcı adı oluşturulamadı")
+}
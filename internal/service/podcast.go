@@ -1,592 +1,918 @@
-package service
-
-import (
-	"errors"
-	"fmt"
-	"mime/multipart"
-	"shortcast/internal/config"
-	"shortcast/internal/dto"
-	"shortcast/internal/model"
-	"shortcast/internal/repository"
-	"shortcast/internal/utils"
-	"time"
-)
-
-type PodcastService struct {
-	podcastRepo  *repository.PodcastRepository
-	userRepo     *repository.UserRepository
-	R2Service    *R2Service
-	RedisService *RedisService
-	config       *config.Config
-}
-
-func NewPodcastService(podcastRepo *repository.PodcastRepository, userRepo *repository.UserRepository, r2Service *R2Service, redisService *RedisService, cfg *config.Config) *PodcastService {
-	return &PodcastService{
-		podcastRepo:  podcastRepo,
-		userRepo:     userRepo,
-		R2Service:    r2Service,
-		RedisService: redisService,
-		config:       cfg,
-	}
-}
-
-// getSignedURL, R2'den imzalı URL alır veya Redis'ten önbelleğe alınmış URL'i döndürür
-func (s *PodcastService) getSignedURL(key string) (string, error) {
-	// Önce Redis'ten kontrol et
-	cachedURL, err := s.RedisService.GetSignedURL(key)
-	if err != nil {
-		return "", fmt.Errorf("redis'ten URL alınırken hata: %v", err)
-	}
-	if cachedURL != "" {
-		return cachedURL, nil
-	}
-
-	// Redis'te yoksa R2'den al
-	url, err := utils.GenerateSignedURL(key, s.config)
-	if err != nil {
-		return "", err
-	}
-
-	// Redis'e kaydet (24 saat geçerli)
-	err = s.RedisService.SetSignedURL(key, url, 24*time.Hour)
-	if err != nil {
-		// Redis hatası kritik değil, URL'i yine de döndür
-		fmt.Printf("Redis'e URL kaydedilirken hata: %v\n", err)
-	}
-
-	return url, nil
-}
-
-// getMultipleSignedURLs, birden fazla imzalı URL alır veya Redis'ten önbelleğe alınmış URL'leri döndürür
-func (s *PodcastService) getMultipleSignedURLs(keys []string) (map[string]string, error) {
-	// Önce Redis'ten kontrol et
-	cachedURLs, err := s.RedisService.GetMultipleSignedURLs(keys)
-	if err != nil {
-		return nil, fmt.Errorf("redis'ten URL'ler alınırken hata: %v", err)
-	}
-
-	// Eksik URL'leri bul
-	missingKeys := make([]string, 0)
-	for _, key := range keys {
-		if _, exists := cachedURLs[key]; !exists {
-			missingKeys = append(missingKeys, key)
-		}
-	}
-
-	// Eksik URL'leri R2'den al
-	if len(missingKeys) > 0 {
-		missingURLs, err := utils.GenerateSignedURLs(missingKeys, s.config)
-		if err != nil {
-			return nil, err
-		}
-
-		// Redis'e kaydet (24 saat geçerli)
-		err = s.RedisService.SetMultipleSignedURLs(missingURLs, 24*time.Hour)
-		if err != nil {
-			// Redis hatası kritik değil, URL'leri yine de döndür
-			fmt.Printf("Redis'e URL'ler kaydedilirken hata: %v\n", err)
-		}
-
-		// Tüm URL'leri birleştir
-		for key, url := range missingURLs {
-			cachedURLs[key] = url
-		}
-	}
-
-	return cachedURLs, nil
-}
-
-func (s *PodcastService) UploadPodcast(podcastDTO *dto.UploadPodcastRequest, audioFile, coverFile *multipart.FileHeader) (*dto.PodcastResponse, error) {
-	// Kullanıcı bilgilerini al
-	user, err := s.userRepo.GetUserByID(podcastDTO.UserID)
-	if err != nil {
-		return nil, fmt.Errorf("kullanıcı bulunamadı: %v", err)
-	}
-
-	// R2'ye yükle
-	audioKey, err := s.R2Service.UploadFile(audioFile, "audio")
-	if err != nil {
-		return nil, err
-	}
-
-	coverKey, err := s.R2Service.UploadFile(coverFile, "covers")
-	if err != nil {
-		// Hata durumunda audio dosyasını da sil
-		s.R2Service.DeleteFile(audioKey)
-		return nil, err
-	}
-
-	// Podcast modeli oluştur
-	podcast := &model.Podcast{
-		Title:    podcastDTO.Title,
-		Category: podcastDTO.Category,
-		AudioKey: audioKey,
-		CoverKey: coverKey,
-		UserID:   podcastDTO.UserID,
-	}
-
-	// Veritabanına kaydet
-	if err := s.podcastRepo.SavePodcast(podcast); err != nil {
-		// Hata durumunda yüklenen dosyaları sil
-		s.R2Service.DeleteFile(audioKey)
-		s.R2Service.DeleteFile(coverKey)
-		return nil, err
-	}
-
-	// İmzalı URL'leri oluştur
-	audioURL, err := s.getSignedURL(audioKey)
-	if err != nil {
-		return nil, err
-	}
-
-	coverURL, err := s.getSignedURL(coverKey)
-	if err != nil {
-		return nil, err
-	}
-
-	return &dto.PodcastResponse{
-		ID:       podcast.ID,
-		Title:    podcast.Title,
-		Category: podcast.Category,
-		AudioURL: audioURL,
-		CoverURL: coverURL,
-		User: dto.UserDTO{
-			ID:        user.ID,
-			FirstName: user.FirstName,
-			LastName:  user.LastName,
-			Username:  user.Username,
-		},
-	}, nil
-}
-
-func (s *PodcastService) GetPodcastByID(id uint) (*dto.PodcastResponse, error) {
-	podcast, err := s.podcastRepo.GetPodcastByID(id)
-	if err != nil {
-		return nil, err
-	}
-
-	// İmzalı URL'leri oluştur
-	audioURL, err := s.getSignedURL(podcast.AudioKey)
-	if err != nil {
-		return nil, err
-	}
-
-	coverURL, err := s.getSignedURL(podcast.CoverKey)
-	if err != nil {
-		return nil, err
-	}
-
-	return &dto.PodcastResponse{
-		ID:       podcast.ID,
-		Title:    podcast.Title,
-		Category: podcast.Category,
-		AudioURL: audioURL,
-		CoverURL: coverURL,
-		User: dto.UserDTO{
-			ID:        podcast.User.ID,
-			FirstName: podcast.User.FirstName,
-			LastName:  podcast.User.LastName,
-			Username:  podcast.User.Username,
-		},
-	}, nil
-}
-
-func (s *PodcastService) GetUserPodcasts(userID uint) ([]dto.PodcastResponse, error) {
-	podcasts, err := s.podcastRepo.GetPodcastsByUserID(userID)
-	if err != nil {
-		return nil, err
-	}
-
-	response := make([]dto.PodcastResponse, 0)
-	for _, podcast := range *podcasts {
-		// İmzalı URL'leri oluştur
-		audioURL, err := s.getSignedURL(podcast.AudioKey)
-		if err != nil {
-			return nil, err
-		}
-
-		coverURL, err := s.getSignedURL(podcast.CoverKey)
-		if err != nil {
-			return nil, err
-		}
-
-		response = append(response, dto.PodcastResponse{
-			ID:       podcast.ID,
-			Title:    podcast.Title,
-			Category: podcast.Category,
-			AudioURL: audioURL,
-			CoverURL: coverURL,
-			User: dto.UserDTO{
-				ID:        podcast.User.ID,
-				FirstName: podcast.User.FirstName,
-				LastName:  podcast.User.LastName,
-				Username:  podcast.User.Username,
-			},
-		})
-	}
-	return response, nil
-}
-
-func (s *PodcastService) DiscoverPodcasts(req *dto.PodcastDiscoverRequest) (*dto.PodcastCursor, error) {
-	limit := req.Limit
-	if limit <= 0 {
-		limit = 10 // Varsayılan limit
-	}
-
-	podcasts, err := s.podcastRepo.DiscoverPodcasts(req.Cursor, req.Direction, limit)
-	if err != nil {
-		return nil, err
-	}
-
-	var response dto.PodcastCursor
-	response.Podcasts = make([]dto.PodcastResponse, 0)
-
-	hasMore := len(*podcasts) > limit
-	actualPodcasts := *podcasts
-	if hasMore {
-		actualPodcasts = actualPodcasts[:limit]
-		nextID := actualPodcasts[len(actualPodcasts)-1].ID + 1
-		response.NextCursor = &nextID
-	}
-
-	// Tüm audio ve cover key'leri topla
-	keys := make([]string, 0, len(actualPodcasts)*2)
-	for _, podcast := range actualPodcasts {
-		keys = append(keys, podcast.AudioKey, podcast.CoverKey)
-	}
-
-	// Tüm URL'leri tek seferde al
-	urls, err := s.getMultipleSignedURLs(keys)
-	if err != nil {
-		return nil, err
-	}
-
-	for _, podcast := range actualPodcasts {
-		response.Podcasts = append(response.Podcasts, dto.PodcastResponse{
-			ID:       podcast.ID,
-			Title:    podcast.Title,
-			Category: podcast.Category,
-			AudioURL: urls[podcast.AudioKey],
-			CoverURL: urls[podcast.CoverKey],
-			User: dto.UserDTO{
-				ID:        podcast.User.ID,
-				FirstName: podcast.User.FirstName,
-				LastName:  podcast.User.LastName,
-				Username:  podcast.User.Username,
-			},
-		})
-	}
-
-	response.HasNext = hasMore
-	response.HasPrevious = req.Cursor != nil
-
-	return &response, nil
-}
-
-func (s *PodcastService) UpdatePodcast(id uint, userID uint, req *dto.UpdatePodcastRequest) (*dto.PodcastResponse, error) {
-	// Podcast'i bul
-	existingPodcast, err := s.podcastRepo.GetPodcastByID(id)
-	if err != nil {
-		return nil, err
-	}
-
-	// Yetki kontrolü
-	if existingPodcast.UserID != userID {
-		return nil, errors.New("bu podcast'i düzenleme yetkiniz yok")
-	}
-
-	// Güncelleme
-	existingPodcast.Title = req.Title
-	existingPodcast.Category = req.Category
-
-	// Veritabanını güncelle
-	if err := s.podcastRepo.UpdatePodcast(id, existingPodcast); err != nil {
-		return nil, err
-	}
-
-	// İmzalı URL'leri oluştur
-	audioURL, err := s.getSignedURL(existingPodcast.AudioKey)
-	if err != nil {
-		return nil, err
-	}
-
-	coverURL, err := s.getSignedURL(existingPodcast.CoverKey)
-	if err != nil {
-		return nil, err
-	}
-
-	return &dto.PodcastResponse{
-		ID:       existingPodcast.ID,
-		Title:    existingPodcast.Title,
-		Category: existingPodcast.Category,
-		AudioURL: audioURL,
-		CoverURL: coverURL,
-		User: dto.UserDTO{
-			ID:        existingPodcast.User.ID,
-			FirstName: existingPodcast.User.FirstName,
-			LastName:  existingPodcast.User.LastName,
-			Username:  existingPodcast.User.Username,
-		},
-	}, nil
-}
-
-func (s *PodcastService) DeletePodcast(id uint, userID uint) error {
-	fmt.Printf("Podcast - Silme işlemi başlatıldı. PodcastID: %d, UserID: %d\n", id, userID)
-
-	podcast, err := s.podcastRepo.GetPodcastByID(id)
-	if err != nil {
-		fmt.Printf("Podcast - HATA: Podcast bulunamadı. PodcastID: %d, Hata: %v\n", id, err)
-		return err
-	}
-
-	fmt.Printf("Podcast - Podcast bulundu. PodcastID: %d, Title: %s\n", podcast.ID, podcast.Title)
-
-	if podcast.UserID != userID {
-		fmt.Printf("Podcast - HATA: Yetkisiz silme denemesi. PodcastID: %d, İsteyen UserID: %d, Sahip UserID: %d\n", id, userID, podcast.UserID)
-		return errors.New("bu podcast'i silme yetkiniz yok")
-	}
-
-	fmt.Printf("Podcast - Yetki kontrolü başarılı. Dosya silme işlemlerine başlanıyor.\n")
-
-	// Cloudflare R2'den dosyaları sil
-	if podcast.AudioKey != "" {
-		fmt.Printf("Podcast - Ses dosyası silme işlemi başlatıldı. Key: %s\n", podcast.AudioKey)
-		if err := s.R2Service.DeleteFile(podcast.AudioKey); err != nil {
-			fmt.Printf("Podcast - HATA: Ses dosyası R2'den silinirken hata oluştu: %v\n", err)
-			return fmt.Errorf("ses dosyası silinirken hata oluştu: %v", err)
-		}
-		fmt.Printf("Podcast - Ses dosyası R2'den başarıyla silindi.\n")
-	} else {
-		fmt.Printf("Podcast - Ses dosyası Key'i boş, silme işlemi atlanıyor.\n")
-	}
-
-	if podcast.CoverKey != "" {
-		fmt.Printf("Podcast - Kapak fotoğrafı silme işlemi başlatıldı. Key: %s\n", podcast.CoverKey)
-		if err := s.R2Service.DeleteFile(podcast.CoverKey); err != nil {
-			fmt.Printf("Podcast - HATA: Kapak fotoğrafı R2'den silinirken hata oluştu: %v\n", err)
-			return fmt.Errorf("kapak fotoğrafı silinirken hata oluştu: %v", err)
-		}
-		fmt.Printf("Podcast - Kapak fotoğrafı R2'den başarıyla silindi.\n")
-	} else {
-		fmt.Printf("Podcast - Kapak fotoğrafı Key'i boş, silme işlemi atlanıyor.\n")
-	}
-
-	fmt.Printf("Podcast - Veritabanından silme işlemi başlatılıyor. PodcastID: %d\n", id)
-	err = s.podcastRepo.DeletePodcast(id)
-	if err != nil {
-		fmt.Printf("Podcast - HATA: Veritabanından silinirken hata oluştu: %v\n", err)
-		return err
-	}
-
-	fmt.Printf("Podcast - Silme işlemi başarıyla tamamlandı. PodcastID: %d\n", id)
-	return nil
-}
-
-func (s *PodcastService) LikePodcast(podcastID, userID uint) (*dto.LikeResponse, error) {
-	// Kullanıcı kontrolü
-	if _, err := s.userRepo.GetUserByID(userID); err != nil {
-		return nil, errors.New("kullanıcı bulunamadı")
-	}
-
-	// Podcast kontrolü
-	if _, err := s.podcastRepo.GetPodcastByID(podcastID); err != nil {
-		return nil, err
-	}
-
-	liked, err := s.podcastRepo.LikePodcast(podcastID, userID)
-	if err != nil {
-		return nil, err
-	}
-
-	return &dto.LikeResponse{
-		PodcastID: podcastID,
-		UserID:    userID,
-		Liked:     liked,
-	}, nil
-}
-
-func (s *PodcastService) GetLikedPodcasts(userID uint) ([]dto.PodcastResponse, error) {
-	podcasts, err := s.podcastRepo.GetLikedPodcasts(userID)
-	if err != nil {
-		return nil, err
-	}
-
-	response := make([]dto.PodcastResponse, 0)
-	for _, p := range *podcasts {
-		// İmzalı URL'leri oluştur
-		audioURL, err := s.getSignedURL(p.AudioKey)
-		if err != nil {
-			return nil, err
-		}
-
-		coverURL, err := s.getSignedURL(p.CoverKey)
-		if err != nil {
-			return nil, err
-		}
-
-		response = append(response, dto.PodcastResponse{
-			ID:       p.ID,
-			Title:    p.Title,
-			Category: p.Category,
-			AudioURL: audioURL,
-			CoverURL: coverURL,
-			User: dto.UserDTO{
-				ID:        p.User.ID,
-				FirstName: p.User.FirstName,
-				LastName:  p.User.LastName,
-				Username:  p.User.Username,
-			},
-		})
-	}
-	return response, nil
-}
-
-func (s *PodcastService) GetPodcastsByCategory(category string) ([]dto.PodcastResponse, error) {
-	podcasts, err := s.podcastRepo.GetPodcastsByCategory(category)
-	if err != nil {
-		return nil, err
-	}
-
-	// Tüm audio ve cover key'leri topla
-	keys := make([]string, 0, len(*podcasts)*2)
-	for _, podcast := range *podcasts {
-		keys = append(keys, podcast.AudioKey, podcast.CoverKey)
-	}
-
-	// Tüm URL'leri tek seferde al
-	urls, err := s.getMultipleSignedURLs(keys)
-	if err != nil {
-		return nil, err
-	}
-
-	response := make([]dto.PodcastResponse, 0)
-	for _, podcast := range *podcasts {
-		response = append(response, dto.PodcastResponse{
-			ID:       podcast.ID,
-			Title:    podcast.Title,
-			Category: podcast.Category,
-			AudioURL: urls[podcast.AudioKey],
-			CoverURL: urls[podcast.CoverKey],
-			User: dto.UserDTO{
-				ID:        podcast.User.ID,
-				FirstName: podcast.User.FirstName,
-				LastName:  podcast.User.LastName,
-				Username:  podcast.User.Username,
-			},
-		})
-	}
-	return response, nil
-}
-
-func (s *PodcastService) AddComment(podcastID, userID uint, content string) (*dto.CommentResponse, error) {
-	// Kullanıcı kontrolü
-	user, err := s.userRepo.GetUserByID(userID)
-	if err != nil {
-		return nil, errors.New("kullanıcı bulunamadı")
-	}
-
-	// Podcast kontrolü
-	if _, err := s.podcastRepo.GetPodcastByID(podcastID); err != nil {
-		return nil, errors.New("podcast bulunamadı")
-	}
-
-	comment := &model.Comment{
-		PodcastID: podcastID,
-		UserID:    userID,
-		Content:   content,
-	}
-
-	err = s.podcastRepo.AddComment(comment)
-	if err != nil {
-		return nil, err
-	}
-
-	return &dto.CommentResponse{
-		ID:        comment.ID,
-		Content:   comment.Content,
-		UserID:    comment.UserID,
-		Username:  user.Username,
-		CreatedAt: comment.CreatedAt,
-	}, nil
-}
-
-func (s *PodcastService) GetComments(podcastID uint) ([]dto.CommentResponse, error) {
-	comments, err := s.podcastRepo.GetComments(podcastID)
-	if err != nil {
-		return nil, err
-	}
-
-	response := make([]dto.CommentResponse, 0) // Boş array ile başla
-	for _, comment := range *comments {
-		response = append(response, dto.CommentResponse{
-			ID:        comment.ID,
-			Content:   comment.Content,
-			UserID:    comment.UserID,
-			Username:  comment.User.Username,
-			CreatedAt: comment.CreatedAt,
-		})
-	}
-	return response, nil
-}
-
-func (s *PodcastService) UpdatePodcastCover(id uint, userID uint, coverFile *multipart.FileHeader) (*dto.PodcastResponse, error) {
-	// Podcast'i bul
-	existingPodcast, err := s.podcastRepo.GetPodcastByID(id)
-	if err != nil {
-		return nil, err
-	}
-
-	// Yetki kontrolü
-	if existingPodcast.UserID != userID {
-		return nil, errors.New("bu podcast'i düzenleme yetkiniz yok")
-	}
-
-	// Eski kapak fotoğrafını sil
-	if existingPodcast.CoverKey != "" {
-		if err := s.R2Service.DeleteFile(existingPodcast.CoverKey); err != nil {
-			fmt.Printf("Podcast - HATA: Eski kapak fotoğrafı silinirken hata oluştu: %v\n", err)
-			// Hata olsa bile devam et
-		}
-	}
-
-	// Yeni kapak fotoğrafını yükle
-	newCoverKey, err := s.R2Service.UploadFile(coverFile, "covers")
-	if err != nil {
-		return nil, err
-	}
-
-	// Podcast'i güncelle
-	existingPodcast.CoverKey = newCoverKey
-
-	// Veritabanını güncelle
-	if err := s.podcastRepo.UpdatePodcast(id, existingPodcast); err != nil {
-		// Hata durumunda yüklenen dosyayı sil
-		s.R2Service.DeleteFile(newCoverKey)
-		return nil, err
-	}
-
-	// İmzalı URL'leri oluştur
-	audioURL, err := s.getSignedURL(existingPodcast.AudioKey)
-	if err != nil {
-		return nil, err
-	}
-
-	coverURL, err := s.getSignedURL(newCoverKey)
-	if err != nil {
-		return nil, err
-	}
-
-	return &dto.PodcastResponse{
-		ID:       existingPodcast.ID,
-		Title:    existingPodcast.Title,
-		Category: existingPodcast.Category,
-		AudioURL: audioURL,
-		CoverURL: coverURL,
-		User: dto.UserDTO{
-			ID:        existingPodcast.User.ID,
-			FirstName: existingPodcast.User.FirstName,
-			LastName:  existingPodcast.User.LastName,
-			Username:  existingPodcast.User.Username,
-		},
-	}, nil
-}
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"os"
+	"shortcast/internal/audio"
+	"shortcast/internal/config"
+	"shortcast/internal/cover"
+	"shortcast/internal/dto"
+	"shortcast/internal/model"
+	"shortcast/internal/policy"
+	"shortcast/internal/repository"
+	"strconv"
+	"time"
+)
+
+// maxPodcastDuration, yüklenebilecek en uzun podcast süresidir
+const maxPodcastDuration = 60 * time.Second
+
+type PodcastService struct {
+	podcastRepo    *repository.PodcastRepository
+	userRepo       *repository.UserRepository
+	socialRepo     *repository.SocialRepository
+	mediaService   *MediaService
+	StorageService *StorageService
+	RedisService   *RedisService
+	config         *config.Config
+}
+
+func NewPodcastService(podcastRepo *repository.PodcastRepository, userRepo *repository.UserRepository, socialRepo *repository.SocialRepository, mediaService *MediaService, storageService *StorageService, redisService *RedisService, cfg *config.Config) *PodcastService {
+	return &PodcastService{
+		podcastRepo:    podcastRepo,
+		userRepo:       userRepo,
+		socialRepo:     socialRepo,
+		mediaService:   mediaService,
+		StorageService: storageService,
+		RedisService:   redisService,
+		config:         cfg,
+	}
+}
+
+// getMultipleSignedURLs, birden fazla imzalı URL alır veya Redis'ten önbelleğe alınmış URL'leri döndürür
+func (s *PodcastService) getMultipleSignedURLs(keys []string) (map[string]string, error) {
+	return getMultipleSignedURLs(s.RedisService, s.StorageService, keys)
+}
+
+// UploadPodcast, ses dosyasını doğrular ve podcast'i "processing" durumunda kaydeder.
+// Dosyalar R2'ye istek içinde değil, iş kuyruğu üzerinden arka planda yüklenir.
+func (s *PodcastService) UploadPodcast(podcastDTO *dto.UploadPodcastRequest, audioFile, coverFile *multipart.FileHeader) (*dto.PodcastResponse, error) {
+	// Kullanıcı bilgilerini al
+	user, err := s.userRepo.GetUserByID(podcastDTO.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("kullanıcı bulunamadı: %v", err)
+	}
+
+	if coverFile.Size > cover.MaxFileSize {
+		return nil, errCoverTooLarge
+	}
+
+	// Dosyaları işlenene kadar yerel diske al
+	audioPath, err := s.mediaService.Spool(audioFile)
+	if err != nil {
+		return nil, err
+	}
+
+	coverPath, err := s.mediaService.Spool(coverFile)
+	if err != nil {
+		os.Remove(audioPath)
+		return nil, err
+	}
+
+	return s.createPodcast(user, podcastDTO, &spooledUpload{
+		audioPath: audioPath,
+		coverPath: coverPath,
+	})
+}
+
+// spooledUpload, spool dizinine alınmış ses ve kapak dosyalarıdır. audioUploadKey ve
+// coverUploadKey, dosyaların depoda zaten bulunduğu uploads/ altındaki anahtarlardır
+// (doğrudan yüklemeler); boşsa dosyalar kuyruğa alınırken depoya yüklenir.
+type spooledUpload struct {
+	audioPath      string
+	audioUploadKey string
+	coverPath      string
+	coverUploadKey string
+}
+
+func (u *spooledUpload) remove() {
+	os.Remove(u.audioPath)
+	os.Remove(u.coverPath)
+}
+
+// createPodcast, spool dizinindeki dosyaları doğrular, gerekirse sesi kırpar ve podcast'i
+// işlenmek üzere kuyruğa alır. Spool dosyaları her durumda silinir.
+func (s *PodcastService) createPodcast(user *model.User, podcastDTO *dto.UploadPodcastRequest, upload *spooledUpload) (*dto.PodcastResponse, error) {
+	// Formatı içeriğe göre tespit et
+	audioInfo, err := probeAudioFile(upload.audioPath)
+	if err != nil {
+		upload.remove()
+		return nil, err
+	}
+
+	coverImage, err := decodeCoverFile(upload.coverPath)
+	if err != nil {
+		upload.remove()
+		return nil, err
+	}
+
+	// Uzun MP3 dosyaları ve aralık seçilen dosyalar kırpılır, ardından süre kontrol edilir
+	trimmedInfo, err := s.trimAudio(upload.audioPath, audioInfo, podcastDTO.StartMs, podcastDTO.EndMs)
+	if err != nil {
+		upload.remove()
+		return nil, err
+	}
+	if trimmedInfo != audioInfo {
+		// Kırpılan ses depodaki dosyadan farklı olduğundan kuyruğa alınırken yeniden yüklenir
+		upload.audioUploadKey = ""
+	}
+	audioInfo = trimmedInfo
+
+	// Anahtarlar kırpılmış sesin ve yüklenen kapağın özetinden türetilir; aynı dosyalar
+	// daha önce yüklenmişse worker mevcut nesneleri kullanır
+	audioKey, err := s.acquireSpooledBlob("audio", upload.audioPath, "."+string(audioInfo.Format))
+	if err != nil {
+		upload.remove()
+		return nil, err
+	}
+	coverKey, err := s.acquireSpooledBlob("covers", upload.coverPath, coverImage.Ext())
+	if err != nil {
+		s.releaseBlob(audioKey)
+		upload.remove()
+		return nil, err
+	}
+
+	// Podcast modeli oluştur; dosyalar worker tarafından yüklenir
+	podcast := &model.Podcast{
+		Title:    podcastDTO.Title,
+		Category: podcastDTO.Category,
+		AudioKey: audioKey,
+		CoverKey: coverKey,
+		Status:   model.PodcastStatusProcessing,
+		UserID:   user.ID,
+	}
+	applyAudioInfo(podcast, audioInfo)
+
+	// Veritabanına kaydet
+	if err := s.podcastRepo.SavePodcast(podcast); err != nil {
+		s.releaseBlob(audioKey)
+		s.releaseBlob(coverKey)
+		upload.remove()
+		return nil, err
+	}
+
+	if err := s.mediaService.EnqueuePodcast(podcast, upload, audioInfo.Format, "."+coverImage.Format); err != nil {
+		s.discardPodcast(podcast)
+		upload.remove()
+		return nil, fmt.Errorf("podcast işlenmek üzere kuyruğa alınamadı: %v", err)
+	}
+	// Dosyalar depoda; worker kendi kopyalarını oradan indirir
+	upload.remove()
+
+	response := toPodcastResponse(podcast, user, nil)
+	return &response, nil
+}
+
+// probeAudioFile, ses dosyasının formatını dosya adına değil içeriğine bakarak belirler
+func probeAudioFile(path string) (*audio.Info, error) {
+	src, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+
+	info, err := audio.Probe(src)
+	if err != nil {
+		if errors.Is(err, audio.ErrUnsupportedFormat) {
+			return nil, errors.New("ses dosyası MP3, M4A (AAC), Ogg Opus veya WAV formatında olmalı")
+		}
+		return nil, fmt.Errorf("ses dosyası okunamadı: %v", err)
+	}
+
+	return info, nil
+}
+
+var errCoverTooLarge = fmt.Errorf("kapak fotoğrafı %d MB'tan büyük olamaz", cover.MaxFileSize/1024/1024)
+
+// decodeCoverFile, kapak fotoğrafının boyutunu, formatını ve ölçülerini doğrular
+func decodeCoverFile(path string) (*cover.Image, error) {
+	src, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+
+	return decodeCover(src)
+}
+
+func decodeCover(src io.ReadSeeker) (*cover.Image, error) {
+	if size, err := src.Seek(0, io.SeekEnd); err != nil {
+		return nil, err
+	} else if size > cover.MaxFileSize {
+		return nil, errCoverTooLarge
+	}
+	if _, err := src.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	img, err := cover.Decode(src)
+	if err != nil {
+		if errors.Is(err, cover.ErrUnsupportedFormat) {
+			return nil, errors.New("kapak fotoğrafı JPEG, PNG veya WebP formatında olmalı")
+		}
+		return nil, fmt.Errorf("kapak fotoğrafı geçersiz: %v", err)
+	}
+	return img, nil
+}
+
+// acquireSpooledBlob, spool dizinindeki dosyanın içerik adresli anahtarını oluşturur ve
+// dosyaya bir referans ekler. Kapaklarda anahtar yüklenen dosyanın özetinden türetilir;
+// yeniden kodlama aynı girdiden aynı çıktıyı ürettiğinden aynı kapak aynı nesneye denk gelir.
+func (s *PodcastService) acquireSpooledBlob(folder, path, ext string) (string, error) {
+	sum, size, err := HashFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	key := ContentKey(folder, sum, ext)
+	if _, err := s.StorageService.AcquireBlob(key, size); err != nil {
+		return "", err
+	}
+	return key, nil
+}
+
+// discardPodcast, kuyruğa alınamayan podcast'in kaydını siler ve dosya referanslarını bırakır.
+// Kayıt silinemezse podcast başarısız olarak işaretlenir ve referanslar podcast silinirken
+// bırakılmak üzere korunur; aksi halde aynı referanslar iki kez bırakılırdı.
+func (s *PodcastService) discardPodcast(podcast *model.Podcast) {
+	if err := s.deletePodcast(podcast); err != nil {
+		fmt.Printf("Podcast - HATA: Kuyruğa alınamayan podcast silinemedi. PodcastID: %d, Hata: %v\n", podcast.ID, err)
+		if err := s.podcastRepo.MarkPodcastFailed(podcast.ID); err != nil {
+			fmt.Printf("Podcast - HATA: Podcast başarısız olarak işaretlenemedi. PodcastID: %d, Hata: %v\n", podcast.ID, err)
+		}
+	}
+}
+
+// deletePodcast, podcast'in kaydını siler ve ses ve kapak dosyalarının referanslarını aynı
+// transaction içinde bırakır. Son referansı bırakılan dosyalar türetilmiş dosyalarıyla birlikte
+// kayıt silindikten sonra silinir; silinemeyenler sahipsiz dosya temizliğine kalır.
+func (s *PodcastService) deletePodcast(podcast *model.Podcast) error {
+	released, err := s.podcastRepo.DeletePodcast(podcast.ID, podcast.AudioKey, podcast.CoverKey)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range released {
+		var derived []string
+		switch key {
+		case podcast.AudioKey:
+			derived = []string{podcast.WaveformKey, podcast.NormalizedAudioKey}
+		case podcast.CoverKey:
+			derived = coverRenditionKeys(podcast)
+		}
+		if err := s.StorageService.PurgeBlob(key, derived...); err != nil {
+			fmt.Printf("Podcast - HATA: Dosya silinemedi. PodcastID: %d, Key: %s, Hata: %v\n", podcast.ID, key, err)
+		}
+	}
+	return nil
+}
+
+// releaseBlob, hata yollarında alınan referansı bırakır; bırakılamazsa hata yalnızca loglanır
+// ve dosya sahipsiz dosya temizliğine kalır
+func (s *PodcastService) releaseBlob(key string, derived ...string) {
+	if err := s.StorageService.ReleaseBlob(key, derived...); err != nil {
+		fmt.Printf("Podcast - HATA: Dosya referansı bırakılamadı. Key: %s, Hata: %v\n", key, err)
+	}
+}
+
+// releaseCover, podcast'in kapak fotoğrafına olan referansını bırakır; kapak sürümleri
+// kapakla birlikte yalnızca son referans bırakıldığında silinir
+func (s *PodcastService) releaseCover(podcast *model.Podcast) error {
+	return s.StorageService.ReleaseBlob(podcast.CoverKey, coverRenditionKeys(podcast)...)
+}
+
+// coverRenditionKeys, podcast'in kapağına ait kare sürümlerin anahtarlarıdır
+func coverRenditionKeys(podcast *model.Podcast) []string {
+	if !podcast.HasCoverRenditions {
+		return nil
+	}
+	return cover.RenditionKeys(podcast.CoverKey)
+}
+
+// trimAudio, MP3 dosyasını seçilen aralığa frame sınırlarından yeniden kodlamadan kırpar.
+// Aralık seçilmemişse 60 saniyeden uzun dosyaların ilk 60 saniyesi alınır. Kırpma
+// yapılamazsa süre sınırını aşan dosyalar reddedilir.
+func (s *PodcastService) trimAudio(spoolPath string, info *audio.Info, startMs, endMs *int64) (*audio.Info, error) {
+	tooLong := fmt.Errorf("Ses dosyası %d saniyeden uzun olamaz", int(maxPodcastDuration.Seconds()))
+	invalidRange := errors.New("ses dosyası için geçersiz kırpma aralığı")
+
+	if startMs == nil && endMs == nil && info.Duration <= maxPodcastDuration {
+		return info, nil
+	}
+	if info.Format != audio.FormatMP3 {
+		if info.Duration > maxPodcastDuration {
+			return nil, tooLong
+		}
+		return nil, errors.New("ses dosyası yalnızca MP3 formatında kırpılabilir")
+	}
+
+	// Milisaniyeler süreye çevrilmeden önce dosyanın süresiyle sınırlanır; çok büyük değerler
+	// çarpımda taşıp geçerli bir aralık gibi görünmesin
+	durationMs := info.Duration.Milliseconds()
+	var start time.Duration
+	if startMs != nil {
+		if *startMs < 0 || *startMs >= durationMs {
+			return nil, invalidRange
+		}
+		start = time.Duration(*startMs) * time.Millisecond
+	}
+	end := start + maxPodcastDuration
+	if endMs != nil {
+		end = time.Duration(min(*endMs, durationMs)) * time.Millisecond
+	}
+	end = min(end, info.Duration)
+
+	if start >= info.Duration || end <= start {
+		return nil, invalidRange
+	}
+	if end-start > maxPodcastDuration {
+		return nil, tooLong
+	}
+
+	trimmed, err := s.mediaService.TrimSpooled(spoolPath, start, end)
+	if err != nil {
+		fmt.Printf("Podcast - HATA: Ses dosyası kırpılamadı. Hata: %v\n", err)
+		return nil, tooLong
+	}
+	if trimmed.Duration > maxPodcastDuration {
+		return nil, tooLong
+	}
+	return trimmed, nil
+}
+
+// GetWaveform, podcast'in audiowaveform formatındaki dalga formu JSON'ını döndürür.
+// Sonuç Redis'te önbelleğe alınır; erişim kuralları önbellekten okunmadan önce kontrol edilir.
+func (s *PodcastService) GetWaveform(id, viewerID uint) ([]byte, error) {
+	podcast, err := s.getVisiblePodcast(id, viewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	cached, err := s.RedisService.GetWaveform(id)
+	if err != nil {
+		// Redis hatası kritik değil, R2'den okumaya devam et
+		fmt.Printf("Redis'ten dalga formu alınırken hata: %v\n", err)
+	}
+	if cached != nil {
+		return cached, nil
+	}
+
+	if podcast.WaveformKey == "" {
+		return nil, errors.New("dalga formu bulunamadı")
+	}
+
+	data, err := s.StorageService.DownloadFile(podcast.WaveformKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.RedisService.SetWaveform(id, data, 24*time.Hour); err != nil {
+		fmt.Printf("Redis'e dalga formu kaydedilirken hata: %v\n", err)
+	}
+
+	return data, nil
+}
+
+// GetStatus, podcast'in arka planda işlenme durumunu döndürür. Yalnızca podcast'i
+// düzenleyebilen kullanıcılar görebilir.
+func (s *PodcastService) GetStatus(id, userID uint) (*dto.PodcastStatusResponse, error) {
+	podcast, err := s.podcastRepo.GetPodcastByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.can(userID, policy.UpdatePodcast, podcast.UserID) {
+		return nil, errors.New("bu podcast'in durumunu görme yetkiniz yok")
+	}
+
+	return s.mediaService.GetStatus(podcast), nil
+}
+
+// WatchStatus, podcast'in güncel durumunu ve sonraki durum değişikliklerini döndürür.
+// İşlem bittiğinde stop çağrılmalıdır.
+func (s *PodcastService) WatchStatus(ctx context.Context, id, userID uint) (*dto.PodcastStatusResponse, <-chan dto.PodcastStatusResponse, func(), error) {
+	podcast, err := s.podcastRepo.GetPodcastByID(id)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if !s.can(userID, policy.UpdatePodcast, podcast.UserID) {
+		return nil, nil, nil, errors.New("bu podcast'in durumunu görme yetkiniz yok")
+	}
+
+	// Önce abone olunur, sonra güncel durum okunur; aradaki değişiklikler kaçmaz
+	updates, stop, err := s.mediaService.WatchStatus(ctx, podcast.ID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return s.mediaService.GetStatus(podcast), updates, stop, nil
+}
+
+// GetPodcastByID, podcast'i izleyicinin görebileceği durumdaysa döndürür
+func (s *PodcastService) GetPodcastByID(id, viewerID uint) (*dto.PodcastResponse, error) {
+	podcast, err := s.getVisiblePodcast(id, viewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.toSignedPodcastResponse(podcast, viewerID)
+}
+
+// GetUserPodcasts, kullanıcının podcastlerini döndürür. İki kullanıcıdan biri diğerini
+// engellediyse boş liste döner.
+func (s *PodcastService) GetUserPodcasts(viewerID, userID uint) ([]dto.PodcastResponse, error) {
+	blocked, err := s.socialRepo.IsBlockedBetween(viewerID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if blocked {
+		return make([]dto.PodcastResponse, 0), nil
+	}
+
+	// İşlenmekte olan ve işlenemeyen podcastleri yalnızca sahibi görür
+	podcasts, err := s.podcastRepo.GetPodcastsByUserID(userID, viewerID != userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.toPodcastResponses(*podcasts, viewerID)
+}
+
+// DiscoverPodcasts, engellenen ve sessize alınan kullanıcıların podcastleri hariç tüm podcastleri sayfalı döndürür
+func (s *PodcastService) DiscoverPodcasts(viewerID uint, req *dto.PodcastDiscoverRequest) (*dto.PodcastCursor, error) {
+	limit := req.Limit
+	if limit <= 0 {
+		limit = 10 // Varsayılan limit
+	}
+
+	podcasts, err := s.podcastRepo.DiscoverPodcasts(viewerID, req.Cursor, req.Direction, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var response dto.PodcastCursor
+
+	hasMore := len(*podcasts) > limit
+	actualPodcasts := *podcasts
+	if hasMore {
+		actualPodcasts = actualPodcasts[:limit]
+		nextID := actualPodcasts[len(actualPodcasts)-1].ID + 1
+		response.NextCursor = &nextID
+	}
+
+	response.Podcasts, err = s.toPodcastResponses(actualPodcasts, viewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	response.HasNext = hasMore
+	response.HasPrevious = req.Cursor != nil
+
+	return &response, nil
+}
+
+// GetFeed, kullanıcının takip ettiği yaratıcıların podcastlerini en yeniden eskiye sayfalı döndürür
+func (s *PodcastService) GetFeed(userID uint, req *dto.PodcastFeedRequest) (*dto.PodcastCursor, error) {
+	limit := req.Limit
+	if limit <= 0 || limit > 50 {
+		limit = 10 // Varsayılan limit
+	}
+
+	podcasts, err := s.podcastRepo.GetFeed(userID, req.Cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var response dto.PodcastCursor
+
+	hasMore := len(*podcasts) > limit
+	actualPodcasts := *podcasts
+	if hasMore {
+		actualPodcasts = actualPodcasts[:limit]
+		nextID := actualPodcasts[len(actualPodcasts)-1].ID
+		response.NextCursor = &nextID
+	}
+
+	response.Podcasts, err = s.toPodcastResponses(actualPodcasts, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response.HasNext = hasMore
+	response.HasPrevious = req.Cursor != nil
+
+	return &response, nil
+}
+
+// toSignedPodcastResponse, tek bir podcast'in URL'lerini imzalayarak yanıtını oluşturur
+func (s *PodcastService) toSignedPodcastResponse(podcast *model.Podcast, viewerID uint) (*dto.PodcastResponse, error) {
+	response, err := s.toPodcastResponses([]model.Podcast{*podcast}, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	return &response[0], nil
+}
+
+// toPodcastResponses, bir sayfadaki tüm kapak URL'lerini tek seferde imzalayarak yanıtları
+// oluşturur. Ses dosyaları erişim kurallarını her istekte kontrol eden API adresleriyle,
+// HLS akışı ise viewerID'ye bağlı imzayla verilir.
+func (s *PodcastService) toPodcastResponses(podcasts []model.Podcast, viewerID uint) ([]dto.PodcastResponse, error) {
+	// Tüm cover key'leri topla
+	keys := make([]string, 0, len(podcasts)*(1+len(cover.Sizes)))
+	for _, podcast := range podcasts {
+		keys = append(keys, podcast.CoverKey)
+		keys = append(keys, coverRenditionKeys(&podcast)...)
+	}
+
+	// Tüm URL'leri tek seferde al
+	urls, err := s.getMultipleSignedURLs(keys)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.PodcastResponse, 0, len(podcasts))
+	for _, podcast := range podcasts {
+		podcastResponse := toPodcastResponse(&podcast, &podcast.User, urls)
+		if podcast.Status == model.PodcastStatusReady {
+			podcastResponse.AudioURL = s.audioURL(podcast.ID, false)
+			if podcast.NormalizedAudioKey != "" {
+				podcastResponse.NormalizedAudioURL = s.audioURL(podcast.ID, true)
+			}
+			if podcast.HLSKey != "" {
+				podcastResponse.StreamURL = s.streamURL(podcast.ID, viewerID)
+			}
+		}
+		response = append(response, podcastResponse)
+	}
+	return response, nil
+}
+
+// toPodcastResponse, podcast'in yanıtını anahtarlarına göre imzalanmış kapak URL'leriyle
+// oluşturur. Dosyaları henüz yüklenmemiş podcastlerde URL'ler boş bırakılır; ses URL'leri
+// toPodcastResponses tarafından doldurulur.
+func toPodcastResponse(podcast *model.Podcast, user *model.User, urls map[string]string) dto.PodcastResponse {
+	var coverURL string
+	coverURLs := make(map[string]string)
+	if podcast.Status == model.PodcastStatusReady {
+		coverURL = urls[podcast.CoverKey]
+		if podcast.HasCoverRenditions {
+			for _, size := range cover.Sizes {
+				coverURLs[strconv.Itoa(size)] = urls[cover.RenditionKey(podcast.CoverKey, size)]
+			}
+		}
+	}
+
+	return dto.PodcastResponse{
+		ID:           podcast.ID,
+		Title:        podcast.Title,
+		Category:     podcast.Category,
+		Status:       podcast.Status,
+		MimeType:     podcast.MimeType,
+		DurationMs:   podcast.DurationMs,
+		Bitrate:      podcast.Bitrate,
+		SampleRate:   podcast.SampleRate,
+		Channels:     podcast.Channels,
+		SizeBytes:    podcast.SizeBytes,
+		Codec:        podcast.Codec,
+		LoudnessLUFS: podcast.LoudnessLUFS,
+		LoudnessPeak: podcast.LoudnessPeak,
+		CoverURL:     coverURL,
+		CoverURLs:    coverURLs,
+		User: dto.UserDTO{
+			ID:        user.ID,
+			FirstName: user.FirstName,
+			LastName:  user.LastName,
+			Username:  user.Username,
+		},
+	}
+}
+
+// applyAudioInfo, ses dosyasından okunan bilgileri podcast modeline yazar
+func applyAudioInfo(podcast *model.Podcast, info *audio.Info) {
+	podcast.MimeType = info.MimeType()
+	podcast.DurationMs = info.Duration.Milliseconds()
+	podcast.Bitrate = info.Bitrate
+	podcast.SampleRate = info.SampleRate
+	podcast.Channels = info.Channels
+	podcast.SizeBytes = info.Size
+	podcast.Codec = info.Codec
+}
+
+func (s *PodcastService) UpdatePodcast(id uint, userID uint, req *dto.UpdatePodcastRequest) (*dto.PodcastResponse, error) {
+	// Podcast'i bul
+	existingPodcast, err := s.podcastRepo.GetPodcastByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Yetki kontrolü
+	if !s.can(userID, policy.UpdatePodcast, existingPodcast.UserID) {
+		return nil, errors.New("bu podcast'i düzenleme yetkiniz yok")
+	}
+
+	// Güncelleme
+	existingPodcast.Title = req.Title
+	existingPodcast.Category = req.Category
+
+	// Veritabanını güncelle
+	if err := s.podcastRepo.UpdatePodcast(id, existingPodcast); err != nil {
+		return nil, err
+	}
+
+	return s.toSignedPodcastResponse(existingPodcast, userID)
+}
+
+func (s *PodcastService) DeletePodcast(id uint, userID uint) error {
+	fmt.Printf("Podcast - Silme işlemi başlatıldı. PodcastID: %d, UserID: %d\n", id, userID)
+
+	podcast, err := s.podcastRepo.GetPodcastByID(id)
+	if err != nil {
+		fmt.Printf("Podcast - HATA: Podcast bulunamadı. PodcastID: %d, Hata: %v\n", id, err)
+		return err
+	}
+
+	fmt.Printf("Podcast - Podcast bulundu. PodcastID: %d, Title: %s\n", podcast.ID, podcast.Title)
+
+	if !s.can(userID, policy.DeletePodcast, podcast.UserID) {
+		fmt.Printf("Podcast - HATA: Yetkisiz silme denemesi. PodcastID: %d, İsteyen UserID: %d, Sahip UserID: %d\n", id, userID, podcast.UserID)
+		return errors.New("bu podcast'i silme yetkiniz yok")
+	}
+
+	fmt.Printf("Podcast - Yetki kontrolü başarılı. Veritabanından silme işlemi başlatılıyor. PodcastID: %d\n", id)
+
+	// Dosyalar kayıt silindikten sonra ve yalnızca başka bir podcast tarafından kullanılmıyorsa
+	// silinir. İşlenmekte olan podcastlerin worker'ın sonradan yüklediği dosyaları worker
+	// tarafından podcast'in silindiği fark edildiğinde temizlenir.
+	if err := s.deletePodcast(podcast); err != nil {
+		fmt.Printf("Podcast - HATA: Veritabanından silinirken hata oluştu: %v\n", err)
+		return err
+	}
+
+	deleteHLS(s.StorageService, podcast)
+	if err := s.RedisService.DeleteWaveform(podcast.ID); err != nil {
+		fmt.Printf("Podcast - HATA: Önbellekteki dalga formu silinemedi: %v\n", err)
+	}
+
+	fmt.Printf("Podcast - Silme işlemi başarıyla tamamlandı. PodcastID: %d\n", id)
+	return nil
+}
+
+func (s *PodcastService) LikePodcast(podcastID, userID uint) (*dto.LikeResponse, error) {
+	// Kullanıcı kontrolü
+	if _, err := s.userRepo.GetUserByID(userID); err != nil {
+		return nil, errors.New("kullanıcı bulunamadı")
+	}
+
+	// Podcast kontrolü
+	podcast, err := s.podcastRepo.GetPodcastByID(podcastID)
+	if err != nil {
+		return nil, err
+	}
+
+	if podcast.Status != model.PodcastStatusReady {
+		return nil, errors.New("podcast henüz yayında değil")
+	}
+
+	if err := s.checkNotBlocked(podcast.UserID, userID); err != nil {
+		return nil, err
+	}
+
+	liked, err := s.podcastRepo.LikePodcast(podcastID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.LikeResponse{
+		PodcastID: podcastID,
+		UserID:    userID,
+		Liked:     liked,
+	}, nil
+}
+
+func (s *PodcastService) GetLikedPodcasts(userID uint) ([]dto.PodcastResponse, error) {
+	podcasts, err := s.podcastRepo.GetLikedPodcasts(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.toPodcastResponses(*podcasts, userID)
+}
+
+func (s *PodcastService) GetPodcastsByCategory(viewerID uint, category string) ([]dto.PodcastResponse, error) {
+	podcasts, err := s.podcastRepo.GetPodcastsByCategory(viewerID, category)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.toPodcastResponses(*podcasts, viewerID)
+}
+
+func (s *PodcastService) AddComment(podcastID, userID uint, content string) (*dto.CommentResponse, error) {
+	// Kullanıcı kontrolü
+	user, err := s.userRepo.GetUserByID(userID)
+	if err != nil {
+		return nil, errors.New("kullanıcı bulunamadı")
+	}
+
+	// Podcast kontrolü
+	podcast, err := s.podcastRepo.GetPodcastByID(podcastID)
+	if err != nil {
+		return nil, errors.New("podcast bulunamadı")
+	}
+
+	if podcast.Status != model.PodcastStatusReady {
+		return nil, errors.New("podcast henüz yayında değil")
+	}
+
+	if err := s.checkNotBlocked(podcast.UserID, userID); err != nil {
+		return nil, err
+	}
+
+	comment := &model.Comment{
+		PodcastID: podcastID,
+		UserID:    userID,
+		Content:   content,
+	}
+
+	err = s.podcastRepo.AddComment(comment)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.CommentResponse{
+		ID:        comment.ID,
+		Content:   comment.Content,
+		UserID:    comment.UserID,
+		Username:  user.Username,
+		CreatedAt: comment.CreatedAt,
+	}, nil
+}
+
+// GetComments, podcast'in yorumlarını döndürür. Podcast'i göremeyen kullanıcılar (engellenenler,
+// yayında olmayan podcastlerde sahibi ve moderatörler dışındakiler) yorumları da göremez.
+func (s *PodcastService) GetComments(podcastID, viewerID uint) ([]dto.CommentResponse, error) {
+	if _, err := s.getVisiblePodcast(podcastID, viewerID); err != nil {
+		return nil, err
+	}
+
+	comments, err := s.podcastRepo.GetComments(podcastID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.CommentResponse, 0) // Boş array ile başla
+	for _, comment := range *comments {
+		response = append(response, dto.CommentResponse{
+			ID:        comment.ID,
+			Content:   comment.Content,
+			UserID:    comment.UserID,
+			Username:  comment.User.Username,
+			CreatedAt: comment.CreatedAt,
+		})
+	}
+	return response, nil
+}
+
+// DeleteComment, yorumu siler. Yorumun sahibi ve moderatörler silebilir.
+func (s *PodcastService) DeleteComment(podcastID, commentID, userID uint) error {
+	comment, err := s.podcastRepo.GetCommentByID(commentID)
+	if err != nil {
+		return err
+	}
+
+	if comment.PodcastID != podcastID {
+		return errors.New("yorum bulunamadı")
+	}
+
+	if !s.can(userID, policy.DeleteComment, comment.UserID) {
+		return errors.New("bu yorumu silme yetkiniz yok")
+	}
+
+	return s.podcastRepo.DeleteComment(commentID)
+}
+
+// getVisiblePodcast, listelerdeki visibleTo kuralını tek podcast için uygular: henüz hazır
+// olmayan podcastleri yalnızca düzenleyebilenler, iki kullanıcıdan birinin diğerini
+// engellediği podcastleri hiç kimse göremez. Görülemeyen podcastler bulunamamış sayılır.
+func (s *PodcastService) getVisiblePodcast(id, viewerID uint) (*model.Podcast, error) {
+	podcast, err := s.podcastRepo.GetPodcastByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if viewerID == podcast.UserID {
+		return podcast, nil
+	}
+
+	if podcast.Status != model.PodcastStatusReady && !s.can(viewerID, policy.UpdatePodcast, podcast.UserID) {
+		return nil, errors.New("podcast bulunamadı")
+	}
+
+	blocked, err := s.socialRepo.IsBlockedBetween(viewerID, podcast.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if blocked {
+		return nil, errors.New("podcast bulunamadı")
+	}
+	return podcast, nil
+}
+
+// checkNotBlocked, podcast sahibinin kullanıcıyı engellemiş olması durumunda hata döndürür
+func (s *PodcastService) checkNotBlocked(ownerID, userID uint) error {
+	blocked, err := s.socialRepo.HasBlocked(ownerID, userID)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return errors.New("bu kullanıcı sizi engellediği için bu işlemi yapamazsınız")
+	}
+	return nil
+}
+
+// can, işlemi yapan kullanıcıyı yükleyerek rol ve sahiplik kontrolü yapar
+func (s *PodcastService) can(userID uint, action policy.Action, ownerID uint) bool {
+	user, err := s.userRepo.GetUserByID(userID)
+	if err != nil {
+		return false
+	}
+	return policy.Can(user, action, ownerID)
+}
+
+func (s *PodcastService) UpdatePodcastCover(id uint, userID uint, coverFile *multipart.FileHeader) (*dto.PodcastResponse, error) {
+	// Podcast'i bul
+	existingPodcast, err := s.podcastRepo.GetPodcastByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Yetki kontrolü
+	if !s.can(userID, policy.UpdatePodcast, existingPodcast.UserID) {
+		return nil, errors.New("bu podcast'i düzenleme yetkiniz yok")
+	}
+
+	src, err := coverFile.Open()
+	if err != nil {
+		return nil, err
+	}
+	coverImage, err := decodeCover(src)
+	src.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	// Yeni kapak fotoğrafını meta verilerinden arındırıp sürümleriyle birlikte yükle. Aynı
+	// kapak daha önce yüklenmişse mevcut nesne kullanılır.
+	src, err = coverFile.Open()
+	if err != nil {
+		return nil, err
+	}
+	sum, size, err := hashReader(src)
+	src.Close()
+	if err != nil {
+		return nil, err
+	}
+	coverKey := ContentKey("covers", sum, coverImage.Ext())
+	if coverKey == existingPodcast.CoverKey {
+		return s.toSignedPodcastResponse(existingPodcast, userID)
+	}
+
+	stored, err := s.StorageService.AcquireBlob(coverKey, size)
+	if err != nil {
+		return nil, err
+	}
+	if !stored {
+		if err := s.mediaService.UploadCover(coverImage, coverKey); err != nil {
+			s.releaseBlob(coverKey, cover.RenditionKeys(coverKey)...)
+			return nil, err
+		}
+		if err := s.StorageService.MarkBlobStored(coverKey); err != nil {
+			fmt.Printf("Podcast - HATA: Kapak fotoğrafı yüklendi olarak işaretlenemedi: %v\n", err)
+		}
+	}
+
+	// Podcast'i güncelle
+	oldPodcast := *existingPodcast
+	existingPodcast.CoverKey = coverKey
+	existingPodcast.HasCoverRenditions = true
+
+	// Veritabanını güncelle
+	if err := s.podcastRepo.UpdatePodcast(id, existingPodcast); err != nil {
+		// Hata durumunda yeni kapağın referansını bırak
+		s.releaseBlob(coverKey, cover.RenditionKeys(coverKey)...)
+		return nil, err
+	}
+
+	// Eski kapak fotoğrafı yalnızca yeni kapak kaydedildikten sonra bırakılır
+	if oldPodcast.CoverKey != "" {
+		if err := s.releaseCover(&oldPodcast); err != nil {
+			fmt.Printf("Podcast - HATA: Eski kapak fotoğrafı silinirken hata oluştu: %v\n", err)
+		}
+	}
+
+	return s.toSignedPodcastResponse(existingPodcast, userID)
+}
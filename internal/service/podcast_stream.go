@@ -0,0 +1,201 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"shortcast/internal/audio"
+	"shortcast/internal/model"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// streamURLTTL, stream_url'in geçerlilik süresidir. Erişim kuralları her istekte yeniden
+// kontrol edildiğinden süre, engellenen kullanıcıların erişimini uzatmaz.
+const streamURLTTL = 24 * time.Hour
+
+// audioURL, podcast'in ses dosyasını erişim kurallarını kontrol ederek sunan API adresidir.
+// Ses dosyaları imzalı R2 URL'leriyle verilmez; verilseydi URL, paylaşıldığı ya da
+// sonradan engellenen kullanıcılarda süresi dolana kadar geçerli kalırdı.
+func (s *PodcastService) audioURL(podcastID uint, normalized bool) string {
+	u := fmt.Sprintf("%s/api/podcasts/%d/audio", strings.TrimSuffix(s.config.AppURL, "/"), podcastID)
+	if normalized {
+		u += "?variant=" + AudioVariantNormalized
+	}
+	return u
+}
+
+// HLS oynatıcıları alt playlist'leri ve segmentleri Authorization başlığı olmadan,
+// playlist'teki göreli yollarla ister. Bu yüzden stream_url podcast'e ve bağlantıyı alan
+// kullanıcıya bağlı, STREAM_SIGNING_KEY ile oluşturulmuş bir HMAC imzası taşır. Playlist'ler
+// ve segmentler bu imzayı doğrulayan ve erişim kurallarını o kullanıcı için her istekte
+// yeniden kontrol eden proxy üzerinden sunulur; playlist'teki bütün yollara aynı imza eklenir.
+// Segmentler imzalı R2 URL'leriyle verilmez; verilseydi sonradan engellenen kullanıcı
+// elindeki playlist'le URL'lerin süresi dolana kadar dinlemeye devam edebilirdi.
+
+// streamURL, podcast'in HLS master playlist'inin viewerID için imzalanmış adresini oluşturur.
+// İmza anahtarı yapılandırılmamışsa boş döner ve istemciler ses dosyasını doğrudan çalar.
+func (s *PodcastService) streamURL(podcastID, viewerID uint) string {
+	if s.config.HLS.SigningKey == "" {
+		return ""
+	}
+	expires := time.Now().Add(streamURLTTL).Unix()
+	return fmt.Sprintf("%s/api/podcasts/%d/hls/%s?%s",
+		strings.TrimSuffix(s.config.AppURL, "/"), podcastID, audio.HLSMasterPlaylist, s.streamQuery(podcastID, viewerID, expires))
+}
+
+func (s *PodcastService) streamQuery(podcastID, viewerID uint, expires int64) string {
+	return url.Values{
+		"viewer":    {strconv.FormatUint(uint64(viewerID), 10)},
+		"expires":   {strconv.FormatInt(expires, 10)},
+		"signature": {s.streamSignature(podcastID, viewerID, expires)},
+	}.Encode()
+}
+
+func (s *PodcastService) streamSignature(podcastID, viewerID uint, expires int64) string {
+	mac := hmac.New(sha256.New, []byte(s.config.HLS.SigningKey))
+	fmt.Fprintf(mac, "%d:%d:%d", podcastID, viewerID, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// openStream, akış bağlantısının imzasını doğrular ve podcast'i imzadaki kullanıcı için
+// erişim kurallarını kontrol ederek playlist yollarına eklenecek imzayla birlikte döndürür.
+func (s *PodcastService) openStream(id uint, viewer, expires, signature string) (*model.Podcast, string, error) {
+	viewerID, viewerErr := strconv.ParseUint(viewer, 10, 0)
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if s.config.HLS.SigningKey == "" || viewerErr != nil || err != nil || time.Now().Unix() > expiresAt ||
+		!hmac.Equal([]byte(signature), []byte(s.streamSignature(id, uint(viewerID), expiresAt))) {
+		return nil, "", errors.New("akış bağlantısı geçersiz veya süresi dolmuş")
+	}
+
+	podcast, err := s.getVisiblePodcast(id, uint(viewerID))
+	if err != nil {
+		return nil, "", err
+	}
+	if podcast.Status != model.PodcastStatusReady || podcast.HLSKey == "" {
+		return nil, "", errors.New("playlist bulunamadı")
+	}
+	return podcast, s.streamQuery(podcast.ID, uint(viewerID), expiresAt), nil
+}
+
+// isHLSVariantFile, name'in bir sürüm dizinindeki dosya (<sürüm>/<dosya>) olup olmadığını döndürür
+func isHLSVariantFile(name string) bool {
+	return strings.Count(name, "/") == 1 && !strings.Contains(name, "..") && !strings.HasPrefix(name, "/")
+}
+
+// GetHLSPlaylist, imzayı doğrular ve podcast'in HLS playlist'ini oynatıcının doğrudan
+// kullanabileceği şekilde yeniden yazarak döndürür. name, master.m3u8 veya
+// <sürüm>/index.m3u8 olabilir. Erişim kuralları her playlist isteğinde imzadaki kullanıcı
+// için yeniden kontrol edilir.
+func (s *PodcastService) GetHLSPlaylist(id uint, name, viewer, expires, signature string) ([]byte, error) {
+	if name != audio.HLSMasterPlaylist && (path.Base(name) != audio.HLSVariantPlaylist || !isHLSVariantFile(name)) {
+		return nil, errors.New("playlist bulunamadı")
+	}
+
+	podcast, query, err := s.openStream(id, viewer, expires, signature)
+	if err != nil {
+		return nil, err
+	}
+
+	key := hlsPrefix(podcast.ID) + name
+	playlist, err := s.RedisService.GetHLSPlaylist(key)
+	if err != nil {
+		// Redis hatası kritik değil, R2'den okumaya devam et
+		fmt.Printf("Redis'ten playlist alınırken hata: %v\n", err)
+	}
+	if playlist == nil {
+		playlist, err = s.StorageService.DownloadFile(key)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.RedisService.SetHLSPlaylist(key, playlist, 24*time.Hour); err != nil {
+			fmt.Printf("Redis'e playlist kaydedilirken hata: %v\n", err)
+		}
+	}
+
+	return rewritePlaylist(playlist, query)
+}
+
+// OpenHLSSegment, imzayı ve erişim kurallarını GetHLSPlaylist gibi doğrular ve
+// <sürüm>/<segment>.ts dosyasını R2'den akış olarak açar
+func (s *PodcastService) OpenHLSSegment(id uint, name, viewer, expires, signature, byteRange, ifNoneMatch string) (*ObjectStream, error) {
+	if path.Ext(name) != ".ts" || !isHLSVariantFile(name) {
+		return nil, errors.New("segment bulunamadı")
+	}
+
+	podcast, _, err := s.openStream(id, viewer, expires, signature)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.Contains(byteRange, ",") {
+		byteRange = ""
+	}
+	stream, err := s.StorageService.StreamFile(hlsPrefix(podcast.ID)+name, byteRange, ifNoneMatch)
+	if errors.Is(err, ErrFileNotFound) {
+		return nil, errors.New("segment bulunamadı")
+	}
+	return stream, err
+}
+
+// rewritePlaylist, playlist'teki alt playlist ve segment yollarına imzayı ekler. Etiket
+// satırları olduğu gibi bırakılır.
+func rewritePlaylist(playlist []byte, query string) ([]byte, error) {
+	var out bytes.Buffer
+	scanner := bufio.NewScanner(bytes.NewReader(playlist))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			out.WriteString(line)
+		} else {
+			out.WriteString(line + "?" + query)
+		}
+		out.WriteByte('\n')
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// AudioVariantNormalized, OpenAudio'da ses yüksekliği normalize edilmiş sürümü seçer
+const AudioVariantNormalized = "normalized"
+
+// OpenAudio, podcast'in ses dosyasını erişim kurallarını kontrol ederek R2'den akış olarak
+// açar. variant boşsa orijinal dosya, AudioVariantNormalized ise normalize edilmiş sürüm
+// açılır. Range ve If-None-Match başlıkları R2'ye iletilir; çoklu aralık istekleri
+// desteklenmediğinden dosyanın tamamı döner.
+func (s *PodcastService) OpenAudio(id, userID uint, variant, byteRange, ifNoneMatch string) (*ObjectStream, error) {
+	// Erişim kuralları HLS akışıyla aynıdır; dosyası henüz yüklenmemiş podcastler yok sayılır
+	podcast, err := s.getVisiblePodcast(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if podcast.Status != model.PodcastStatusReady {
+		return nil, errors.New("podcast bulunamadı")
+	}
+
+	key := podcast.AudioKey
+	switch variant {
+	case "":
+	case AudioVariantNormalized:
+		if podcast.NormalizedAudioKey == "" {
+			return nil, errors.New("podcast bulunamadı")
+		}
+		key = podcast.NormalizedAudioKey
+	default:
+		return nil, errors.New("geçersiz ses sürümü")
+	}
+
+	if strings.Contains(byteRange, ",") {
+		byteRange = ""
+	}
+	return s.StorageService.StreamFile(key, byteRange, ifNoneMatch)
+}
@@ -0,0 +1,295 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"shortcast/internal/cover"
+	"shortcast/internal/dto"
+	"shortcast/internal/model"
+	"time"
+)
+
+const (
+	// pendingUploadTTL, imzalı URL'lerin ve tamamlanmamış yüklemelerin geçerlilik süresidir
+	pendingUploadTTL = time.Hour
+	// maxAudioUploadSize, doğrudan yüklenebilecek en büyük ses dosyasıdır. 60 saniyelik
+	// 96 kHz 32 bit stereo bir WAV dosyası yaklaşık 46 MB'tır.
+	maxAudioUploadSize = 50 * 1024 * 1024
+	// uploadCleanupInterval, süresi dolan yüklemelerin temizlenme aralığıdır
+	uploadCleanupInterval = 10 * time.Minute
+)
+
+// audioContentTypeExts ve coverContentTypeExts, imzalı URL'lerde izin verilen içerik türleri
+// ve geçici anahtarlarda kullanılan uzantılardır. Uzantı istemcinin verdiği dosya adından
+// alınmaz; asıl format, yükleme tamamlanırken dosyanın içeriğinden tespit edilir.
+var audioContentTypeExts = map[string]string{
+	"audio/mpeg":  ".mp3",
+	"audio/mp3":   ".mp3",
+	"audio/mp4":   ".m4a",
+	"audio/x-m4a": ".m4a",
+	"audio/aac":   ".m4a",
+	"audio/ogg":   ".opus",
+	"audio/opus":  ".opus",
+	"audio/wav":   ".wav",
+	"audio/x-wav": ".wav",
+	"audio/wave":  ".wav",
+}
+
+var coverContentTypeExts = map[string]string{
+	"image/jpeg": ".jpeg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+// CreateUpload, ses ve kapak dosyalarının API'den geçmeden doğrudan R2'ye yüklenebilmesi
+// için imzalı PUT URL'leri oluşturur. Dosyalar CompleteUpload çağrılana kadar uploads/
+// altındaki geçici anahtarlarda bekler.
+func (s *PodcastService) CreateUpload(userID uint, req *dto.CreateUploadRequest) (*dto.UploadSessionResponse, error) {
+	if req.AudioSize <= 0 || req.AudioSize > maxAudioUploadSize {
+		return nil, fmt.Errorf("ses dosyası 1 bayt ile %d MB arasında olmalı", maxAudioUploadSize/1024/1024)
+	}
+	audioExt, ok := audioContentTypeExts[req.AudioContentType]
+	if !ok {
+		return nil, errors.New("ses dosyası türü desteklenmiyor")
+	}
+	if req.CoverSize <= 0 || req.CoverSize > cover.MaxFileSize {
+		return nil, errCoverTooLarge
+	}
+	coverExt, ok := coverContentTypeExts[req.CoverContentType]
+	if !ok {
+		return nil, errors.New("kapak fotoğrafı JPEG, PNG veya WebP formatında olmalı")
+	}
+
+	prefix, err := newUploadPrefix()
+	if err != nil {
+		return nil, err
+	}
+
+	upload := &model.PendingUpload{
+		UserID:           userID,
+		Title:            req.Title,
+		Category:         req.Category,
+		AudioFilename:    path.Base(req.AudioFilename),
+		AudioKey:         prefix + "/audio" + audioExt,
+		AudioSize:        req.AudioSize,
+		AudioContentType: req.AudioContentType,
+		CoverFilename:    path.Base(req.CoverFilename),
+		CoverKey:         prefix + "/cover" + coverExt,
+		CoverSize:        req.CoverSize,
+		CoverContentType: req.CoverContentType,
+		ExpiresAt:        time.Now().Add(pendingUploadTTL),
+	}
+
+	audioUpload, err := s.presignUpload(upload.AudioKey, upload.AudioContentType, upload.AudioSize)
+	if err != nil {
+		return nil, err
+	}
+	coverUpload, err := s.presignUpload(upload.CoverKey, upload.CoverContentType, upload.CoverSize)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.podcastRepo.SavePendingUpload(upload); err != nil {
+		return nil, err
+	}
+
+	return &dto.UploadSessionResponse{
+		ID:        upload.ID,
+		Audio:     *audioUpload,
+		Cover:     *coverUpload,
+		ExpiresAt: upload.ExpiresAt,
+	}, nil
+}
+
+// CompleteUpload, R2'ye yüklenen dosyaların beyan edilen boyut ve türde olduğunu kontrol
+// eder, dosyaları doğrular ve podcast'i UploadPodcast ile aynı şekilde işlenmek üzere
+// kuyruğa alır. Worker dosyaları yüklendikleri geçici anahtarlardan okur; yalnızca kırpılan
+// ses yeniden yüklenir. Doğrulama başarısız olursa yükleme süresi dolana kadar yeniden denenebilir.
+func (s *PodcastService) CompleteUpload(id, userID uint, req *dto.CompleteUploadRequest) (*dto.PodcastResponse, error) {
+	upload, err := s.podcastRepo.GetPendingUpload(id)
+	if err != nil {
+		return nil, err
+	}
+	if upload.UserID != userID {
+		return nil, errors.New("yükleme bulunamadı")
+	}
+	if time.Now().After(upload.ExpiresAt) {
+		return nil, errors.New("yüklemenin süresi dolmuş")
+	}
+
+	claimed, err := s.podcastRepo.ClaimPendingUpload(upload.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		return nil, errors.New("yükleme zaten tamamlanıyor")
+	}
+
+	response, err := s.completeUpload(upload, req)
+	if err != nil {
+		if releaseErr := s.podcastRepo.ReleasePendingUpload(upload.ID); releaseErr != nil {
+			fmt.Printf("Upload - HATA: Yükleme serbest bırakılamadı. UploadID: %d, Hata: %v\n", upload.ID, releaseErr)
+		}
+		return nil, err
+	}
+
+	// Geçici dosyalar artık kuyruktaki işe ait; süresi dolan yüklemelerle birlikte silinmesinler
+	if err := s.podcastRepo.DeletePendingUpload(upload.ID); err != nil {
+		fmt.Printf("Upload - HATA: Yükleme kaydı silinemedi. UploadID: %d, Hata: %v\n", upload.ID, err)
+	}
+	return response, nil
+}
+
+func (s *PodcastService) completeUpload(upload *model.PendingUpload, req *dto.CompleteUploadRequest) (*dto.PodcastResponse, error) {
+	if err := s.verifyUploadedObject(upload.AudioKey, upload.AudioSize, upload.AudioContentType); err != nil {
+		return nil, fmt.Errorf("ses dosyası %v", err)
+	}
+	if err := s.verifyUploadedObject(upload.CoverKey, upload.CoverSize, upload.CoverContentType); err != nil {
+		return nil, fmt.Errorf("kapak fotoğrafı %v", err)
+	}
+
+	user, err := s.userRepo.GetUserByID(upload.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("kullanıcı bulunamadı: %v", err)
+	}
+
+	// Dosyalar yalnızca doğrulanmak için indirilir
+	audioPath, err := s.mediaService.SpoolObject(upload.AudioKey, path.Ext(upload.AudioKey))
+	if err != nil {
+		return nil, err
+	}
+	coverPath, err := s.mediaService.SpoolObject(upload.CoverKey, path.Ext(upload.CoverKey))
+	if err != nil {
+		os.Remove(audioPath)
+		return nil, err
+	}
+
+	staged := &spooledUpload{
+		audioPath:      audioPath,
+		audioUploadKey: upload.AudioKey,
+		coverPath:      coverPath,
+		coverUploadKey: upload.CoverKey,
+	}
+	response, err := s.createPodcast(user, &dto.UploadPodcastRequest{
+		UserID:   upload.UserID,
+		Title:    upload.Title,
+		Category: upload.Category,
+		StartMs:  req.StartMs,
+		EndMs:    req.EndMs,
+	}, staged)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ses kırpıldıysa işe kırpılmış kopyası verildi; yüklenen dosya artık kullanılmıyor
+	if staged.audioUploadKey != upload.AudioKey {
+		if err := s.StorageService.DeleteFile(upload.AudioKey); err != nil {
+			fmt.Printf("Upload - HATA: Geçici dosya silinemedi. Key: %s, Hata: %v\n", upload.AudioKey, err)
+		}
+	}
+	return response, nil
+}
+
+// verifyUploadedObject, R2'deki dosyanın imzalı URL'de beyan edilen boyut ve türde olduğunu kontrol eder
+func (s *PodcastService) verifyUploadedObject(key string, size int64, contentType string) error {
+	actualSize, actualType, err := s.StorageService.HeadFile(key)
+	if err != nil {
+		return errors.New("henüz yüklenmemiş")
+	}
+	if actualSize != size || actualType != contentType {
+		return errors.New("beyan edilen boyut veya türle eşleşmiyor")
+	}
+	return nil
+}
+
+func (s *PodcastService) presignUpload(key, contentType string, size int64) (*dto.PresignedUpload, error) {
+	url, signedHeaders, err := s.StorageService.PresignPut(key, contentType, size, pendingUploadTTL)
+	if err != nil {
+		return nil, fmt.Errorf("imzalı yükleme URL'i oluşturulamadı: %v", err)
+	}
+
+	// Host ve Content-Length başlıklarını istemci kendisi gönderir
+	headers := make(map[string]string)
+	for name, values := range signedHeaders {
+		if name == "Host" || name == "Content-Length" || len(values) == 0 {
+			continue
+		}
+		headers[name] = values[0]
+	}
+
+	return &dto.PresignedUpload{
+		URL:     url,
+		Method:  http.MethodPut,
+		Headers: headers,
+	}, nil
+}
+
+// CleanupExpiredUploads, süresi dolan yüklemeleri ve R2'deki geçici dosyalarını siler
+func (s *PodcastService) CleanupExpiredUploads() (int, error) {
+	deleted := 0
+	for {
+		uploads, err := s.podcastRepo.GetExpiredPendingUploads(time.Now(), 100)
+		if err != nil {
+			return deleted, err
+		}
+		if len(*uploads) == 0 {
+			return deleted, nil
+		}
+
+		for _, upload := range *uploads {
+			if err := s.deletePendingUpload(&upload); err != nil {
+				return deleted, err
+			}
+			deleted++
+		}
+	}
+}
+
+// StartUploadCleanup, süresi dolan yüklemeleri ctx iptal edilene kadar düzenli aralıklarla temizler
+func (s *PodcastService) StartUploadCleanup(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(uploadCleanupInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				deleted, err := s.CleanupExpiredUploads()
+				if err != nil {
+					fmt.Printf("Upload - HATA: Süresi dolan yüklemeler temizlenemedi: %v\n", err)
+				}
+				if deleted > 0 {
+					fmt.Printf("Upload - Süresi dolan %d yükleme temizlendi\n", deleted)
+				}
+			}
+		}
+	}()
+}
+
+// deletePendingUpload, yüklemenin geçici dosyalarını ve kaydını siler. Hiç yüklenmemiş
+// dosyalar için R2'nin döndürdüğü hatalar yok sayılır.
+func (s *PodcastService) deletePendingUpload(upload *model.PendingUpload) error {
+	for _, key := range []string{upload.AudioKey, upload.CoverKey} {
+		if _, _, err := s.StorageService.HeadFile(key); err == nil {
+			s.StorageService.DeleteFile(key)
+		}
+	}
+	return s.podcastRepo.DeletePendingUpload(upload.ID)
+}
+
+// newUploadPrefix, tahmin edilemeyen geçici bir yükleme dizini oluşturur
+func newUploadPrefix() (string, error) {
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+	return "uploads/" + hex.EncodeToString(token), nil
+}
@@ -1,76 +1,156 @@
-package service
-
-import (
-	"context"
-	"fmt"
-	"time"
-
-	"github.com/redis/go-redis/v9"
-)
-
-type RedisService struct {
-	client *redis.Client
-}
-
-func NewRedisService(client *redis.Client) *RedisService {
-	return &RedisService{
-		client: client,
-	}
-}
-
-// GetSignedURL, Redis'ten imzalı URL'i alır
-func (s *RedisService) GetSignedURL(key string) (string, error) {
-	ctx := context.Background()
-	val, err := s.client.Get(ctx, fmt.Sprintf("signed_url:%s", key)).Result()
-	if err == redis.Nil {
-		return "", nil // URL bulunamadı
-	}
-	if err != nil {
-		return "", fmt.Errorf("redis'ten URL alınırken hata: %v", err)
-	}
-	return val, nil
-}
-
-// SetSignedURL, imzalı URL'i Redis'e kaydeder
-func (s *RedisService) SetSignedURL(key, url string, expiration time.Duration) error {
-	ctx := context.Background()
-	return s.client.Set(ctx, fmt.Sprintf("signed_url:%s", key), url, expiration).Err()
-}
-
-// GetMultipleSignedURLs, birden fazla imzalı URL'i Redis'ten alır
-func (s *RedisService) GetMultipleSignedURLs(keys []string) (map[string]string, error) {
-	ctx := context.Background()
-	result := make(map[string]string)
-
-	for _, key := range keys {
-		val, err := s.client.Get(ctx, fmt.Sprintf("signed_url:%s", key)).Result()
-		if err == redis.Nil {
-			continue // URL bulunamadı, atla
-		}
-		if err != nil {
-			return nil, fmt.Errorf("redis'ten URL alınırken hata: %v", err)
-		}
-		result[key] = val
-	}
-
-	return result, nil
-}
-
-// SetMultipleSignedURLs, birden fazla imzalı URL'i Redis'e kaydeder
-func (s *RedisService) SetMultipleSignedURLs(urls map[string]string, expiration time.Duration) error {
-	ctx := context.Background()
-	pipe := s.client.Pipeline()
-
-	for key, url := range urls {
-		pipe.Set(ctx, fmt.Sprintf("signed_url:%s", key), url, expiration)
-	}
-
-	_, err := pipe.Exec(ctx)
-	return err
-}
-
-// DeleteSignedURL, Redis'ten imzalı URL'i siler
-func (s *RedisService) DeleteSignedURL(key string) error {
-	ctx := context.Background()
-	return s.client.Del(ctx, fmt.Sprintf("signed_url:%s", key)).Err()
-}
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type RedisService struct {
+	client *redis.Client
+}
+
+func NewRedisService(client *redis.Client) *RedisService {
+	return &RedisService{
+		client: client,
+	}
+}
+
+// GetSignedURL, Redis'ten imzalı URL'i alır
+func (s *RedisService) GetSignedURL(key string) (string, error) {
+	ctx := context.Background()
+	val, err := s.client.Get(ctx, fmt.Sprintf("signed_url:%s", key)).Result()
+	if err == redis.Nil {
+		return "", nil // URL bulunamadı
+	}
+	if err != nil {
+		return "", fmt.Errorf("redis'ten URL alınırken hata: %v", err)
+	}
+	return val, nil
+}
+
+// SetSignedURL, imzalı URL'i Redis'e kaydeder
+func (s *RedisService) SetSignedURL(key, url string, expiration time.Duration) error {
+	ctx := context.Background()
+	return s.client.Set(ctx, fmt.Sprintf("signed_url:%s", key), url, expiration).Err()
+}
+
+// GetMultipleSignedURLs, birden fazla imzalı URL'i Redis'ten alır
+func (s *RedisService) GetMultipleSignedURLs(keys []string) (map[string]string, error) {
+	ctx := context.Background()
+	result := make(map[string]string)
+
+	for _, key := range keys {
+		val, err := s.client.Get(ctx, fmt.Sprintf("signed_url:%s", key)).Result()
+		if err == redis.Nil {
+			continue // URL bulunamadı, atla
+		}
+		if err != nil {
+			return nil, fmt.Errorf("redis'ten URL alınırken hata: %v", err)
+		}
+		result[key] = val
+	}
+
+	return result, nil
+}
+
+// SetMultipleSignedURLs, birden fazla imzalı URL'i Redis'e kaydeder
+func (s *RedisService) SetMultipleSignedURLs(urls map[string]string, expiration time.Duration) error {
+	ctx := context.Background()
+	pipe := s.client.Pipeline()
+
+	for key, url := range urls {
+		pipe.Set(ctx, fmt.Sprintf("signed_url:%s", key), url, expiration)
+	}
+
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// DeleteSignedURL, Redis'ten imzalı URL'i siler
+func (s *RedisService) DeleteSignedURL(key string) error {
+	ctx := context.Background()
+	return s.client.Del(ctx, fmt.Sprintf("signed_url:%s", key)).Err()
+}
+
+// GetWaveform, podcast'in önbelleğe alınmış dalga formu JSON'ını döndürür; yoksa nil döner
+func (s *RedisService) GetWaveform(podcastID uint) ([]byte, error) {
+	ctx := context.Background()
+	val, err := s.client.Get(ctx, fmt.Sprintf("waveform:%d", podcastID)).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("redis'ten dalga formu alınırken hata: %v", err)
+	}
+	return val, nil
+}
+
+// SetWaveform, dalga formu JSON'ını Redis'e kaydeder
+func (s *RedisService) SetWaveform(podcastID uint, data []byte, expiration time.Duration) error {
+	ctx := context.Background()
+	return s.client.Set(ctx, fmt.Sprintf("waveform:%d", podcastID), data, expiration).Err()
+}
+
+// DeleteWaveform, önbelleğe alınmış dalga formunu siler
+func (s *RedisService) DeleteWaveform(podcastID uint) error {
+	ctx := context.Background()
+	return s.client.Del(ctx, fmt.Sprintf("waveform:%d", podcastID)).Err()
+}
+
+// GetHLSPlaylist, önbelleğe alınmış HLS playlist'ini döndürür; yoksa nil döner
+func (s *RedisService) GetHLSPlaylist(key string) ([]byte, error) {
+	ctx := context.Background()
+	val, err := s.client.Get(ctx, fmt.Sprintf("hls_playlist:%s", key)).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("redis'ten playlist alınırken hata: %v", err)
+	}
+	return val, nil
+}
+
+// SetHLSPlaylist, R2'deki HLS playlist'ini Redis'e kaydeder
+func (s *RedisService) SetHLSPlaylist(key string, data []byte, expiration time.Duration) error {
+	ctx := context.Background()
+	return s.client.Set(ctx, fmt.Sprintf("hls_playlist:%s", key), data, expiration).Err()
+}
+
+// SetPodcastStatus, podcast'in işlenme durumunu kaydeder ve durumu izleyen
+// istemcilere yayınlar
+func (s *RedisService) SetPodcastStatus(podcastID uint, status []byte, expiration time.Duration) error {
+	ctx := context.Background()
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, fmt.Sprintf("podcast_status:%d", podcastID), status, expiration)
+		pipe.Publish(ctx, fmt.Sprintf("podcast_status:%d", podcastID), status)
+		return nil
+	})
+	return err
+}
+
+// GetPodcastStatus, podcast'in kayıtlı işlenme durumunu döndürür; yoksa nil döner
+func (s *RedisService) GetPodcastStatus(podcastID uint) ([]byte, error) {
+	ctx := context.Background()
+	val, err := s.client.Get(ctx, fmt.Sprintf("podcast_status:%d", podcastID)).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("redis'ten podcast durumu alınırken hata: %v", err)
+	}
+	return val, nil
+}
+
+// SubscribePodcastStatus, podcast'in durum değişikliklerine abone olur. Abonelik
+// onaylandıktan sonra döner; böylece sonrasında yayınlanan hiçbir değişiklik kaçmaz.
+func (s *RedisService) SubscribePodcastStatus(ctx context.Context, podcastID uint) (*redis.PubSub, error) {
+	pubsub := s.client.Subscribe(ctx, fmt.Sprintf("podcast_status:%d", podcastID))
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return nil, fmt.Errorf("podcast durumuna abone olunamadı: %v", err)
+	}
+	return pubsub, nil
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"fmt"
+	"time"
+)
+
+// signedURLTTL, medya dosyaları için verilen imzalı URL'lerin geçerlilik süresidir
+const signedURLTTL = 24 * time.Hour
+
+// getSignedURL, depodan imzalı URL alır veya Redis'ten önbelleğe alınmış URL'i döndürür
+func getSignedURL(redisService *RedisService, storageService *StorageService, key string) (string, error) {
+	// Önce Redis'ten kontrol et
+	cachedURL, err := redisService.GetSignedURL(key)
+	if err != nil {
+		return "", fmt.Errorf("redis'ten URL alınırken hata: %v", err)
+	}
+	if cachedURL != "" {
+		return cachedURL, nil
+	}
+
+	// Redis'te yoksa depodan al
+	url, err := storageService.GetPresignedURL(key, signedURLTTL)
+	if err != nil {
+		return "", err
+	}
+
+	// Redis'e kaydet (24 saat geçerli)
+	err = redisService.SetSignedURL(key, url, signedURLTTL)
+	if err != nil {
+		// Redis hatası kritik değil, URL'i yine de döndür
+		fmt.Printf("Redis'e URL kaydedilirken hata: %v\n", err)
+	}
+
+	return url, nil
+}
+
+// getMultipleSignedURLs, birden fazla imzalı URL alır veya Redis'ten önbelleğe alınmış URL'leri döndürür
+func getMultipleSignedURLs(redisService *RedisService, storageService *StorageService, keys []string) (map[string]string, error) {
+	// Önce Redis'ten kontrol et
+	cachedURLs, err := redisService.GetMultipleSignedURLs(keys)
+	if err != nil {
+		return nil, fmt.Errorf("redis'ten URL'ler alınırken hata: %v", err)
+	}
+
+	// Eksik URL'leri bul
+	missingKeys := make([]string, 0)
+	for _, key := range keys {
+		if _, exists := cachedURLs[key]; !exists {
+			missingKeys = append(missingKeys, key)
+		}
+	}
+
+	// Eksik URL'leri depodan al
+	if len(missingKeys) > 0 {
+		missingURLs := make(map[string]string, len(missingKeys))
+		for _, key := range missingKeys {
+			if key == "" {
+				continue
+			}
+			url, err := storageService.GetPresignedURL(key, signedURLTTL)
+			if err != nil {
+				return nil, fmt.Errorf("imzalı URL oluşturulamadı: %w", err)
+			}
+			missingURLs[key] = url
+		}
+
+		// Redis'e kaydet (24 saat geçerli)
+		err = redisService.SetMultipleSignedURLs(missingURLs, signedURLTTL)
+		if err != nil {
+			// Redis hatası kritik değil, URL'leri yine de döndür
+			fmt.Printf("Redis'e URL'ler kaydedilirken hata: %v\n", err)
+		}
+
+		// Tüm URL'leri birleştir
+		for key, url := range missingURLs {
+			cachedURLs[key] = url
+		}
+	}
+
+	return cachedURLs, nil
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"errors"
+	"shortcast/internal/dto"
+	"shortcast/internal/model"
+	"shortcast/internal/repository"
+)
+
+type SocialService struct {
+	socialRepo *repository.SocialRepository
+	userRepo   *repository.UserRepository
+}
+
+func NewSocialService(socialRepo *repository.SocialRepository, userRepo *repository.UserRepository) *SocialService {
+	return &SocialService{
+		socialRepo: socialRepo,
+		userRepo:   userRepo,
+	}
+}
+
+func (s *SocialService) Follow(followerID, followingID uint) error {
+	if followerID == followingID {
+		return errors.New("kendinizi takip edemezsiniz")
+	}
+
+	if _, err := s.userRepo.GetUserByID(followingID); err != nil {
+		return err
+	}
+
+	blocked, err := s.socialRepo.IsBlockedBetween(followerID, followingID)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return errors.New("engellenen bir kullanıcı takip edilemez")
+	}
+
+	return s.socialRepo.Follow(followerID, followingID)
+}
+
+func (s *SocialService) Unfollow(followerID, followingID uint) error {
+	return s.socialRepo.Unfollow(followerID, followingID)
+}
+
+func (s *SocialService) GetFollowers(userID uint, req *dto.FollowListRequest) (*dto.FollowListResponse, error) {
+	return s.listUsers(userID, req, s.socialRepo.GetFollowers)
+}
+
+func (s *SocialService) GetFollowing(userID uint, req *dto.FollowListRequest) (*dto.FollowListResponse, error) {
+	return s.listUsers(userID, req, s.socialRepo.GetFollowing)
+}
+
+// Block, kullanıcıyı engeller; iki kullanıcı arasındaki takip ilişkileri de kaldırılır
+func (s *SocialService) Block(blockerID, blockedID uint) error {
+	if blockerID == blockedID {
+		return errors.New("kendinizi engelleyemezsiniz")
+	}
+
+	if _, err := s.userRepo.GetUserByID(blockedID); err != nil {
+		return err
+	}
+
+	return s.socialRepo.Block(blockerID, blockedID)
+}
+
+func (s *SocialService) Unblock(blockerID, blockedID uint) error {
+	return s.socialRepo.Unblock(blockerID, blockedID)
+}
+
+// Mute, kullanıcının podcastlerini keşfet ve takip akışından gizler
+func (s *SocialService) Mute(muterID, mutedID uint) error {
+	if muterID == mutedID {
+		return errors.New("kendinizi sessize alamazsınız")
+	}
+
+	if _, err := s.userRepo.GetUserByID(mutedID); err != nil {
+		return err
+	}
+
+	return s.socialRepo.Mute(muterID, mutedID)
+}
+
+func (s *SocialService) Unmute(muterID, mutedID uint) error {
+	return s.socialRepo.Unmute(muterID, mutedID)
+}
+
+func (s *SocialService) GetBlockedUsers(userID uint, req *dto.FollowListRequest) (*dto.FollowListResponse, error) {
+	return s.listUsers(userID, req, s.socialRepo.GetBlockedUsers)
+}
+
+func (s *SocialService) GetMutedUsers(userID uint, req *dto.FollowListRequest) (*dto.FollowListResponse, error) {
+	return s.listUsers(userID, req, s.socialRepo.GetMutedUsers)
+}
+
+func (s *SocialService) listUsers(userID uint, req *dto.FollowListRequest, list func(uint, int, int) (*[]model.User, int64, error)) (*dto.FollowListResponse, error) {
+	if _, err := s.userRepo.GetUserByID(userID); err != nil {
+		return nil, err
+	}
+
+	page := req.Page
+	if page <= 0 {
+		page = 1
+	}
+	limit := req.Limit
+	if limit <= 0 || limit > 100 {
+		limit = 20 // Varsayılan limit
+	}
+
+	users, total, err := list(userID, (page-1)*limit, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.FollowListResponse{
+		Users: make([]dto.UserDTO, 0),
+		Page:  page,
+		Limit: limit,
+		Total: total,
+	}
+	for _, user := range *users {
+		response.Users = append(response.Users, dto.UserDTO{
+			ID:        user.ID,
+			FirstName: user.FirstName,
+			LastName:  user.LastName,
+			Username:  user.Username,
+		})
+	}
+
+	return response, nil
+}
@@ -0,0 +1,448 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"shortcast/internal/repository"
+	"shortcast/internal/storage"
+	"strings"
+	"time"
+)
+
+// StorageService, yapılandırılan depolama sürücüsü (R2/S3, yerel disk veya bellek) üzerinde
+// dosya işlemlerini yapar. Ses, kapak ve avatar dosyaları içerik adreslidir: anahtarları
+// içeriğin SHA-256 özetinden türetilir ve referans sayıları blobs tablosunda tutulur.
+type StorageService struct {
+	store    storage.BlobStore
+	blobRepo *repository.BlobRepository
+}
+
+func NewStorageService(store storage.BlobStore, blobRepo *repository.BlobRepository) *StorageService {
+	return &StorageService{store: store, blobRepo: blobRepo}
+}
+
+// imageContentTypeExts, UploadFile ile yüklenebilen görsel türleri ve anahtarlarda kullanılan
+// uzantılarıdır. Tür dosya adından değil içeriğin ilk baytlarından tespit edilir.
+var imageContentTypeExts = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+// UploadFile görseli içerik adresli bir anahtarla depoya yükler ve anahtarı döndürür.
+// Aynı içerik daha önce yüklenmişse mevcut nesne kullanılır. Anahtar ReleaseBlob ile bırakılmalıdır.
+func (s *StorageService) UploadFile(file *multipart.FileHeader, folder string) (string, error) {
+	src, err := file.Open()
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+
+	contentType, err := detectContentType(src)
+	if err != nil {
+		return "", err
+	}
+	ext, ok := imageContentTypeExts[contentType]
+	if !ok {
+		return "", errors.New("dosya JPEG, PNG veya WebP olmalı")
+	}
+
+	sum, size, err := hashReader(src)
+	if err != nil {
+		return "", err
+	}
+	if _, err := src.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	key := ContentKey(folder, sum, ext)
+	stored, err := s.AcquireBlob(key, size)
+	if err != nil {
+		return "", err
+	}
+	if !stored {
+		if err := s.UploadStream(key, src, contentType); err != nil {
+			s.ReleaseBlob(key)
+			return "", err
+		}
+		if err := s.MarkBlobStored(key); err != nil {
+			fmt.Printf("Depolama - HATA: Dosya yüklendi olarak işaretlenemedi. Key: %s, Hata: %v\n", key, err)
+		}
+	}
+	return key, nil
+}
+
+// detectContentType, dosyanın türünü ilk 512 baytından tespit eder ve okuyucuyu başa sarar
+func detectContentType(src io.ReadSeeker) (string, error) {
+	header := make([]byte, 512)
+	n, err := io.ReadFull(src, header)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return "", err
+	}
+	if _, err := src.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	return http.DetectContentType(header[:n]), nil
+}
+
+// ContentKey, içeriğin SHA-256 özetinden "<klasör>/<özet><uzantı>" anahtarını oluşturur.
+// Uzantı dosyanın içeriğinden tespit edilen formattan gelir ve küçük harfe çevrilir.
+func ContentKey(folder string, sum []byte, ext string) string {
+	return folder + "/" + hex.EncodeToString(sum) + strings.ToLower(ext)
+}
+
+// HashFile, dosyanın SHA-256 özetini ve boyutunu döndürür
+func HashFile(path string) ([]byte, int64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer file.Close()
+
+	return hashReader(file)
+}
+
+func hashReader(r io.Reader) ([]byte, int64, error) {
+	hash := sha256.New()
+	size, err := io.Copy(hash, r)
+	if err != nil {
+		return nil, 0, err
+	}
+	return hash.Sum(nil), size, nil
+}
+
+// AcquireBlob, içerik adresli dosyaya bir referans ekler ve nesnenin depoda zaten olup
+// olmadığını döndürür. Nesne yoksa çağıran yükleyip MarkBlobStored'u çağırmalıdır.
+func (s *StorageService) AcquireBlob(key string, size int64) (bool, error) {
+	blob, err := s.blobRepo.AcquireBlob(key, size)
+	if err != nil {
+		return false, fmt.Errorf("dosya referansı eklenemedi: %v", err)
+	}
+	return blob.Stored, nil
+}
+
+// IsBlobStored, içerik adresli dosyanın depoya yüklenip yüklenmediğini döndürür
+func (s *StorageService) IsBlobStored(key string) (bool, error) {
+	blob, err := s.blobRepo.GetBlob(key)
+	if err != nil {
+		if err.Error() == "dosya kaydı bulunamadı" {
+			return false, nil
+		}
+		return false, err
+	}
+	return blob.Stored, nil
+}
+
+// MarkBlobStored, içerik adresli dosyanın depoya yüklendiğini kaydeder
+func (s *StorageService) MarkBlobStored(key string) error {
+	return s.blobRepo.MarkBlobStored(key)
+}
+
+// ReleaseBlob, içerik adresli dosyanın bir referansını bırakır. Başka referans kalmadıysa
+// dosya ve ondan türetilmiş dosyalar (dalga formu, kapak sürümleri gibi) PurgeBlob ile
+// silinir. Kaydı olmayan dosyalara dokunulmaz.
+func (s *StorageService) ReleaseBlob(key string, derived ...string) error {
+	if key == "" {
+		return nil
+	}
+	last, err := s.blobRepo.ReleaseBlob(key)
+	if err != nil || !last {
+		return err
+	}
+	return s.PurgeBlob(key, derived...)
+}
+
+// PurgeBlob, referansı kalmamış dosyayı ve ondan türetilmiş dosyaları siler. Depoda
+// bulunmayan nesneler silinmiş sayılır; türetilmiş dosyaların silinme hataları yalnızca
+// loglanır. Ana dosya silinemezse kaydı korunur ve nesne sahipsiz dosya temizliğinde silinir.
+func (s *StorageService) PurgeBlob(key string, derived ...string) error {
+	if key == "" {
+		return nil
+	}
+	return s.blobRepo.PurgeBlob(key, func() error {
+		for _, derivedKey := range derived {
+			if derivedKey == "" {
+				continue
+			}
+			if err := s.store.Delete(context.TODO(), objectKey(derivedKey)); err != nil {
+				fmt.Printf("Depolama - HATA: Türetilmiş dosya silinemedi. Key: %s, Hata: %v\n", derivedKey, err)
+			}
+		}
+		if err := s.store.Delete(context.TODO(), objectKey(key)); err != nil {
+			return fmt.Errorf("dosya silinirken hata oluştu: %v", err)
+		}
+		return nil
+	})
+}
+
+// DeleteUnreferenced, referansı kalmamış dosyaları siler. Silinmiş bir podcast'in işlenmesi
+// sırasında yüklenen dosyaları temizlemek için kullanılır; hatalar yalnızca loglanır.
+func (s *StorageService) DeleteUnreferenced(key string, derived ...string) {
+	if err := s.PurgeBlob(key, derived...); err != nil {
+		fmt.Printf("Depolama - HATA: Dosya silinemedi. Key: %s, Hata: %v\n", key, err)
+	}
+}
+
+// UploadStream, okuyucudaki veriyi verilen anahtarla depoya yükler. contentType boşsa gönderilmez.
+func (s *StorageService) UploadStream(key string, body io.Reader, contentType string) error {
+	fmt.Printf("Depolama - Dosya yükleme işlemi başlatıldı. Key: %s\n", key)
+
+	if err := s.store.Put(context.TODO(), key, body, contentType); err != nil {
+		fmt.Printf("Depolama - HATA: Dosya yüklenirken hata oluştu. Key: %s, Hata: %v\n", key, err)
+		return err
+	}
+
+	fmt.Printf("Depolama - Dosya başarıyla yüklendi. Key: %s\n", key)
+	return nil
+}
+
+// UploadBytes, bellekteki veriyi verilen anahtarla depoya yükler
+func (s *StorageService) UploadBytes(key string, data []byte, contentType string) error {
+	return s.UploadStream(key, bytes.NewReader(data), contentType)
+}
+
+// DownloadFile, dosyanın içeriğini depodan indirir
+func (s *StorageService) DownloadFile(key string) ([]byte, error) {
+	src, err := s.OpenFile(key)
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+
+	return io.ReadAll(src)
+}
+
+func (s *StorageService) DeleteFile(key string) error {
+	fmt.Printf("Depolama - Dosya silme işlemi başlatıldı. Key: %s\n", key)
+
+	if key == "" {
+		fmt.Println("Depolama - HATA: Boş key ile silme işlemi yapılamaz")
+		return fmt.Errorf("dosya key'i boş olamaz")
+	}
+
+	key = objectKey(key)
+
+	// Önce dosyanın var olup olmadığını kontrol et
+	if _, err := s.store.Head(context.TODO(), key); err != nil {
+		fmt.Printf("Depolama - HATA: Dosya bulunamadı veya erişilemedi. Key: %s, Hata: %v\n", key, err)
+		return fmt.Errorf("dosya bulunamadı veya erişilemedi: %v", err)
+	}
+
+	if err := s.store.Delete(context.TODO(), key); err != nil {
+		fmt.Printf("Depolama - HATA: Dosya silinirken hata oluştu. Key: %s, Hata: %v\n", key, err)
+		return fmt.Errorf("dosya silinirken hata oluştu: %v", err)
+	}
+
+	fmt.Printf("Depolama - Dosya başarıyla silindi. Key: %s\n", key)
+	return nil
+}
+
+// objectKey, kayıttaki değerin depodaki anahtarını döndürür. Eski kayıtlarda key yerine
+// tam R2 URL'i tutulmuş olabilir.
+func objectKey(key string) string {
+	if strings.Contains(key, "r2.cloudflarestorage.com") {
+		parts := strings.Split(key, "r2.cloudflarestorage.com/")
+		if len(parts) > 1 {
+			return parts[1]
+		}
+	}
+	return key
+}
+
+// PresignPut, istemcinin dosyayı doğrudan depoya yükleyebileceği imzalı bir PUT isteği
+// oluşturur. Content-Type ve Content-Length imzaya dahil edildiğinden istemci başka bir
+// türde veya boyutta dosya yükleyemez. İstemcinin göndermesi gereken başlıklar da döner.
+func (s *StorageService) PresignPut(key, contentType string, size int64, expires time.Duration) (string, http.Header, error) {
+	return s.store.PresignPut(context.TODO(), key, contentType, size, expires)
+}
+
+// HeadFile, dosyanın boyutunu ve içerik türünü döndürür
+func (s *StorageService) HeadFile(key string) (int64, string, error) {
+	info, err := s.store.Head(context.TODO(), key)
+	if err != nil {
+		return 0, "", err
+	}
+	return info.Size, info.ContentType, nil
+}
+
+// ObjectInfo, depodaki bir dosyanın anahtarı, boyutu ve son değiştirilme zamanıdır
+type ObjectInfo = storage.ObjectInfo
+
+// StatFile, dosyanın bilgilerini döndürür; dosya yoksa ErrFileNotFound döner
+func (s *StorageService) StatFile(key string) (*ObjectInfo, error) {
+	return s.store.Head(context.TODO(), key)
+}
+
+// ListFiles, anahtarı prefix ile başlayan dosyaları fn ile tek tek dolaşır. fn hata
+// döndürürse listeleme durur ve hata döndürülür.
+func (s *StorageService) ListFiles(prefix string, fn func(ObjectInfo) error) error {
+	return s.store.List(context.TODO(), prefix, fn)
+}
+
+// OpenFile, dosyanın içeriğini akış olarak okumak için açar. Okuyucu kapatılmalıdır.
+func (s *StorageService) OpenFile(key string) (io.ReadCloser, error) {
+	object, err := s.store.Get(context.TODO(), key, storage.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("dosya indirilemedi: %v", err)
+	}
+	return object.Body, nil
+}
+
+func (s *StorageService) GetPresignedURL(key string, expires time.Duration) (string, error) {
+	return s.store.PresignGet(context.TODO(), key, expires)
+}
+
+// multipart, sürücü parça parça yüklemeyi desteklemiyorsa hata döner
+func (s *StorageService) multipart() (storage.MultipartStore, error) {
+	store, ok := s.store.(storage.MultipartStore)
+	if !ok {
+		return nil, errors.New("depolama sürücüsü multipart yüklemeyi desteklemiyor")
+	}
+	return store, nil
+}
+
+// SupportsMultipart, sürücünün multipart yüklemeyi destekleyip desteklemediğini döndürür
+func (s *StorageService) SupportsMultipart() bool {
+	_, err := s.multipart()
+	return err == nil
+}
+
+// CreateMultipartUpload, parça parça yüklenecek bir dosya için multipart yükleme başlatır
+// ve yükleme kimliğini döndürür
+func (s *StorageService) CreateMultipartUpload(key, contentType string) (string, error) {
+	store, err := s.multipart()
+	if err != nil {
+		return "", err
+	}
+	uploadID, err := store.CreateMultipartUpload(context.TODO(), key, contentType)
+	if err != nil {
+		return "", fmt.Errorf("multipart yükleme başlatılamadı: %v", err)
+	}
+	return uploadID, nil
+}
+
+// UploadPart, multipart yüklemeye verilen numarayla bir parça ekler. Son parça hariç
+// parçalar en az 5 MB olmalıdır.
+func (s *StorageService) UploadPart(key, uploadID string, partNumber int32, data []byte) error {
+	store, err := s.multipart()
+	if err != nil {
+		return err
+	}
+	if err := store.UploadPart(context.TODO(), key, uploadID, partNumber, data); err != nil {
+		return fmt.Errorf("parça yüklenemedi: %v", err)
+	}
+	return nil
+}
+
+// CompleteMultipartUpload, yüklenen parçaları sırayla birleştirerek dosyayı oluşturur
+func (s *StorageService) CompleteMultipartUpload(key, uploadID string) error {
+	store, err := s.multipart()
+	if err != nil {
+		return err
+	}
+	if err := store.CompleteMultipartUpload(context.TODO(), key, uploadID); err != nil {
+		return fmt.Errorf("multipart yükleme tamamlanamadı: %v", err)
+	}
+	return nil
+}
+
+// AbortMultipartUpload, tamamlanmamış multipart yüklemeyi ve yüklenen parçalarını siler
+func (s *StorageService) AbortMultipartUpload(key, uploadID string) error {
+	store, err := s.multipart()
+	if err != nil {
+		return err
+	}
+	if err := store.AbortMultipartUpload(context.TODO(), key, uploadID); err != nil {
+		return fmt.Errorf("multipart yükleme iptal edilemedi: %v", err)
+	}
+	return nil
+}
+
+// DeletePrefix, anahtarı prefix ile başlayan tüm dosyaları siler ve silinen dosya sayısını döndürür
+func (s *StorageService) DeletePrefix(prefix string) (int, error) {
+	if prefix == "" || !strings.HasSuffix(prefix, "/") {
+		return 0, fmt.Errorf("geçersiz önek: %q", prefix)
+	}
+
+	var keys []string
+	err := s.store.List(context.TODO(), prefix, func(info storage.ObjectInfo) error {
+		keys = append(keys, info.Key)
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("dosyalar listelenemedi: %v", err)
+	}
+
+	deleted := 0
+	for _, key := range keys {
+		if err := s.store.Delete(context.TODO(), key); err != nil {
+			return deleted, fmt.Errorf("dosyalar silinemedi: %v", err)
+		}
+		deleted++
+	}
+
+	fmt.Printf("Depolama - %s altındaki %d dosya silindi\n", prefix, deleted)
+	return deleted, nil
+}
+
+// ErrNotModified ve ErrInvalidRange, StreamFile'ın koşullu ve aralıklı isteklerde döndürdüğü
+// hatalardır. İmzalı dosya route'ları ayrıca ErrFileNotFound ve ErrInvalidSignature döndürür.
+var (
+	ErrNotModified      = storage.ErrNotModified
+	ErrInvalidRange     = storage.ErrInvalidRange
+	ErrFileNotFound     = storage.ErrNotFound
+	ErrInvalidSignature = storage.ErrInvalidSignature
+)
+
+// ObjectStream, depodan akış olarak okunan dosyanın gövdesi ve yanıt başlıklarıdır.
+// Body kapatılmalıdır.
+type ObjectStream = storage.Object
+
+// StreamFile, dosyayı depodan akış olarak açar. byteRange (örn. "bytes=0-1023") ve
+// ifNoneMatch boş değilse sürücüye iletilir; ETag eşleşirse ErrNotModified, aralık dosyanın
+// dışındaysa ErrInvalidRange döner.
+func (s *StorageService) StreamFile(key, byteRange, ifNoneMatch string) (*ObjectStream, error) {
+	object, err := s.store.Get(context.TODO(), key, storage.GetOptions{Range: byteRange, IfNoneMatch: ifNoneMatch})
+	if err != nil {
+		if errors.Is(err, storage.ErrNotModified) || errors.Is(err, storage.ErrInvalidRange) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("dosya indirilemedi: %v", err)
+	}
+	return object, nil
+}
+
+// OpenSignedFile, local ve memory sürücülerinin imzalı GET URL'lerini doğrulayıp dosyayı açar
+func (s *StorageService) OpenSignedFile(key, expires, signature, byteRange, ifNoneMatch string) (*ObjectStream, error) {
+	store, ok := s.store.(storage.SignedStore)
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+	if err := store.VerifyGet(key, expires, signature); err != nil {
+		return nil, err
+	}
+	if strings.Contains(byteRange, ",") {
+		byteRange = ""
+	}
+	return s.store.Get(context.TODO(), key, storage.GetOptions{Range: byteRange, IfNoneMatch: ifNoneMatch})
+}
+
+// PutSignedFile, local ve memory sürücülerinin imzalı PUT URL'lerini doğrulayıp dosyayı kaydeder
+func (s *StorageService) PutSignedFile(key, expires, signature, contentType string, data []byte) error {
+	store, ok := s.store.(storage.SignedStore)
+	if !ok {
+		return storage.ErrNotFound
+	}
+	if err := store.VerifyPut(key, expires, signature, contentType, int64(len(data))); err != nil {
+		return err
+	}
+	return s.store.Put(context.TODO(), key, bytes.NewReader(data), contentType)
+}
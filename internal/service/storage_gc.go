@@ -0,0 +1,212 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"shortcast/internal/cover"
+	"shortcast/internal/repository"
+	"time"
+)
+
+// gcPrefixes, sahipsiz dosya temizliğinin taradığı öneklerdir. uploads/ altındaki geçici
+// dosyalar tamamlanmamış bir yüklemeye ait değilse yalnızca kuyruktaki bir iş tarafından
+// kullanılır; kuyruk veritabanında tutulmadığından bu dosyalar grace süresince korunur ve
+// işi tamamlanmadan (ör. kuyruğa alınmadan önce süreç çöktüğü için) kalanlar silinir. tus
+// yüklemeleri ve HLS paketleri kendi temizlik işleriyle silinir.
+var gcPrefixes = []string{"audio/", "covers/", "avatars/", "uploads/"}
+
+// StorageGCService, depoda bulunan ancak hiçbir podcast'e veya kullanıcıya ait olmayan ses,
+// kapak, avatar ve geçici yükleme dosyalarını bulup siler. Bu dosyalar yükleme hatalarında silinemeyen, silinmiş (soft delete) podcastlerden
+// kalan ya da referansı bırakılırken silinemeyen dosyalardır.
+type StorageGCService struct {
+	podcastRepo    *repository.PodcastRepository
+	userRepo       *repository.UserRepository
+	blobRepo       *repository.BlobRepository
+	StorageService *StorageService
+}
+
+type StorageGCResult struct {
+	Scanned  int
+	Orphaned int
+	Deleted  int
+	Failed   int
+	// Bytes, sahipsiz dosyaların toplam boyutudur
+	Bytes int64
+}
+
+func NewStorageGCService(podcastRepo *repository.PodcastRepository, userRepo *repository.UserRepository, blobRepo *repository.BlobRepository, storageService *StorageService) *StorageGCService {
+	return &StorageGCService{
+		podcastRepo:    podcastRepo,
+		userRepo:       userRepo,
+		blobRepo:       blobRepo,
+		StorageService: storageService,
+	}
+}
+
+// Run, gcPrefixes altındaki dosyaları listeler ve podcastlerin ya da kullanıcıların anahtarlarıyla
+// veya referansı olan içerik adresli dosyalarla eşleşmeyenleri siler. Yüklemesi süren dosyalar
+// silinmesin diye son grace süresi içinde değiştirilmiş dosyalara dokunulmaz. dryRun true ise sahipsiz dosyalar
+// yalnızca raporlanır. Podcastler batchSize'lık gruplar halinde okunur.
+func (s *StorageGCService) Run(grace time.Duration, batchSize int, dryRun bool) (*StorageGCResult, error) {
+	result := &StorageGCResult{}
+	cutoff := time.Now().Add(-grace)
+
+	// Dosyalar canlı anahtarlardan önce listelenir; aradaki sürede oluşturulan podcastlerin
+	// dosyaları böylece canlı anahtarlar arasında yer alır
+	var candidates []ObjectInfo
+	for _, prefix := range gcPrefixes {
+		err := s.StorageService.ListFiles(prefix, func(info ObjectInfo) error {
+			result.Scanned++
+			if info.LastModified.Before(cutoff) {
+				candidates = append(candidates, info)
+			}
+			return nil
+		})
+		if err != nil {
+			return result, fmt.Errorf("dosyalar listelenemedi: %v", err)
+		}
+	}
+
+	live, err := s.liveKeys(batchSize)
+	if err != nil {
+		return result, err
+	}
+
+	for _, info := range candidates {
+		if live[info.Key] {
+			continue
+		}
+		result.Orphaned++
+		result.Bytes += info.Size
+		fmt.Printf("Depolama GC - Sahipsiz dosya: %s, %d bayt, son değişiklik %s\n",
+			info.Key, info.Size, info.LastModified.UTC().Format(time.RFC3339))
+
+		if dryRun {
+			continue
+		}
+
+		deleted, err := s.deleteOrphan(info.Key, cutoff)
+		if err != nil {
+			fmt.Printf("Depolama GC - HATA: Dosya silinemedi. Key: %s, Hata: %v\n", info.Key, err)
+			result.Failed++
+			continue
+		}
+		if deleted {
+			result.Deleted++
+		}
+	}
+
+	return result, nil
+}
+
+// liveKeys, silinmemiş podcastlerin ve referansı olan içerik adresli dosyaların anahtarlarını,
+// bunlardan türetilen dalga formu, normalize edilmiş ses ve kapak sürümü anahtarlarıyla
+// birlikte döndürür. Kullanıcıların avatarları ve tamamlanmamış yüklemelerin geçici dosyaları
+// da canlı sayılır.
+func (s *StorageGCService) liveKeys(batchSize int) (map[string]bool, error) {
+	live := make(map[string]bool)
+	add := func(keys ...string) {
+		for _, key := range keys {
+			if key != "" {
+				live[objectKey(key)] = true
+			}
+		}
+	}
+	addAudio := func(key string) {
+		if key != "" {
+			add(key, waveformKey(key), normalizedAudioKey(key))
+		}
+	}
+	addCover := func(key string) {
+		if key != "" {
+			add(key)
+			add(cover.RenditionKeys(key)...)
+		}
+	}
+
+	var lastID uint
+	for {
+		podcasts, err := s.podcastRepo.GetMediaKeys(lastID, batchSize)
+		if err != nil {
+			return nil, fmt.Errorf("podcast anahtarları okunamadı: %v", err)
+		}
+		if len(*podcasts) == 0 {
+			break
+		}
+		for _, podcast := range *podcasts {
+			lastID = podcast.ID
+			addAudio(podcast.AudioKey)
+			addCover(podcast.CoverKey)
+			add(podcast.WaveformKey, podcast.NormalizedAudioKey)
+		}
+	}
+
+	// Henüz podcast'e bağlanmamış yüklemelerin dosyaları
+	audioKeys, err := s.blobRepo.GetReferencedKeys("audio/")
+	if err != nil {
+		return nil, fmt.Errorf("dosya referansları okunamadı: %v", err)
+	}
+	for _, key := range audioKeys {
+		addAudio(key)
+	}
+	coverKeys, err := s.blobRepo.GetReferencedKeys("covers/")
+	if err != nil {
+		return nil, fmt.Errorf("dosya referansları okunamadı: %v", err)
+	}
+	for _, key := range coverKeys {
+		addCover(key)
+	}
+
+	// Kullanıcıların avatarları ve henüz kullanıcıya bağlanmamış avatar yüklemeleri
+	avatarKeys, err := s.userRepo.GetAvatarKeys()
+	if err != nil {
+		return nil, fmt.Errorf("avatar anahtarları okunamadı: %v", err)
+	}
+	add(avatarKeys...)
+	avatarKeys, err = s.blobRepo.GetReferencedKeys("avatars/")
+	if err != nil {
+		return nil, fmt.Errorf("dosya referansları okunamadı: %v", err)
+	}
+	add(avatarKeys...)
+
+	// İstemcinin doğrudan yüklediği ancak henüz tamamlamadığı dosyalar
+	uploadKeys, err := s.podcastRepo.GetPendingUploadKeys()
+	if err != nil {
+		return nil, fmt.Errorf("yükleme anahtarları okunamadı: %v", err)
+	}
+	add(uploadKeys...)
+
+	return live, nil
+}
+
+// errRecentlyModified, deleteOrphan'da listelemeden sonra değişmiş dosyalar için döner
+var errRecentlyModified = errors.New("dosya yakın zamanda değişmiş")
+
+// deleteOrphan, dosyayı silmeden önce listelemeden sonra yeniden referans alıp almadığını ya da
+// aynı içerik yeniden yüklendiği için değişip değişmediğini kontrol eder. Referansı bırakılmış
+// ancak nesnesi silinememiş dosyaların kayıtları da nesneyle birlikte silinir.
+func (s *StorageGCService) deleteOrphan(key string, cutoff time.Time) (bool, error) {
+	deleted := false
+	err := s.blobRepo.PurgeBlob(key, func() error {
+		info, err := s.StorageService.StatFile(key)
+		if errors.Is(err, ErrFileNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !info.LastModified.Before(cutoff) {
+			return errRecentlyModified
+		}
+
+		if err := s.StorageService.DeleteFile(key); err != nil {
+			return err
+		}
+		deleted = true
+		return nil
+	})
+	if errors.Is(err, errRecentlyModified) {
+		return false, nil
+	}
+	return deleted, err
+}
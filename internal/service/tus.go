@@ -0,0 +1,377 @@
+package service
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"os"
+	"path"
+	"shortcast/internal/cover"
+	"shortcast/internal/dto"
+	"shortcast/internal/model"
+	"shortcast/internal/repository"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// tusUploadTTL, tus yüklemelerinin tamamlanması için tanınan süredir. Bağlantısı sık
+	// kopan istemcilerin devam edebilmesi için imzalı yüklemelerden uzun tutulur.
+	tusUploadTTL = 24 * time.Hour
+	// tusLockTimeout, bir PATCH isteğinin yüklemeyi en fazla ne kadar kilitli tutabileceğidir
+	tusLockTimeout = 5 * time.Minute
+
+	TusKindAudio = "audio"
+	TusKindCover = "cover"
+)
+
+// TusChecksumAlgorithms, Upload-Checksum başlığında desteklenen algoritmalardır
+var TusChecksumAlgorithms = []string{"md5", "sha1", "sha256"}
+
+// TusService, tus 1.0 protokolüyle kesintiye dayanıklı, parça parça yüklemeleri yönetir.
+// Kapak fotoğrafı ve ses dosyası ayrı yüklemelerdir; ses yüklemesi tamamlandığında
+// dosyalar spool dizinine alınır ve podcast UploadPodcast ile aynı şekilde oluşturulur.
+type TusService struct {
+	podcastRepo    *repository.PodcastRepository
+	userRepo       *repository.UserRepository
+	podcastService *PodcastService
+	store          TusStore
+}
+
+func NewTusService(podcastRepo *repository.PodcastRepository, userRepo *repository.UserRepository, podcastService *PodcastService, store TusStore) *TusService {
+	return &TusService{
+		podcastRepo:    podcastRepo,
+		userRepo:       userRepo,
+		podcastService: podcastService,
+		store:          store,
+	}
+}
+
+// MaxSize, bir tus yüklemesinin en büyük boyutudur (Tus-Max-Size)
+func (s *TusService) MaxSize() int64 {
+	return maxAudioUploadSize
+}
+
+// CreateUpload, Upload-Metadata'daki bilgilerle yeni bir yükleme oluşturur. kind "cover"
+// veya "audio" olmalıdır; ses yüklemeleri title, category ve tamamlanmış bir kapak
+// yüklemesinin kimliğini (cover_upload_id) taşır.
+func (s *TusService) CreateUpload(userID uint, length int64, metadata map[string]string) (*model.TusUpload, error) {
+	filename := path.Base(metadata["filename"])
+	if metadata["filename"] == "" {
+		return nil, errors.New("dosya adı (filename) zorunludur")
+	}
+
+	upload := &model.TusUpload{
+		UserID:    userID,
+		Kind:      metadata["kind"],
+		Filename:  filename,
+		Length:    length,
+		ExpiresAt: time.Now().Add(tusUploadTTL),
+	}
+
+	switch upload.Kind {
+	case TusKindCover:
+		if length > cover.MaxFileSize {
+			return nil, errCoverTooLarge
+		}
+	case TusKindAudio:
+		if length > maxAudioUploadSize {
+			return nil, fmt.Errorf("ses dosyası %d MB'tan büyük olamaz", maxAudioUploadSize/1024/1024)
+		}
+		if err := s.applyAudioMetadata(upload, metadata); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errors.New("kind alanı audio veya cover olmalı")
+	}
+
+	id, err := newTusUploadID()
+	if err != nil {
+		return nil, err
+	}
+	upload.ID = id
+	upload.StorageKey = "tus/" + id
+
+	if err := s.store.Create(upload); err != nil {
+		return nil, fmt.Errorf("yükleme oluşturulamadı: %v", err)
+	}
+	if err := s.podcastRepo.SaveTusUpload(upload); err != nil {
+		s.store.Remove(upload)
+		return nil, err
+	}
+
+	// Kapak yüklemesi, ses yüklemesi tamamlanana kadar silinmemeli
+	if upload.Kind == TusKindAudio {
+		if err := s.podcastRepo.ExtendTusUpload(upload.CoverUploadID, upload.ExpiresAt); err != nil {
+			fmt.Printf("Tus - HATA: Kapak yüklemesinin süresi uzatılamadı. UploadID: %s, Hata: %v\n", upload.CoverUploadID, err)
+		}
+	}
+	return upload, nil
+}
+
+func (s *TusService) applyAudioMetadata(upload *model.TusUpload, metadata map[string]string) error {
+	upload.Title = metadata["title"]
+	upload.Category = metadata["category"]
+	if upload.Title == "" || upload.Category == "" {
+		return errors.New("başlık ve kategori alanları zorunludur")
+	}
+
+	coverUpload, err := s.podcastRepo.GetTusUpload(metadata["cover_upload_id"])
+	if err != nil || coverUpload.UserID != upload.UserID || coverUpload.Kind != TusKindCover || coverUpload.PodcastID != nil {
+		return errors.New("kapak fotoğrafı yüklemesi bulunamadı")
+	}
+	if coverUpload.Offset < coverUpload.Length {
+		return errors.New("kapak fotoğrafı yüklemesi henüz tamamlanmamış")
+	}
+	upload.CoverUploadID = coverUpload.ID
+
+	for field, target := range map[string]**int64{"start_ms": &upload.StartMs, "end_ms": &upload.EndMs} {
+		if metadata[field] == "" {
+			continue
+		}
+		value, err := strconv.ParseInt(metadata[field], 10, 64)
+		if err != nil || value < 0 {
+			return errors.New("start_ms ve end_ms sıfır veya pozitif tam sayı olmalıdır")
+		}
+		*target = &value
+	}
+	return nil
+}
+
+// GetUpload, kullanıcının yüklemesini döndürür. Başka kullanıcıların yüklemeleri
+// bulunamadı olarak raporlanır.
+func (s *TusService) GetUpload(id string, userID uint) (*model.TusUpload, error) {
+	upload, err := s.podcastRepo.GetTusUpload(id)
+	if err != nil {
+		return nil, err
+	}
+	if upload.UserID != userID {
+		return nil, errors.New("yükleme bulunamadı")
+	}
+	if time.Now().After(upload.ExpiresAt) {
+		return nil, errors.New("yüklemenin süresi dolmuş")
+	}
+	return upload, nil
+}
+
+// WriteChunk, parçayı offset konumuna ekler. checksum verilirse ("<algoritma> <base64>")
+// parça yazılmadan önce doğrulanır. Ses yüklemesinin son parçası yazıldığında podcast
+// oluşturulur; oluşturma başarısız olursa boş bir PATCH ile yeniden denenebilir.
+func (s *TusService) WriteChunk(id string, userID uint, offset int64, data []byte, checksum string) (*model.TusUpload, error) {
+	upload, err := s.GetUpload(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if offset != upload.Offset {
+		return nil, errors.New("yükleme konumu eşleşmiyor")
+	}
+	if offset+int64(len(data)) > upload.Length {
+		return nil, errors.New("yükleme boyutu aşıldı")
+	}
+	if checksum != "" {
+		if err := verifyTusChecksum(checksum, data); err != nil {
+			return nil, err
+		}
+	}
+
+	locked, err := s.podcastRepo.LockTusUpload(upload.ID, time.Now().Add(tusLockTimeout))
+	if err != nil {
+		return nil, err
+	}
+	if !locked {
+		return nil, errors.New("yükleme başka bir istek tarafından yazılıyor")
+	}
+	defer func() {
+		if err := s.podcastRepo.UnlockTusUpload(upload.ID); err != nil {
+			fmt.Printf("Tus - HATA: Yükleme kilidi kaldırılamadı. UploadID: %s, Hata: %v\n", upload.ID, err)
+		}
+	}()
+
+	// Kilit alınırken başka bir istek yazmış olabilir
+	upload, err = s.podcastRepo.GetTusUpload(upload.ID)
+	if err != nil {
+		return nil, err
+	}
+	if offset != upload.Offset {
+		return nil, errors.New("yükleme konumu eşleşmiyor")
+	}
+
+	if len(data) > 0 {
+		if err := s.store.Write(upload, data); err != nil {
+			return nil, fmt.Errorf("parça kaydedilemedi: %v", err)
+		}
+		upload.Offset += int64(len(data))
+		if upload.Offset == upload.Length {
+			if err := s.store.Finish(upload); err != nil {
+				return nil, fmt.Errorf("yükleme tamamlanamadı: %v", err)
+			}
+		}
+		if err := s.podcastRepo.UpdateTusUpload(upload); err != nil {
+			return nil, err
+		}
+	}
+
+	if upload.Kind == TusKindAudio && upload.Offset == upload.Length && upload.PodcastID == nil {
+		if err := s.createPodcast(upload); err != nil {
+			return nil, err
+		}
+	}
+	return upload, nil
+}
+
+// createPodcast, tamamlanan ses ve kapak yüklemelerini spool dizinine alır ve podcast'i
+// oluşturur. Başarılı olursa yüklemelerin verisi depodan silinir.
+func (s *TusService) createPodcast(upload *model.TusUpload) error {
+	coverUpload, err := s.podcastRepo.GetTusUpload(upload.CoverUploadID)
+	if err != nil || coverUpload.PodcastID != nil {
+		return errors.New("kapak fotoğrafı yüklemesi bulunamadı")
+	}
+
+	user, err := s.userRepo.GetUserByID(upload.UserID)
+	if err != nil {
+		return fmt.Errorf("kullanıcı bulunamadı: %v", err)
+	}
+
+	audioPath, err := s.spool(upload)
+	if err != nil {
+		return err
+	}
+	coverPath, err := s.spool(coverUpload)
+	if err != nil {
+		os.Remove(audioPath)
+		return err
+	}
+
+	response, err := s.podcastService.createPodcast(user, &dto.UploadPodcastRequest{
+		UserID:   upload.UserID,
+		Title:    upload.Title,
+		Category: upload.Category,
+		StartMs:  upload.StartMs,
+		EndMs:    upload.EndMs,
+	}, &spooledUpload{
+		audioPath: audioPath,
+		coverPath: coverPath,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Kayıtlar süreleri dolana kadar podcast kimliğiyle birlikte tutulur
+	for _, u := range []*model.TusUpload{upload, coverUpload} {
+		u.PodcastID = &response.ID
+		if err := s.podcastRepo.UpdateTusUpload(u); err != nil {
+			fmt.Printf("Tus - HATA: Yükleme güncellenemedi. UploadID: %s, Hata: %v\n", u.ID, err)
+		}
+		if err := s.store.Remove(u); err != nil {
+			fmt.Printf("Tus - HATA: Yükleme verisi silinemedi. UploadID: %s, Hata: %v\n", u.ID, err)
+		}
+	}
+	return nil
+}
+
+func (s *TusService) spool(upload *model.TusUpload) (string, error) {
+	src, err := s.store.Open(upload)
+	if err != nil {
+		return "", fmt.Errorf("yüklenen dosya okunamadı: %v", err)
+	}
+	defer src.Close()
+
+	return s.podcastService.mediaService.spool(src, "")
+}
+
+// CleanupExpiredUploads, süresi dolan tus yüklemelerini ve verilerini siler
+func (s *TusService) CleanupExpiredUploads() (int, error) {
+	deleted := 0
+	for {
+		uploads, err := s.podcastRepo.GetExpiredTusUploads(time.Now(), 100)
+		if err != nil {
+			return deleted, err
+		}
+		if len(*uploads) == 0 {
+			return deleted, nil
+		}
+
+		for _, upload := range *uploads {
+			// Podcast'e dönüşen yüklemelerin verisi zaten silinmiştir
+			if upload.PodcastID == nil {
+				if err := s.store.Remove(&upload); err != nil {
+					fmt.Printf("Tus - HATA: Yükleme verisi silinemedi. UploadID: %s, Hata: %v\n", upload.ID, err)
+				}
+			}
+			if err := s.podcastRepo.DeleteTusUpload(upload.ID); err != nil {
+				return deleted, err
+			}
+			deleted++
+		}
+	}
+}
+
+// StartCleanup, süresi dolan yüklemeleri ctx iptal edilene kadar düzenli aralıklarla temizler
+func (s *TusService) StartCleanup(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(uploadCleanupInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				deleted, err := s.CleanupExpiredUploads()
+				if err != nil {
+					fmt.Printf("Tus - HATA: Süresi dolan yüklemeler temizlenemedi: %v\n", err)
+				}
+				if deleted > 0 {
+					fmt.Printf("Tus - Süresi dolan %d yükleme temizlendi\n", deleted)
+				}
+			}
+		}
+	}()
+}
+
+// verifyTusChecksum, Upload-Checksum başlığındaki özeti parçayla karşılaştırır
+func verifyTusChecksum(header string, data []byte) error {
+	algorithm, encoded, ok := strings.Cut(header, " ")
+	if !ok {
+		return errors.New("geçersiz Upload-Checksum başlığı")
+	}
+	expected, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return errors.New("geçersiz Upload-Checksum başlığı")
+	}
+
+	var h hash.Hash
+	switch algorithm {
+	case "md5":
+		h = md5.New()
+	case "sha1":
+		h = sha1.New()
+	case "sha256":
+		h = sha256.New()
+	default:
+		return errors.New("desteklenmeyen checksum algoritması")
+	}
+	h.Write(data)
+
+	if string(h.Sum(nil)) != string(expected) {
+		return errors.New("checksum eşleşmiyor")
+	}
+	return nil
+}
+
+// newTusUploadID, yükleme URL'inde kullanılan tahmin edilemeyen kimliği oluşturur
+func newTusUploadID() (string, error) {
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(token), nil
+}
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"shortcast/internal/model"
+)
+
+// TusStore, tus yüklemelerinin parçalarını saklar. Parçalar her zaman yüklemenin mevcut
+// konumuna eklenir; konum ve sürücü durumu çağıran tarafından veritabanına kaydedilir.
+type TusStore interface {
+	// Create, yükleme için depolama alanı ayırır ve sürücü durumunu upload'a yazar
+	Create(upload *model.TusUpload) error
+	// Write, data'yı upload.Offset konumuna ekler
+	Write(upload *model.TusUpload, data []byte) error
+	// Finish, tüm parçalar yazıldıktan sonra dosyayı okunabilir hale getirir
+	Finish(upload *model.TusUpload) error
+	Open(upload *model.TusUpload) (io.ReadCloser, error)
+	// Remove, yüklemenin tamamlanmış veya yarım kalmış tüm verisini siler
+	Remove(upload *model.TusUpload) error
+}
+
+// NewTusStore, sürücü adına göre tus deposunu oluşturur: s3 veya disk. Sürücü boşsa
+// depolama sürücüsü multipart yüklemeyi destekliyorsa s3, desteklemiyorsa disk kullanılır.
+func NewTusStore(driver, dir string, storageService *StorageService) (TusStore, error) {
+	if driver == "" {
+		driver = "disk"
+		if storageService.SupportsMultipart() {
+			driver = "s3"
+		}
+	}
+
+	switch driver {
+	case "s3", "r2":
+		if !storageService.SupportsMultipart() {
+			return nil, fmt.Errorf("depolama sürücüsü tus için multipart yüklemeyi desteklemiyor, TUS_STORAGE=disk kullanın")
+		}
+		return &s3TusStore{storage: storageService}, nil
+	case "disk":
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, err
+		}
+		return &diskTusStore{dir: dir}, nil
+	default:
+		return nil, fmt.Errorf("bilinmeyen tus depolama sürücüsü: %s", driver)
+	}
+}
+
+// diskTusStore, yüklemeleri yerel diskte tek bir dosyada biriktirir. Geliştirme ortamı ve
+// tek sunuculu kurulumlar içindir.
+type diskTusStore struct {
+	dir string
+}
+
+func (s *diskTusStore) path(upload *model.TusUpload) string {
+	return filepath.Join(s.dir, filepath.Base(upload.StorageKey))
+}
+
+func (s *diskTusStore) Create(upload *model.TusUpload) error {
+	file, err := os.OpenFile(s.path(upload), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+func (s *diskTusStore) Write(upload *model.TusUpload, data []byte) error {
+	file, err := os.OpenFile(s.path(upload), os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	// Önceki bir isteğin yarım kalan yazımı konumdan itibaren ezilir
+	if _, err := file.WriteAt(data, upload.Offset); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func (s *diskTusStore) Finish(upload *model.TusUpload) error {
+	return os.Truncate(s.path(upload), upload.Length)
+}
+
+func (s *diskTusStore) Open(upload *model.TusUpload) (io.ReadCloser, error) {
+	return os.Open(s.path(upload))
+}
+
+func (s *diskTusStore) Remove(upload *model.TusUpload) error {
+	if err := os.Remove(s.path(upload)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// s3MinPartSize, S3 multipart yüklemelerinde son parça hariç en küçük parça boyutudur
+const s3MinPartSize = 5 * 1024 * 1024
+
+// s3TusStore, yüklemeleri S3 uyumlu depoda multipart yükleme olarak biriktirir. tus istemcileri
+// istedikleri boyutta parça gönderebildiğinden 5 MB'tan küçük parçalar <anahtar>.part
+// nesnesinde birleştirilir ve yeterince büyüdüğünde multipart yüklemeye eklenir.
+type s3TusStore struct {
+	storage *StorageService
+}
+
+func (s *s3TusStore) partialKey(upload *model.TusUpload) string {
+	return upload.StorageKey + ".part"
+}
+
+func (s *s3TusStore) Create(upload *model.TusUpload) error {
+	multipartID, err := s.storage.CreateMultipartUpload(upload.StorageKey, "")
+	if err != nil {
+		return err
+	}
+	upload.MultipartID = multipartID
+	return nil
+}
+
+func (s *s3TusStore) Write(upload *model.TusUpload, data []byte) error {
+	if upload.PartialSize > 0 {
+		partial, err := s.storage.DownloadFile(s.partialKey(upload))
+		if err != nil {
+			return err
+		}
+		if int64(len(partial)) < upload.PartialSize {
+			return fmt.Errorf("bekleyen parça eksik: %d/%d bayt", len(partial), upload.PartialSize)
+		}
+		// Kaydedilemeyen önceki bir yazımın fazla baytları atılır
+		data = append(partial[:upload.PartialSize], data...)
+	}
+
+	last := upload.Offset-upload.PartialSize+int64(len(data)) == upload.Length
+	if len(data) < s3MinPartSize && !last {
+		if err := s.storage.UploadBytes(s.partialKey(upload), data, ""); err != nil {
+			return err
+		}
+		upload.PartialSize = int64(len(data))
+		return nil
+	}
+
+	if err := s.storage.UploadPart(upload.StorageKey, upload.MultipartID, int32(upload.PartCount+1), data); err != nil {
+		return err
+	}
+	// Bekleyen parça nesnesi bir sonraki küçük parçada ezilir, yükleme silinirken temizlenir
+	upload.PartCount++
+	upload.PartialSize = 0
+	return nil
+}
+
+func (s *s3TusStore) Finish(upload *model.TusUpload) error {
+	return s.storage.CompleteMultipartUpload(upload.StorageKey, upload.MultipartID)
+}
+
+func (s *s3TusStore) Open(upload *model.TusUpload) (io.ReadCloser, error) {
+	return s.storage.OpenFile(upload.StorageKey)
+}
+
+func (s *s3TusStore) Remove(upload *model.TusUpload) error {
+	if _, _, err := s.storage.HeadFile(s.partialKey(upload)); err == nil {
+		s.storage.DeleteFile(s.partialKey(upload))
+	}
+	if upload.Offset < upload.Length {
+		return s.storage.AbortMultipartUpload(upload.StorageKey, upload.MultipartID)
+	}
+	if _, _, err := s.storage.HeadFile(upload.StorageKey); err != nil {
+		// Son parça yazıldı ancak yükleme tamamlanamadı
+		return s.storage.AbortMultipartUpload(upload.StorageKey, upload.MultipartID)
+	}
+	return s.storage.DeleteFile(upload.StorageKey)
+}
@@ -1,22 +1,446 @@
 package service
 
 import (
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/mail"
+	"net/url"
+	"shortcast/internal/config"
+	"shortcast/internal/dto"
 	"shortcast/internal/model"
 	"shortcast/internal/repository"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+const (
+	maxBioLength      = 500
+	maxLocationLength = 100
+	maxAvatarSize     = 5 * 1024 * 1024
 )
 
 type UserService struct {
-	userRepo *repository.UserRepository
+	userRepo       *repository.UserRepository
+	podcastRepo    *repository.PodcastRepository
+	socialRepo     *repository.SocialRepository
+	authRepo       *repository.AuthRepository
+	authService    *AuthService
+	StorageService *StorageService
+	RedisService   *RedisService
+	cfg            *config.Config
 }
 
-func NewUserService(userRepo *repository.UserRepository) *UserService {
-	return &UserService{userRepo: userRepo}
+func NewUserService(userRepo *repository.UserRepository, podcastRepo *repository.PodcastRepository, socialRepo *repository.SocialRepository, authRepo *repository.AuthRepository, authService *AuthService, storageService *StorageService, redisService *RedisService, cfg *config.Config) *UserService {
+	return &UserService{
+		userRepo:       userRepo,
+		podcastRepo:    podcastRepo,
+		socialRepo:     socialRepo,
+		authRepo:       authRepo,
+		authService:    authService,
+		StorageService: storageService,
+		RedisService:   redisService,
+		cfg:            cfg,
+	}
 }
 
-func (s *UserService) GetUserById(id uint) (*model.User, error) {
+// GetUser, kullanıcı bilgilerini döndürür. E-posta adresi yalnızca kullanıcı kendi bilgilerine bakıyorsa eklenir.
+func (s *UserService) GetUser(id, viewerID uint) (*dto.UserResponse, error) {
 	user, err := s.userRepo.GetUserByID(id)
 	if err != nil {
 		return nil, err
 	}
-	return user, nil
+	return s.toUserResponse(user, viewerID)
+}
+
+// GetProfile, yaratıcı sayfası için kullanıcı bilgilerini herkese açık sayılarla birlikte döndürür
+func (s *UserService) GetProfile(id, viewerID uint) (*dto.ProfileResponse, error) {
+	user, err := s.userRepo.GetUserByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	userResponse, err := s.toUserResponse(user, viewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	podcasts, err := s.userRepo.CountPodcasts(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	followers, err := s.socialRepo.CountFollowers(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	following, err := s.socialRepo.CountFollowing(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	likesReceived, err := s.userRepo.CountLikesReceived(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	isFollowing := false
+	if viewerID != user.ID {
+		isFollowing, err = s.socialRepo.IsFollowing(viewerID, user.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &dto.ProfileResponse{
+		UserResponse: *userResponse,
+		CreatedAt:    user.CreatedAt,
+		IsFollowing:  isFollowing,
+		Stats: dto.ProfileStats{
+			Podcasts:      podcasts,
+			Followers:     followers,
+			Following:     following,
+			LikesReceived: likesReceived,
+		},
+	}, nil
+}
+
+// UpdateProfile, kullanıcının ad, kullanıcı adı ve e-posta bilgilerini günceller.
+// E-posta değişirse yeni adrese doğrulama linki gönderilir.
+func (s *UserService) UpdateProfile(userID uint, req *dto.UpdateProfileRequest) (*dto.UserResponse, error) {
+	user, err := s.userRepo.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := make(map[string]interface{})
+
+	if req.FirstName != nil {
+		firstName := strings.TrimSpace(*req.FirstName)
+		if firstName == "" {
+			return nil, errors.New("ad boş olamaz")
+		}
+		updates["first_name"] = firstName
+	}
+
+	if req.LastName != nil {
+		lastName := strings.TrimSpace(*req.LastName)
+		if lastName == "" {
+			return nil, errors.New("soyad boş olamaz")
+		}
+		updates["last_name"] = lastName
+	}
+
+	if req.Username != nil {
+		username := strings.TrimSpace(*req.Username)
+		if username == "" {
+			return nil, errors.New("kullanıcı adı boş olamaz")
+		}
+		if username != user.Username {
+			_, err := s.userRepo.GetUserByUsername(username)
+			if err == nil {
+				return nil, errors.New("bu kullanıcı adı zaten kullanılıyor")
+			}
+			if err != gorm.ErrRecordNotFound {
+				return nil, err
+			}
+			updates["username"] = username
+		}
+	}
+
+	if req.Bio != nil {
+		bio := strings.TrimSpace(*req.Bio)
+		if utf8.RuneCountInString(bio) > maxBioLength {
+			return nil, fmt.Errorf("biyografi en fazla %d karakter olabilir", maxBioLength)
+		}
+		updates["bio"] = bio
+	}
+
+	if req.Website != nil {
+		website := strings.TrimSpace(*req.Website)
+		if website != "" {
+			parsed, err := url.Parse(website)
+			if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" || len(website) > 255 {
+				return nil, errors.New("geçersiz web sitesi adresi")
+			}
+		}
+		updates["website"] = website
+	}
+
+	if req.Location != nil {
+		location := strings.TrimSpace(*req.Location)
+		if utf8.RuneCountInString(location) > maxLocationLength {
+			return nil, fmt.Errorf("konum en fazla %d karakter olabilir", maxLocationLength)
+		}
+		updates["location"] = location
+	}
+
+	emailChanged := false
+	if req.Email != nil {
+		email := strings.TrimSpace(*req.Email)
+		if _, err := mail.ParseAddress(email); err != nil {
+			return nil, errors.New("geçersiz e-posta adresi")
+		}
+		if email != user.Email {
+			_, err := s.userRepo.GetUserByEmail(email)
+			if err == nil {
+				return nil, errors.New("bu email adresi zaten kullanılıyor")
+			}
+			if err != gorm.ErrRecordNotFound {
+				return nil, err
+			}
+			updates["email"] = email
+			emailChanged = true
+		}
+	}
+
+	if len(updates) > 0 {
+		if err := s.userRepo.UpdateProfile(userID, updates); err != nil {
+			return nil, err
+		}
+	}
+
+	user, err = s.userRepo.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if emailChanged {
+		if err := s.authService.SendVerificationEmail(user); err != nil {
+			fmt.Printf("User - HATA: Doğrulama e-postası gönderilemedi. UserID: %d, Hata: %v\n", user.ID, err)
+		}
+	}
+
+	return s.toUserResponse(user, userID)
+}
+
+// UpdateAvatar, yeni profil fotoğrafını avatars klasörüne yükler. Eski fotoğrafın
+// referansı yalnızca yeni fotoğraf kaydedildikten sonra bırakılır.
+func (s *UserService) UpdateAvatar(userID uint, avatarFile *multipart.FileHeader) (*dto.UserResponse, error) {
+	user, err := s.userRepo.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateAvatar(avatarFile); err != nil {
+		return nil, err
+	}
+
+	newAvatarKey, err := s.StorageService.UploadFile(avatarFile, "avatars")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.userRepo.UpdateAvatarKey(user.ID, newAvatarKey); err != nil {
+		// Hata durumunda yüklenen dosyanın referansını bırak
+		s.StorageService.ReleaseBlob(newAvatarKey)
+		return nil, err
+	}
+
+	// Aynı fotoğraf tekrar yüklendiyse anahtar değişmez; UploadFile'ın eklediği referans bırakılmış olur
+	if user.AvatarKey != "" {
+		s.releaseFile(user.AvatarKey)
+	}
+
+	user.AvatarKey = newAvatarKey
+	return s.toUserResponse(user, userID)
+}
+
+func (s *UserService) DeleteAvatar(userID uint) (*dto.UserResponse, error) {
+	user, err := s.userRepo.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.AvatarKey == "" {
+		return s.toUserResponse(user, userID)
+	}
+
+	if err := s.userRepo.UpdateAvatarKey(user.ID, ""); err != nil {
+		return nil, err
+	}
+	s.releaseFile(user.AvatarKey)
+
+	user.AvatarKey = ""
+	return s.toUserResponse(user, userID)
+}
+
+// ChangePassword, mevcut şifreyi doğrulayıp yeni şifreyi kaydeder ve mevcut oturum
+// dışındaki tüm oturumları sonlandırır. Harici sağlayıcıyla oluşturulmuş ve henüz
+// şifresi olmayan hesaplarda mevcut şifre yerine yakın zamanda giriş yapılmış olması istenir.
+func (s *UserService) ChangePassword(userID, currentSessionID uint, req *dto.ChangePasswordRequest) error {
+	user, err := s.userRepo.GetUserByID(userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.authService.Reauthenticate(user, currentSessionID, req.CurrentPassword); err != nil {
+		return err
+	}
+
+	if len(req.NewPassword) < 6 {
+		return errors.New("şifre en az 6 karakter olmalı")
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	if err := s.userRepo.UpdatePassword(user.ID, string(hashedPassword)); err != nil {
+		return err
+	}
+
+	return s.authRepo.RevokeUserSessions(user.ID, currentSessionID, s.accessTTL())
+}
+
+// DeleteAccount, kullanıcının hesabını podcastleri, beğenileri, yorumları ve R2'deki
+// dosyalarıyla birlikte kalıcı olarak siler
+func (s *UserService) DeleteAccount(userID, currentSessionID uint, password string) error {
+	user, err := s.userRepo.GetUserByID(userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.authService.Reauthenticate(user, currentSessionID, password); err != nil {
+		return err
+	}
+
+	return s.removeAccount(user)
+}
+
+// RemoveUser, kullanıcının hesabını DeleteAccount ile aynı şekilde siler. Admin panelinden
+// silme için kullanılır; şifre sorulmaz.
+func (s *UserService) RemoveUser(userID uint) error {
+	user, err := s.userRepo.GetUserByID(userID)
+	if err != nil {
+		return err
+	}
+
+	return s.removeAccount(user)
+}
+
+func (s *UserService) removeAccount(user *model.User) error {
+	podcasts, err := s.podcastRepo.GetPodcastsByUserID(user.ID, false)
+	if err != nil {
+		return err
+	}
+
+	// Silinen hesaba ait access token'lar süreleri dolana kadar reddedilsin
+	if err := s.authRepo.RevokeUserSessions(user.ID, 0, s.accessTTL()); err != nil {
+		return err
+	}
+
+	// Dosya referansları kayıtlarla aynı transaction içinde bırakılır; türetilmiş dosyalar
+	// ana dosyayla birlikte silinir
+	keys := []string{user.AvatarKey}
+	derived := make(map[string][]string)
+	for _, podcast := range *podcasts {
+		keys = append(keys, podcast.AudioKey, podcast.CoverKey)
+		derived[podcast.AudioKey] = []string{podcast.WaveformKey, podcast.NormalizedAudioKey}
+		derived[podcast.CoverKey] = coverRenditionKeys(&podcast)
+	}
+
+	released, err := s.userRepo.DeleteAccount(user.ID, keys...)
+	if err != nil {
+		return err
+	}
+
+	// Dosyalar veritabanı kayıtları silindikten sonra silinir; bir dosya silinemezse
+	// hesap silme işlemi yarıda kalmaz, dosya yalnızca sahipsiz kalır
+	for _, key := range released {
+		if err := s.StorageService.PurgeBlob(key, derived[key]...); err != nil {
+			fmt.Printf("User - HATA: Dosya silinemedi. Key: %s, Hata: %v\n", key, err)
+		}
+	}
+	for _, key := range keys {
+		s.deleteSignedURL(key)
+	}
+	for _, podcast := range *podcasts {
+		deleteHLS(s.StorageService, &podcast)
+		if err := s.RedisService.DeleteWaveform(podcast.ID); err != nil {
+			fmt.Printf("User - HATA: Önbellekteki dalga formu silinemedi. PodcastID: %d, Hata: %v\n", podcast.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// releaseFile, dosyanın referansını bırakır ve imzalı URL'ini önbellekten siler. Dosya ve
+// türetilmiş dosyaları başka bir kayıt kullanmıyorsa silinir. Hatalar yalnızca loglanır.
+func (s *UserService) releaseFile(key string, derived ...string) {
+	if key == "" {
+		return
+	}
+	if err := s.StorageService.ReleaseBlob(key, derived...); err != nil {
+		fmt.Printf("User - HATA: Dosya silinemedi. Key: %s, Hata: %v\n", key, err)
+	}
+	s.deleteSignedURL(key)
+}
+
+// deleteSignedURL, dosyanın önbellekteki imzalı URL'ini siler; hatalar yalnızca loglanır
+func (s *UserService) deleteSignedURL(key string) {
+	if key == "" {
+		return
+	}
+	if err := s.RedisService.DeleteSignedURL(key); err != nil {
+		fmt.Printf("User - HATA: Önbellekteki URL silinemedi. Key: %s, Hata: %v\n", key, err)
+	}
+}
+
+func (s *UserService) toUserResponse(user *model.User, viewerID uint) (*dto.UserResponse, error) {
+	response := &dto.UserResponse{
+		ID:        user.ID,
+		Firstname: user.FirstName,
+		Lastname:  user.LastName,
+		Username:  user.Username,
+		Bio:       user.Bio,
+		Website:   user.Website,
+		Location:  user.Location,
+	}
+
+	if user.ID == viewerID {
+		response.Email = user.Email
+	}
+
+	if user.AvatarKey != "" {
+		avatarURL, err := getSignedURL(s.RedisService, s.StorageService, user.AvatarKey)
+		if err != nil {
+			return nil, err
+		}
+		response.AvatarURL = avatarURL
+	}
+
+	return response, nil
+}
+
+// validateAvatar, profil fotoğrafının boyutunu ve içeriğine göre türünü kontrol eder
+func validateAvatar(file *multipart.FileHeader) error {
+	if file.Size > maxAvatarSize {
+		return fmt.Errorf("profil fotoğrafı en fazla %d MB olabilir", maxAvatarSize/(1024*1024))
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	contentType, err := detectContentType(src)
+	if err != nil {
+		return errors.New("profil fotoğrafı okunamadı")
+	}
+	if _, ok := imageContentTypeExts[contentType]; !ok {
+		return errors.New("profil fotoğrafı JPEG, PNG veya WebP olmalı")
+	}
+	return nil
+}
+
+func (s *UserService) accessTTL() time.Duration {
+	return time.Duration(s.cfg.JWTExpiration) * time.Second
 }
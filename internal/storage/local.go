@@ -0,0 +1,229 @@
+package storage
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// LocalStore, dosyaları yerel diskte saklar. Geliştirme ortamı ve tek sunuculu kurulumlar
+// içindir. Dosyalar <dir>/objects altında, içerik türü ve ETag <dir>/meta altında tutulur.
+// İmzalı URL'ler API'nin /api/files yolu üzerinden sunulur.
+type LocalStore struct {
+	*urlSigner
+	dir string
+}
+
+type localMeta struct {
+	ContentType string `json:"content_type"`
+	ETag        string `json:"etag"`
+}
+
+func NewLocalStore(dir string, signer *urlSigner) (*LocalStore, error) {
+	for _, sub := range []string{"objects", "meta"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0o755); err != nil {
+			return nil, err
+		}
+	}
+	return &LocalStore{urlSigner: signer, dir: dir}, nil
+}
+
+// paths, anahtarın dosya ve metadata yollarını döndürür. Depo dizininin dışına çıkan
+// anahtarlar reddedilir.
+func (s *LocalStore) paths(key string) (string, string, error) {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return "", "", fmt.Errorf("geçersiz dosya anahtarı: %q", key)
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return "", "", fmt.Errorf("geçersiz dosya anahtarı: %q", key)
+		}
+	}
+	name := filepath.FromSlash(key)
+	return filepath.Join(s.dir, "objects", name), filepath.Join(s.dir, "meta", name+".json"), nil
+}
+
+func (s *LocalStore) Put(ctx context.Context, key string, body io.Reader, contentType string) error {
+	objectPath, metaPath, err := s.paths(key)
+	if err != nil {
+		return err
+	}
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	if err := os.MkdirAll(filepath.Dir(objectPath), 0o755); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(metaPath), 0o755); err != nil {
+		return err
+	}
+
+	// Okuyucular yarım dosya görmesin diye önce geçici dosyaya yazılır
+	tmp, err := os.CreateTemp(filepath.Dir(objectPath), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	hash := md5.New()
+	if _, err := io.Copy(io.MultiWriter(tmp, hash), body); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	meta, err := json.Marshal(localMeta{
+		ContentType: contentType,
+		ETag:        `"` + hex.EncodeToString(hash.Sum(nil)) + `"`,
+	})
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(metaPath, meta, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), objectPath)
+}
+
+func (s *LocalStore) Get(ctx context.Context, key string, opts GetOptions) (*Object, error) {
+	info, err := s.Head(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if etagMatches(opts.IfNoneMatch, info.ETag) {
+		return nil, ErrNotModified
+	}
+
+	start, end, ranged, err := parseRange(opts.Range, info.Size)
+	if err != nil {
+		return nil, err
+	}
+
+	objectPath, _, _ := s.paths(key)
+	file, err := os.Open(objectPath)
+	if err != nil {
+		return nil, localError(err)
+	}
+
+	object := &Object{
+		Body:          file,
+		ContentLength: info.Size,
+		ContentType:   info.ContentType,
+		ETag:          info.ETag,
+		LastModified:  info.LastModified,
+	}
+	if ranged {
+		object.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.NewSectionReader(file, start, end-start+1), file}
+		object.ContentLength = end - start + 1
+		object.ContentRange = fmt.Sprintf("bytes %d-%d/%d", start, end, info.Size)
+	}
+	return object, nil
+}
+
+func (s *LocalStore) Head(ctx context.Context, key string) (*ObjectInfo, error) {
+	objectPath, metaPath, err := s.paths(key)
+	if err != nil {
+		return nil, err
+	}
+	stat, err := os.Stat(objectPath)
+	if err != nil {
+		return nil, localError(err)
+	}
+
+	info := &ObjectInfo{
+		Key:          key,
+		Size:         stat.Size(),
+		ContentType:  "application/octet-stream",
+		LastModified: stat.ModTime(),
+	}
+	if data, err := os.ReadFile(metaPath); err == nil {
+		var meta localMeta
+		if err := json.Unmarshal(data, &meta); err == nil {
+			info.ContentType = meta.ContentType
+			info.ETag = meta.ETag
+		}
+	}
+	if info.ETag == "" {
+		info.ETag = fmt.Sprintf(`"%x-%x"`, stat.ModTime().UnixNano(), stat.Size())
+	}
+	return info, nil
+}
+
+func (s *LocalStore) Delete(ctx context.Context, key string) error {
+	objectPath, metaPath, err := s.paths(key)
+	if err != nil {
+		return err
+	}
+	for _, path := range []string{objectPath, metaPath} {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *LocalStore) List(ctx context.Context, prefix string, fn func(ObjectInfo) error) error {
+	root := filepath.Join(s.dir, "objects")
+	// Yalnızca önekin bulunduğu dizin taranır
+	start := root
+	if dir := prefix[:strings.LastIndex(prefix, "/")+1]; dir != "" {
+		start = filepath.Join(root, filepath.FromSlash(dir))
+	}
+
+	err := filepath.WalkDir(start, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".upload-") {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+		info, err := s.Head(ctx, key)
+		if err != nil {
+			return err
+		}
+		return fn(*info)
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func (s *LocalStore) PresignGet(ctx context.Context, key string, expires time.Duration) (string, error) {
+	return s.getURL(key, expires), nil
+}
+
+func (s *LocalStore) PresignPut(ctx context.Context, key, contentType string, size int64, expires time.Duration) (string, http.Header, error) {
+	url, header := s.putURL(key, contentType, size, expires)
+	return url, header, nil
+}
+
+func localError(err error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return ErrNotFound
+	}
+	return err
+}
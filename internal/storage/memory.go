@@ -0,0 +1,158 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+type memoryObject struct {
+	data         []byte
+	contentType  string
+	etag         string
+	lastModified time.Time
+}
+
+// MemoryStore, dosyaları bellekte tutar; testlerde kullanılır. İmzalı URL'ler local
+// sürücüde olduğu gibi API üzerinden sunulur.
+type MemoryStore struct {
+	*urlSigner
+	mu      sync.RWMutex
+	objects map[string]memoryObject
+}
+
+func NewMemoryStore(signer *urlSigner) *MemoryStore {
+	return &MemoryStore{urlSigner: signer, objects: make(map[string]memoryObject)}
+}
+
+func (s *MemoryStore) Put(ctx context.Context, key string, body io.Reader, contentType string) error {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	sum := md5.Sum(data)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.objects[key] = memoryObject{
+		data:         data,
+		contentType:  contentType,
+		etag:         `"` + hex.EncodeToString(sum[:]) + `"`,
+		lastModified: time.Now(),
+	}
+	return nil
+}
+
+func (s *MemoryStore) Get(ctx context.Context, key string, opts GetOptions) (*Object, error) {
+	s.mu.RLock()
+	object, ok := s.objects[key]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, ErrNotFound
+	}
+	if etagMatches(opts.IfNoneMatch, object.etag) {
+		return nil, ErrNotModified
+	}
+
+	size := int64(len(object.data))
+	start, end, ranged, err := parseRange(opts.Range, size)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Object{
+		Body:          io.NopCloser(bytes.NewReader(object.data)),
+		ContentLength: size,
+		ContentType:   object.contentType,
+		ETag:          object.etag,
+		LastModified:  object.lastModified,
+	}
+	if ranged {
+		result.Body = io.NopCloser(bytes.NewReader(object.data[start : end+1]))
+		result.ContentLength = end - start + 1
+		result.ContentRange = fmt.Sprintf("bytes %d-%d/%d", start, end, size)
+	}
+	return result, nil
+}
+
+func (s *MemoryStore) Head(ctx context.Context, key string) (*ObjectInfo, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	object, ok := s.objects[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &ObjectInfo{
+		Key:          key,
+		Size:         int64(len(object.data)),
+		ContentType:  object.contentType,
+		ETag:         object.etag,
+		LastModified: object.lastModified,
+	}, nil
+}
+
+func (s *MemoryStore) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.objects, key)
+	return nil
+}
+
+func (s *MemoryStore) List(ctx context.Context, prefix string, fn func(ObjectInfo) error) error {
+	s.mu.RLock()
+	infos := make([]ObjectInfo, 0)
+	for key, object := range s.objects {
+		if strings.HasPrefix(key, prefix) {
+			infos = append(infos, ObjectInfo{
+				Key:          key,
+				Size:         int64(len(object.data)),
+				ContentType:  object.contentType,
+				ETag:         object.etag,
+				LastModified: object.lastModified,
+			})
+		}
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Key < infos[j].Key })
+	for _, info := range infos {
+		if err := fn(info); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *MemoryStore) PresignGet(ctx context.Context, key string, expires time.Duration) (string, error) {
+	return s.getURL(key, expires), nil
+}
+
+func (s *MemoryStore) PresignPut(ctx context.Context, key, contentType string, size int64, expires time.Duration) (string, http.Header, error) {
+	url, header := s.putURL(key, contentType, size, expires)
+	return url, header, nil
+}
+
+// Keys, saklanan dosyaların anahtarlarını sıralı olarak döndürür
+func (s *MemoryStore) Keys() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	keys := make([]string, 0, len(s.objects))
+	for key := range s.objects {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
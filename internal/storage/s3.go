@@ -0,0 +1,244 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+// S3Store, dosyaları S3 uyumlu bir bucket'ta saklar: Cloudflare R2, AWS S3 veya MinIO
+type S3Store struct {
+	client *s3.Client
+	bucket string
+}
+
+// NewS3Store, endpoint boşsa AWS'nin bölgeye göre varsayılan adresini kullanır.
+// MinIO ve R2 path-style adresleme gerektirir.
+func NewS3Store(endpoint, region, accessKeyID, accessKeySecret, bucket string, usePathStyle bool) (*S3Store, error) {
+	if bucket == "" {
+		return nil, errors.New("S3 bucket adı boş olamaz")
+	}
+	if region == "" {
+		region = "auto"
+	}
+
+	client := s3.New(s3.Options{
+		Credentials:  credentials.NewStaticCredentialsProvider(accessKeyID, accessKeySecret, ""),
+		Region:       region,
+		UsePathStyle: usePathStyle,
+	}, func(o *s3.Options) {
+		if endpoint != "" {
+			o.BaseEndpoint = aws.String(endpoint)
+		}
+	})
+
+	return &S3Store{client: client, bucket: bucket}, nil
+}
+
+func (s *S3Store) Put(ctx context.Context, key string, body io.Reader, contentType string) error {
+	input := &s3.PutObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+		Body:   body,
+	}
+	if contentType != "" {
+		input.ContentType = aws.String(contentType)
+	}
+
+	_, err := s.client.PutObject(ctx, input)
+	return err
+}
+
+func (s *S3Store) Get(ctx context.Context, key string, opts GetOptions) (*Object, error) {
+	input := &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	}
+	if opts.Range != "" {
+		input.Range = aws.String(opts.Range)
+	}
+	if opts.IfNoneMatch != "" {
+		input.IfNoneMatch = aws.String(opts.IfNoneMatch)
+	}
+
+	output, err := s.client.GetObject(ctx, input)
+	if err != nil {
+		return nil, s3Error(err)
+	}
+
+	return &Object{
+		Body:          output.Body,
+		ContentLength: aws.ToInt64(output.ContentLength),
+		ContentType:   aws.ToString(output.ContentType),
+		ContentRange:  aws.ToString(output.ContentRange),
+		ETag:          aws.ToString(output.ETag),
+		LastModified:  aws.ToTime(output.LastModified),
+	}, nil
+}
+
+func (s *S3Store) Head(ctx context.Context, key string) (*ObjectInfo, error) {
+	output, err := s.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, s3Error(err)
+	}
+
+	return &ObjectInfo{
+		Key:          key,
+		Size:         aws.ToInt64(output.ContentLength),
+		ContentType:  aws.ToString(output.ContentType),
+		ETag:         aws.ToString(output.ETag),
+		LastModified: aws.ToTime(output.LastModified),
+	}, nil
+}
+
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	_, err := s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	return err
+}
+
+func (s *S3Store) List(ctx context.Context, prefix string, fn func(ObjectInfo) error) error {
+	paginator := s3.NewListObjectsV2Paginator(s.client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(s.bucket),
+		Prefix: aws.String(prefix),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return err
+		}
+		for _, object := range page.Contents {
+			err := fn(ObjectInfo{
+				Key:          aws.ToString(object.Key),
+				Size:         aws.ToInt64(object.Size),
+				ETag:         aws.ToString(object.ETag),
+				LastModified: aws.ToTime(object.LastModified),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *S3Store) PresignGet(ctx context.Context, key string, expires time.Duration) (string, error) {
+	request, err := s3.NewPresignClient(s.client).PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	}, s3.WithPresignExpires(expires))
+	if err != nil {
+		return "", err
+	}
+	return request.URL, nil
+}
+
+func (s *S3Store) PresignPut(ctx context.Context, key, contentType string, size int64, expires time.Duration) (string, http.Header, error) {
+	request, err := s3.NewPresignClient(s.client).PresignPutObject(ctx, &s3.PutObjectInput{
+		Bucket:        aws.String(s.bucket),
+		Key:           aws.String(key),
+		ContentType:   aws.String(contentType),
+		ContentLength: aws.Int64(size),
+	}, s3.WithPresignExpires(expires))
+	if err != nil {
+		return "", nil, err
+	}
+	return request.URL, request.SignedHeader, nil
+}
+
+func (s *S3Store) CreateMultipartUpload(ctx context.Context, key, contentType string) (string, error) {
+	input := &s3.CreateMultipartUploadInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	}
+	if contentType != "" {
+		input.ContentType = aws.String(contentType)
+	}
+
+	output, err := s.client.CreateMultipartUpload(ctx, input)
+	if err != nil {
+		return "", err
+	}
+	return aws.ToString(output.UploadId), nil
+}
+
+func (s *S3Store) UploadPart(ctx context.Context, key, uploadID string, partNumber int32, data []byte) error {
+	_, err := s.client.UploadPart(ctx, &s3.UploadPartInput{
+		Bucket:     aws.String(s.bucket),
+		Key:        aws.String(key),
+		UploadId:   aws.String(uploadID),
+		PartNumber: aws.Int32(partNumber),
+		Body:       bytes.NewReader(data),
+	})
+	return err
+}
+
+// CompleteMultipartUpload, yüklenen parçaları sırayla birleştirerek dosyayı oluşturur
+func (s *S3Store) CompleteMultipartUpload(ctx context.Context, key, uploadID string) error {
+	var parts []types.CompletedPart
+	paginator := s3.NewListPartsPaginator(s.client, &s3.ListPartsInput{
+		Bucket:   aws.String(s.bucket),
+		Key:      aws.String(key),
+		UploadId: aws.String(uploadID),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("parçalar listelenemedi: %v", err)
+		}
+		for _, part := range page.Parts {
+			parts = append(parts, types.CompletedPart{
+				ETag:       part.ETag,
+				PartNumber: part.PartNumber,
+			})
+		}
+	}
+
+	_, err := s.client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(s.bucket),
+		Key:             aws.String(key),
+		UploadId:        aws.String(uploadID),
+		MultipartUpload: &types.CompletedMultipartUpload{Parts: parts},
+	})
+	return err
+}
+
+func (s *S3Store) AbortMultipartUpload(ctx context.Context, key, uploadID string) error {
+	_, err := s.client.AbortMultipartUpload(ctx, &s3.AbortMultipartUploadInput{
+		Bucket:   aws.String(s.bucket),
+		Key:      aws.String(key),
+		UploadId: aws.String(uploadID),
+	})
+	return err
+}
+
+// s3Error, SDK hatalarını sürücülerin ortak hatalarına çevirir
+func s3Error(err error) error {
+	var responseErr *awshttp.ResponseError
+	if errors.As(err, &responseErr) {
+		switch responseErr.HTTPStatusCode() {
+		case http.StatusNotFound:
+			return ErrNotFound
+		case http.StatusNotModified:
+			return ErrNotModified
+		case http.StatusRequestedRangeNotSatisfiable:
+			return ErrInvalidRange
+		}
+	}
+	return err
+}
@@ -0,0 +1,131 @@
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FilesPath, local ve memory sürücülerinin imzalı URL'lerinin API'deki yoludur
+const FilesPath = "/api/files/"
+
+// urlSigner, bucket'ı olmayan sürücüler için S3'ün imzalı URL'lerine benzer bağlantılar
+// üretir: <BaseURL>/api/files/<key>?expires=...&signature=... İmza HMAC-SHA256'dır ve
+// PUT isteklerinde içerik türü ile boyutu da kapsar.
+type urlSigner struct {
+	baseURL string
+	key     []byte
+}
+
+func newURLSigner(baseURL, key string) *urlSigner {
+	return &urlSigner{baseURL: strings.TrimSuffix(baseURL, "/"), key: []byte(key)}
+}
+
+func (s *urlSigner) signature(method, key string, expires int64, contentType string, size int64) string {
+	mac := hmac.New(sha256.New, s.key)
+	fmt.Fprintf(mac, "%s\n%s\n%d\n%s\n%d", method, key, expires, contentType, size)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *urlSigner) url(method, key string, expires time.Duration, contentType string, size int64) string {
+	expiresAt := time.Now().Add(expires).Unix()
+	query := url.Values{
+		"expires":   {strconv.FormatInt(expiresAt, 10)},
+		"signature": {s.signature(method, key, expiresAt, contentType, size)},
+	}
+	return s.baseURL + FilesPath + (&url.URL{Path: key}).EscapedPath() + "?" + query.Encode()
+}
+
+func (s *urlSigner) getURL(key string, expires time.Duration) string {
+	return s.url(http.MethodGet, key, expires, "", 0)
+}
+
+func (s *urlSigner) putURL(key, contentType string, size int64, expires time.Duration) (string, http.Header) {
+	header := http.Header{}
+	header.Set("Content-Type", contentType)
+	header.Set("Content-Length", strconv.FormatInt(size, 10))
+	return s.url(http.MethodPut, key, expires, contentType, size), header
+}
+
+func (s *urlSigner) verify(method, key, expires, signature, contentType string, size int64) error {
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || time.Now().Unix() > expiresAt {
+		return ErrInvalidSignature
+	}
+	if !hmac.Equal([]byte(signature), []byte(s.signature(method, key, expiresAt, contentType, size))) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func (s *urlSigner) VerifyGet(key, expires, signature string) error {
+	return s.verify(http.MethodGet, key, expires, signature, "", 0)
+}
+
+func (s *urlSigner) VerifyPut(key, expires, signature, contentType string, size int64) error {
+	return s.verify(http.MethodPut, key, expires, signature, contentType, size)
+}
+
+// parseRange, tek aralıklı bir Range başlığını dosya boyutuna göre [start, end] olarak
+// çözer. Başlık boşsa ok false döner; aralık dosyanın dışındaysa ErrInvalidRange döner.
+func parseRange(header string, size int64) (start, end int64, ok bool, err error) {
+	if header == "" {
+		return 0, 0, false, nil
+	}
+	spec, found := strings.CutPrefix(header, "bytes=")
+	if !found || strings.Contains(spec, ",") {
+		return 0, 0, false, ErrInvalidRange
+	}
+	first, last, found := strings.Cut(spec, "-")
+	if !found {
+		return 0, 0, false, ErrInvalidRange
+	}
+
+	if first == "" {
+		// bytes=-N: son N bayt
+		n, err := strconv.ParseInt(last, 10, 64)
+		if err != nil || n <= 0 || size == 0 {
+			return 0, 0, false, ErrInvalidRange
+		}
+		if n > size {
+			n = size
+		}
+		return size - n, size - 1, true, nil
+	}
+
+	start, err = strconv.ParseInt(first, 10, 64)
+	if err != nil || start < 0 || start >= size {
+		return 0, 0, false, ErrInvalidRange
+	}
+	end = size - 1
+	if last != "" {
+		end, err = strconv.ParseInt(last, 10, 64)
+		if err != nil || end < start {
+			return 0, 0, false, ErrInvalidRange
+		}
+		if end >= size {
+			end = size - 1
+		}
+	}
+	return start, end, true, nil
+}
+
+// etagMatches, If-None-Match başlığındaki ETag listesinde etag olup olmadığını kontrol eder
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
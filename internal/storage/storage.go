@@ -0,0 +1,109 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"shortcast/internal/config"
+	"time"
+)
+
+// Sürücülerin ortak hataları
+var (
+	ErrNotFound         = errors.New("dosya bulunamadı")
+	ErrNotModified      = errors.New("dosya değişmedi")
+	ErrInvalidRange     = errors.New("geçersiz aralık")
+	ErrInvalidSignature = errors.New("imzalı bağlantı geçersiz veya süresi dolmuş")
+)
+
+// ObjectInfo, depolanan bir dosyanın bilgileridir
+type ObjectInfo struct {
+	Key          string
+	Size         int64
+	ContentType  string
+	ETag         string
+	LastModified time.Time
+}
+
+// Object, Get ile okunan dosyanın gövdesi ve bilgileridir. Body kapatılmalıdır.
+type Object struct {
+	Body          io.ReadCloser
+	ContentLength int64 // Gövdenin uzunluğu; aralıklı isteklerde dosya boyutundan küçüktür
+	ContentType   string
+	// ContentRange yalnızca aralıklı isteklerde doludur, örn. "bytes 0-1023/4096"
+	ContentRange string
+	ETag         string
+	LastModified time.Time
+}
+
+// GetOptions, Get isteğinin isteğe bağlı HTTP koşullarıdır
+type GetOptions struct {
+	Range       string // örn. "bytes=0-1023"; çoklu aralıklar desteklenmez
+	IfNoneMatch string // ETag eşleşirse ErrNotModified döner
+}
+
+// BlobStore, medya dosyalarının saklandığı depolama altyapısını soyutlar. Üretimde
+// R2/S3 uyumlu bir bucket, yerelde disk ya da bellek kullanılabilir.
+type BlobStore interface {
+	// Put, okuyucudaki veriyi key ile kaydeder. contentType boşsa sürücü varsayılanı kullanılır.
+	Put(ctx context.Context, key string, body io.Reader, contentType string) error
+	Get(ctx context.Context, key string, opts GetOptions) (*Object, error)
+	Head(ctx context.Context, key string) (*ObjectInfo, error)
+	// Delete, dosyayı siler; dosya yoksa hata döndürmez
+	Delete(ctx context.Context, key string) error
+	// List, anahtarı prefix ile başlayan dosyaları sırayla fn'e verir
+	List(ctx context.Context, prefix string, fn func(ObjectInfo) error) error
+	// PresignGet, dosyanın Authorization başlığı olmadan indirilebileceği süreli bir URL oluşturur
+	PresignGet(ctx context.Context, key string, expires time.Duration) (string, error)
+	// PresignPut, istemcinin dosyayı doğrudan yükleyebileceği süreli bir PUT isteği oluşturur.
+	// Content-Type ve Content-Length imzaya dahildir; istemcinin göndermesi gereken başlıklar da döner.
+	PresignPut(ctx context.Context, key, contentType string, size int64, expires time.Duration) (string, http.Header, error)
+}
+
+// MultipartStore, dosyayı parça parça yüklemeyi destekleyen sürücülerin ek yetenekleridir
+type MultipartStore interface {
+	CreateMultipartUpload(ctx context.Context, key, contentType string) (string, error)
+	// UploadPart, son parça hariç en az 5 MB'lık parçaları kabul eder
+	UploadPart(ctx context.Context, key, uploadID string, partNumber int32, data []byte) error
+	CompleteMultipartUpload(ctx context.Context, key, uploadID string) error
+	AbortMultipartUpload(ctx context.Context, key, uploadID string) error
+}
+
+// SignedStore, imzalı URL'leri API üzerinden sunan sürücülerin (local, memory) imza
+// doğrulamasıdır. S3 sürücüsünde imzayı bucket doğruladığından bu arayüz uygulanmaz.
+type SignedStore interface {
+	VerifyGet(key, expires, signature string) error
+	VerifyPut(key, expires, signature, contentType string, size int64) error
+}
+
+// New, yapılandırmadaki sürücüye göre uygun BlobStore'u döndürür. local ve memory
+// sürücülerinin imzalı URL'lerini API doğruladığından bu sürücüler açıkça tanımlanmış ve
+// varsayılan olmayan bir imza anahtarı olmadan oluşturulmaz; aksi halde herkes dosyaları
+// okuyup üzerine yazabilecek imzalar üretebilirdi.
+func New(cfg config.StorageConfig) (BlobStore, error) {
+	switch cfg.Driver {
+	case "s3", "r2", "":
+		return NewS3Store(cfg.Endpoint, cfg.Region, cfg.AccessKeyID, cfg.AccessKeySecret, cfg.Bucket, cfg.UsePathStyle)
+	case "local":
+		if err := checkSigningKey(cfg.SigningKey); err != nil {
+			return nil, err
+		}
+		return NewLocalStore(cfg.LocalDir, newURLSigner(cfg.BaseURL, cfg.SigningKey))
+	case "memory":
+		if err := checkSigningKey(cfg.SigningKey); err != nil {
+			return nil, err
+		}
+		return NewMemoryStore(newURLSigner(cfg.BaseURL, cfg.SigningKey)), nil
+	default:
+		return nil, fmt.Errorf("bilinmeyen depolama sürücüsü: %s", cfg.Driver)
+	}
+}
+
+func checkSigningKey(key string) error {
+	if key == "" || key == config.DefaultSecretKey {
+		return errors.New("local ve memory sürücüleri için varsayılan olmayan bir STORAGE_SIGNING_KEY tanımlanmalı")
+	}
+	return nil
+}
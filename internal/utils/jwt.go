@@ -1,17 +1,56 @@
 package utils
 
 import (
+	"errors"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
-func GenerateJWT(userID uint, secret string) (string, error) {
+const (
+	TokenTypeAccess             = "access"
+	TokenTypeTwoFactorChallenge = "2fa_challenge"
+)
+
+// GenerateJWT, verilen oturum için kısa ömürlü bir access token üretir
+func GenerateJWT(keys *KeySet, userID, sessionID uint, ttl time.Duration) (string, error) {
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"user_id": userID,
+		"sid":     sessionID,
+		"typ":     TokenTypeAccess,
+		"iat":     now.Unix(),
+		"exp":     now.Add(ttl).Unix(),
+	}
+
+	return keys.Sign(claims)
+}
+
+// GenerateChallengeJWT, şifresi doğrulanmış ancak ikinci adımı tamamlanmamış
+// kullanıcı için kısa ömürlü bir token üretir. Bu token API'ye erişim sağlamaz.
+func GenerateChallengeJWT(keys *KeySet, userID uint, ttl time.Duration) (string, error) {
+	now := time.Now()
 	claims := jwt.MapClaims{
 		"user_id": userID,
-		"exp":     time.Now().Add(time.Hour * 72).Unix(),
+		"typ":     TokenTypeTwoFactorChallenge,
+		"iat":     now.Unix(),
+		"exp":     now.Add(ttl).Unix(),
+	}
+
+	return keys.Sign(claims)
+}
+
+// ParseJWT, token'ı doğrular ve beklenen türde olduğunu kontrol eder
+func ParseJWT(keys *KeySet, tokenString, expectedType string) (jwt.MapClaims, error) {
+	token, err := jwt.Parse(tokenString, keys.Keyfunc, jwt.WithValidMethods(keys.ValidMethods()))
+	if err != nil || !token.Valid || !keys.ValidIssuer(token) {
+		return nil, errors.New("geçersiz token")
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || claims["typ"] != expectedType {
+		return nil, errors.New("geçersiz token")
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(secret))
+	return claims, nil
 }
@@ -0,0 +1,403 @@
+package utils
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// hsKeyID, SECRET_KEY ile oluşturulan HS256 anahtarının kid'idir. kid header'ı
+// olmayan eski token'lar da bu anahtarla doğrulanır.
+const hsKeyID = "hs256"
+
+// SigningKey, kid ile tanımlanan tek bir JWT anahtarıdır. Private alanı boşsa
+// anahtar yalnızca doğrulama için kullanılır (rotasyonla emekliye ayrılmış anahtarlar).
+type SigningKey struct {
+	ID      string
+	Method  jwt.SigningMethod
+	Private interface{}
+	Public  interface{}
+}
+
+// KeySet, token imzalamak için aktif anahtarı ve doğrulamada kabul edilen tüm
+// anahtarları tutar. Yeni bir anahtar eklenip aktif yapıldığında eski anahtarla
+// imzalanmış token'lar süreleri dolana kadar geçerli kalır.
+type KeySet struct {
+	active *SigningKey
+	keys   map[string]*SigningKey
+	issuer string
+}
+
+type KeySetOptions struct {
+	Algorithm    string // HS256, RS256 veya EdDSA
+	KeysDir      string
+	ActiveKeyID  string
+	Secret       string
+	AcceptLegacy bool
+	Issuer       string
+}
+
+// LoadKeySet, seçilen algoritmaya göre anahtarları yükler. Asimetrik algoritmalarda
+// KeysDir içindeki her "<kid>.pem" dosyası bir private key, her "<kid>.pub.pem"
+// dosyası ise yalnızca doğrulamada kullanılan bir public key olarak okunur. Dizin yoksa
+// ya da boşsa yeni bir anahtar üretilip dizine kaydedilir.
+func LoadKeySet(opts KeySetOptions) (*KeySet, error) {
+	ks := &KeySet{keys: make(map[string]*SigningKey), issuer: opts.Issuer}
+
+	if opts.Algorithm == "" || opts.Algorithm == jwt.SigningMethodHS256.Alg() {
+		key := &SigningKey{
+			ID:      hsKeyID,
+			Method:  jwt.SigningMethodHS256,
+			Private: []byte(opts.Secret),
+			Public:  []byte(opts.Secret),
+		}
+		ks.keys[key.ID] = key
+		ks.active = key
+		return ks, nil
+	}
+
+	var method jwt.SigningMethod
+	switch opts.Algorithm {
+	case jwt.SigningMethodRS256.Alg():
+		method = jwt.SigningMethodRS256
+	case jwt.SigningMethodEdDSA.Alg():
+		method = jwt.SigningMethodEdDSA
+	default:
+		return nil, fmt.Errorf("desteklenmeyen JWT algoritması: %s", opts.Algorithm)
+	}
+
+	if opts.KeysDir != "" {
+		if err := ks.loadDir(opts.KeysDir, method); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(ks.keys) == 0 {
+		key, err := generateKey(method)
+		if err != nil {
+			return nil, err
+		}
+		// Üretilen anahtar kaydedilemezse yalnızca bellekte tutulur; yeniden başlatmada tüm
+		// token'lar geçersiz olur
+		if err := saveKey(opts.KeysDir, key); err != nil {
+			fmt.Printf("JWT - UYARI: %s dizininde anahtar bulunamadı ve yeni anahtar kaydedilemedi (%v), geçici bir %s anahtarı kullanılıyor (kid: %s)\n", opts.KeysDir, err, method.Alg(), key.ID)
+		} else {
+			fmt.Printf("JWT - %s dizininde anahtar bulunamadı, yeni bir %s anahtarı üretildi (kid: %s)\n", opts.KeysDir, method.Alg(), key.ID)
+		}
+		ks.keys[key.ID] = key
+	}
+
+	if err := ks.selectActive(opts.ActiveKeyID); err != nil {
+		return nil, err
+	}
+
+	// Asimetrik imzalamaya geçişte eski HS256 token'ları süreleri dolana kadar kabul et
+	if opts.AcceptLegacy && opts.Secret != "" {
+		ks.keys[hsKeyID] = &SigningKey{
+			ID:     hsKeyID,
+			Method: jwt.SigningMethodHS256,
+			Public: []byte(opts.Secret),
+		}
+	}
+
+	return ks, nil
+}
+
+func (ks *KeySet) loadDir(dir string, method jwt.SigningMethod) error {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("JWT anahtar dizini okunamadı: %w", err)
+	}
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".pem") {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return err
+		}
+
+		if strings.HasSuffix(name, ".pub.pem") {
+			kid := strings.TrimSuffix(name, ".pub.pem")
+			if _, exists := ks.keys[kid]; exists {
+				continue // Private key'i zaten yüklendi
+			}
+			public, err := parsePublicKey(data, method)
+			if err != nil {
+				return fmt.Errorf("%s okunamadı: %w", name, err)
+			}
+			ks.keys[kid] = &SigningKey{ID: kid, Method: method, Public: public}
+			continue
+		}
+
+		kid := strings.TrimSuffix(name, ".pem")
+		private, public, err := parsePrivateKey(data, method)
+		if err != nil {
+			return fmt.Errorf("%s okunamadı: %w", name, err)
+		}
+		ks.keys[kid] = &SigningKey{ID: kid, Method: method, Private: private, Public: public}
+	}
+
+	return nil
+}
+
+// selectActive, imzalama anahtarını seçer. Belirtilmemişse private key'i olan
+// anahtarlardan kid'i alfabetik olarak en büyük olan (ör. tarih önekli) kullanılır.
+func (ks *KeySet) selectActive(activeKeyID string) error {
+	if activeKeyID != "" {
+		key, ok := ks.keys[activeKeyID]
+		if !ok || key.Private == nil {
+			return fmt.Errorf("aktif JWT anahtarı bulunamadı: %s", activeKeyID)
+		}
+		ks.active = key
+		return nil
+	}
+
+	ids := make([]string, 0, len(ks.keys))
+	for id, key := range ks.keys {
+		if key.Private != nil {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return errors.New("imzalama için private key bulunamadı")
+	}
+
+	sort.Strings(ids)
+	ks.active = ks.keys[ids[len(ids)-1]]
+	return nil
+}
+
+// Sign, claim'leri aktif anahtarla imzalar ve header'a kid ekler
+func (ks *KeySet) Sign(claims jwt.MapClaims) (string, error) {
+	if _, ok := claims["iss"]; !ok && ks.issuer != "" {
+		claims["iss"] = ks.issuer
+	}
+
+	token := jwt.NewWithClaims(ks.active.Method, claims)
+	token.Header["kid"] = ks.active.ID
+	return token.SignedString(ks.active.Private)
+}
+
+// ValidIssuer, token'ın bu servis tarafından üretildiğini iss claim'inden kontrol eder.
+// iss eklenmeden önce üretilmiş, kid'i olmayan eski HS256 token'larında claim aranmaz.
+func (ks *KeySet) ValidIssuer(token *jwt.Token) bool {
+	if ks.issuer == "" {
+		return true
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return false
+	}
+	iss, exists := claims["iss"]
+	if !exists {
+		_, hasKid := token.Header["kid"]
+		return !hasKid
+	}
+	return iss == ks.issuer
+}
+
+// Keyfunc, token header'ındaki kid'e göre doğrulama anahtarını bulur.
+// Algoritma karışıklığı saldırılarını önlemek için token'ın algoritması anahtarınkiyle aynı olmalıdır.
+func (ks *KeySet) Keyfunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		kid = hsKeyID
+	}
+
+	key, ok := ks.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("bilinmeyen anahtar: %s", kid)
+	}
+
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, fmt.Errorf("beklenmeyen imza algoritması: %s", token.Method.Alg())
+	}
+
+	return key.Public, nil
+}
+
+// ValidMethods, doğrulamada kabul edilen imza algoritmalarını döndürür
+func (ks *KeySet) ValidMethods() []string {
+	seen := make(map[string]bool)
+	methods := make([]string, 0, 2)
+	for _, key := range ks.keys {
+		if alg := key.Method.Alg(); !seen[alg] {
+			seen[alg] = true
+			methods = append(methods, alg)
+		}
+	}
+	return methods
+}
+
+// JWK, RFC 7517'deki JSON Web Key formatıdır
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS, doğrulamada kabul edilen asimetrik anahtarların public kısımlarını döndürür.
+// HS256 anahtarları paylaşılan secret olduğu için asla yayınlanmaz.
+func (ks *KeySet) JWKS() JWKSet {
+	set := JWKSet{Keys: make([]JWK, 0, len(ks.keys))}
+
+	ids := make([]string, 0, len(ks.keys))
+	for id := range ks.keys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		key := ks.keys[id]
+		switch public := key.Public.(type) {
+		case *rsa.PublicKey:
+			set.Keys = append(set.Keys, JWK{
+				Kty: "RSA",
+				Kid: key.ID,
+				Use: "sig",
+				Alg: key.Method.Alg(),
+				N:   base64.RawURLEncoding.EncodeToString(public.N.Bytes()),
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes()),
+			})
+		case ed25519.PublicKey:
+			set.Keys = append(set.Keys, JWK{
+				Kty: "OKP",
+				Kid: key.ID,
+				Use: "sig",
+				Alg: key.Method.Alg(),
+				Crv: "Ed25519",
+				X:   base64.RawURLEncoding.EncodeToString(public),
+			})
+		}
+	}
+
+	return set
+}
+
+func parsePrivateKey(data []byte, method jwt.SigningMethod) (interface{}, interface{}, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, nil, errors.New("PEM bloğu bulunamadı")
+	}
+
+	var parsed interface{}
+	var err error
+	if block.Type == "RSA PRIVATE KEY" {
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	} else {
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch key := parsed.(type) {
+	case *rsa.PrivateKey:
+		if method != jwt.SigningMethodRS256 {
+			return nil, nil, errors.New("RSA anahtarı yalnızca RS256 ile kullanılabilir")
+		}
+		return key, &key.PublicKey, nil
+	case ed25519.PrivateKey:
+		if method != jwt.SigningMethodEdDSA {
+			return nil, nil, errors.New("Ed25519 anahtarı yalnızca EdDSA ile kullanılabilir")
+		}
+		return key, key.Public(), nil
+	default:
+		return nil, nil, errors.New("desteklenmeyen anahtar türü")
+	}
+}
+
+func parsePublicKey(data []byte, method jwt.SigningMethod) (interface{}, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("PEM bloğu bulunamadı")
+	}
+
+	parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	switch key := parsed.(type) {
+	case *rsa.PublicKey:
+		if method != jwt.SigningMethodRS256 {
+			return nil, errors.New("RSA anahtarı yalnızca RS256 ile kullanılabilir")
+		}
+		return key, nil
+	case ed25519.PublicKey:
+		if method != jwt.SigningMethodEdDSA {
+			return nil, errors.New("Ed25519 anahtarı yalnızca EdDSA ile kullanılabilir")
+		}
+		return key, nil
+	default:
+		return nil, errors.New("desteklenmeyen anahtar türü")
+	}
+}
+
+func generateKey(method jwt.SigningMethod) (*SigningKey, error) {
+	kid, err := GenerateRandomToken(8)
+	if err != nil {
+		return nil, err
+	}
+
+	var private crypto.Signer
+	if method == jwt.SigningMethodRS256 {
+		private, err = rsa.GenerateKey(rand.Reader, 2048)
+	} else {
+		_, private, err = ed25519.GenerateKey(rand.Reader)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// kid tarih önekli olduğundan sonradan eklenen anahtarlarla alfabetik sıralama korunur
+	id := time.Now().UTC().Format("2006-01-02") + "-" + kid
+	return &SigningKey{ID: id, Method: method, Private: private, Public: private.Public()}, nil
+}
+
+// saveKey, üretilen private key'i dizine "<kid>.pem" olarak yazar; dizin yoksa oluşturur
+func saveKey(dir string, key *SigningKey) error {
+	if dir == "" {
+		return errors.New("JWT_KEYS_DIR tanımlı değil")
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key.Private)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	data := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	return os.WriteFile(filepath.Join(dir, key.ID+".pem"), data, 0o600)
+}
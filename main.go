@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	_ "shortcast/docs"
 	"shortcast/internal/container"
 	"shortcast/internal/router"
@@ -42,6 +43,10 @@ func main() {
 	router.SetupAPIRoutes(app, cont)
 	router.SetupDocsRoutes(app)
 
+	cont.Worker.Start(context.Background())
+	cont.PodcastService.StartUploadCleanup(context.Background())
+	cont.TusService.StartCleanup(context.Background())
+
 	app.Listen(":8080")
 
 }